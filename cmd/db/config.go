@@ -2,6 +2,12 @@ package main
 
 import "github.com/rendyananta/example-online-book-store/internal/config"
 
+// defaultInitialBookStock matches the stock of the seeded books.
+const defaultInitialBookStock = 100
+
 type BinaryConfig struct {
 	App config.App
+
+	// InitialBookStock is the stock given to the existing books when the stock column is added to them.
+	InitialBookStock int
 }
@@ -19,7 +19,8 @@ type Migration interface {
 // simple migrations binary
 func main() {
 	cfg := BinaryConfig{
-		App: config.LoadAppConfig(),
+		App:              config.LoadAppConfig(),
+		InitialBookStock: config.LoadFromEnvInt("DB_MIGRATION_INITIAL_BOOK_STOCK", defaultInitialBookStock),
 	}
 
 	var upCmd = true
@@ -59,8 +60,24 @@ func main() {
 		&migrations.CreateBooksTable{Conn: defaultConn},
 		&migrations.CreateBooksAuthorsTable{Conn: defaultConn},
 		&migrations.CreateBooksGenresTable{Conn: defaultConn},
+		&migrations.CreateBookSearchIndexTable{Conn: defaultConn},
 		&migrations.CreateOrdersTable{Conn: defaultConn},
 		&migrations.CreateOrderLinesTable{Conn: defaultConn},
+		&migrations.CreateBookStockAdjustmentsTable{Conn: defaultConn},
+		&migrations.CreateOrderStatusHistoriesTable{Conn: defaultConn},
+		&migrations.CreateOrderTransactionsTable{Conn: defaultConn},
+		&migrations.CreateCartsTable{Conn: defaultConn},
+		&migrations.CreateVouchersTable{Conn: defaultConn},
+		&migrations.CreateUserAddressesTable{Conn: defaultConn},
+		&migrations.CreateExchangeRatesTable{Conn: defaultConn},
+		&migrations.CreateInvoicesTable{Conn: defaultConn},
+		&migrations.CreateOutboxEventsTable{Conn: defaultConn},
+		&migrations.CreateWebhookTables{Conn: defaultConn},
+		&migrations.AddUsersRoleColumn{Conn: defaultConn},
+		&migrations.AddBooksStockColumn{Conn: defaultConn, InitialStock: cfg.InitialBookStock},
+		&migrations.AddShippingColumns{Conn: defaultConn},
+		&migrations.ConvertMoneyColumns{Conn: defaultConn},
+		&migrations.AddOrdersExchangeRateColumns{Conn: defaultConn},
 	}
 
 	if upCmd {
@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 )
 
@@ -33,8 +35,17 @@ func main() {
 	mux := http.NewServeMux()
 
 	handlers.Auth.Handle(mux)
+	handlers.UserAdmin.Handle(mux)
 	handlers.Book.Handle(mux)
+	handlers.BookAdmin.Handle(mux)
 	handlers.Order.Handle(mux)
+	handlers.OrderAdmin.Handle(mux)
+	handlers.Payment.Handle(mux)
+	handlers.Cart.Handle(mux)
+	handlers.VoucherAdmin.Handle(mux)
+	handlers.Address.Handle(mux)
+	handlers.ExchangeRateAdmin.Handle(mux)
+	handlers.WebhookAdmin.Handle(mux)
 
 	slog.Info(fmt.Sprintf("listening http server on :%d", cfg.HTTP.ListenPort))
 
@@ -44,12 +55,25 @@ func main() {
 	}
 
 	go func() {
-		if err := server.ListenAndServe(); err != nil {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			panic(err)
-			return
 		}
 	}()
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	workers.Add(2)
+
+	go func() {
+		defer workers.Done()
+		usecaseModules.EventDispatcher.Run(workersCtx)
+	}()
+
+	go func() {
+		defer workers.Done()
+		usecaseModules.WebhookSender.Run(workersCtx)
+	}()
+
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGKILL, syscall.SIGHUP)
 	<-signalChan
@@ -57,4 +81,8 @@ func main() {
 	if err := server.Shutdown(context.Background()); err != nil {
 		log.Fatalf("Server Shutdown Failed:%+v", err)
 	}
+
+	// the events and the webhook deliveries left by the stopped workers are sent on the next start.
+	stopWorkers()
+	workers.Wait()
 }
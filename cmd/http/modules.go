@@ -1,42 +1,103 @@
 package main
 
 import (
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/address"
 	"github.com/rendyananta/example-online-book-store/internal/presenter/http/book"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/cart"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/exchangerate"
 	"github.com/rendyananta/example-online-book-store/internal/presenter/http/order"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/payment"
 	"github.com/rendyananta/example-online-book-store/internal/presenter/http/user"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/voucher"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/webhook"
+	addressrp "github.com/rendyananta/example-online-book-store/internal/repo/address"
 	bookrp "github.com/rendyananta/example-online-book-store/internal/repo/book"
+	cartrp "github.com/rendyananta/example-online-book-store/internal/repo/cart"
+	exchangeraterp "github.com/rendyananta/example-online-book-store/internal/repo/exchangerate"
+	invoicerp "github.com/rendyananta/example-online-book-store/internal/repo/invoice"
 	orderrp "github.com/rendyananta/example-online-book-store/internal/repo/order"
+	outboxrp "github.com/rendyananta/example-online-book-store/internal/repo/outbox"
+	paymentrp "github.com/rendyananta/example-online-book-store/internal/repo/payment"
 	userrp "github.com/rendyananta/example-online-book-store/internal/repo/user"
+	voucherrp "github.com/rendyananta/example-online-book-store/internal/repo/voucher"
+	webhookrp "github.com/rendyananta/example-online-book-store/internal/repo/webhook"
+	addressuc "github.com/rendyananta/example-online-book-store/internal/usecase/address"
 	bookuc "github.com/rendyananta/example-online-book-store/internal/usecase/book"
+	cartuc "github.com/rendyananta/example-online-book-store/internal/usecase/cart"
+	exchangerateuc "github.com/rendyananta/example-online-book-store/internal/usecase/exchangerate"
+	invoiceuc "github.com/rendyananta/example-online-book-store/internal/usecase/invoice"
 	orderuc "github.com/rendyananta/example-online-book-store/internal/usecase/order"
+	outboxuc "github.com/rendyananta/example-online-book-store/internal/usecase/outbox"
+	paymentuc "github.com/rendyananta/example-online-book-store/internal/usecase/payment"
 	useruc "github.com/rendyananta/example-online-book-store/internal/usecase/user"
+	voucheruc "github.com/rendyananta/example-online-book-store/internal/usecase/voucher"
+	webhookuc "github.com/rendyananta/example-online-book-store/internal/usecase/webhook"
 	"github.com/rendyananta/example-online-book-store/pkg/auth"
 	"github.com/rendyananta/example-online-book-store/pkg/cache"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/idempotency"
+	paymentpkg "github.com/rendyananta/example-online-book-store/pkg/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
+	webhookpkg "github.com/rendyananta/example-online-book-store/pkg/webhook"
 )
 
 type GlobalModules struct {
-	DBConnManager *db.ConnManager
-	CacheManager  *cache.Manager
-	AuthManager   *auth.Manager
+	DBConnManager    *db.ConnManager
+	CacheManager     *cache.Manager
+	AuthManager      *auth.Manager
+	IdempotencyStore *idempotency.Store
+	PaymentManager   *paymentpkg.Manager
+	ShippingManager  *shipping.Manager
+	WebhookClient    *webhookpkg.Client
 }
 
 type RepoModules struct {
-	BookRepo  *bookrp.Repo
-	UserRepo  *userrp.Repo
-	OrderRepo *orderrp.Repo
+	BookRepo         *bookrp.Repo
+	UserRepo         *userrp.Repo
+	OrderRepo        *orderrp.Repo
+	PaymentRepo      *paymentrp.Repo
+	CartRepo         *cartrp.Repo
+	VoucherRepo      *voucherrp.Repo
+	AddressRepo      *addressrp.Repo
+	ExchangeRateRepo *exchangeraterp.Repo
+	InvoiceRepo      *invoicerp.Repo
+	OutboxRepo       *outboxrp.Repo
+	WebhookRepo      *webhookrp.Repo
 }
 
 type UseCaseModules struct {
 	UserAuthentication *useruc.AuthenticatorUseCase
 	UserRegistration   *useruc.RegisterUseCase
+	UserRoleAssignment *useruc.RoleAssignmentUseCase
+	UserSessions       *useruc.SessionUseCase
 	BookQueries        *bookuc.QueriesUseCase
+	CatalogQueries     *bookuc.CatalogQueriesUseCase
+	BookManagement     *bookuc.ManagementUseCase
 	OrderPlacement     *orderuc.PlaceOrderUseCase
 	OrderQueries       *orderuc.QueriesUseCase
+	OrderStatus        *orderuc.StatusUseCase
+	Payment            *paymentuc.UseCase
+	Cart               *cartuc.UseCase
+	VoucherManagement  *voucheruc.ManagementUseCase
+	AddressBook        *addressuc.BookUseCase
+	ExchangeRates      *exchangerateuc.UseCase
+	Invoices           *invoiceuc.UseCase
+	EventDispatcher    *outboxuc.Dispatcher
+	WebhookManagement  *webhookuc.ManagementUseCase
+	WebhookSender      *webhookuc.Sender
 }
 
 type HTTPHandlers struct {
-	Auth  user.Handler
-	Book  book.Handler
-	Order order.Handler
+	Auth              user.Handler
+	UserAdmin         user.AdminHandler
+	Book              book.Handler
+	BookAdmin         book.AdminHandler
+	Order             order.Handler
+	OrderAdmin        order.AdminHandler
+	Payment           payment.Handler
+	Cart              cart.Handler
+	VoucherAdmin      voucher.AdminHandler
+	Address           address.Handler
+	ExchangeRateAdmin exchangerate.AdminHandler
+	WebhookAdmin      webhook.AdminHandler
 }
@@ -7,7 +7,11 @@ import (
 	"github.com/rendyananta/example-online-book-store/pkg/auth"
 	"github.com/rendyananta/example-online-book-store/pkg/cache"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/idempotency"
 	"github.com/rendyananta/example-online-book-store/pkg/log"
+	"github.com/rendyananta/example-online-book-store/pkg/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
+	"github.com/rendyananta/example-online-book-store/pkg/webhook"
 )
 
 func loadGlobalModules(cfg BinaryConfig) GlobalModules {
@@ -35,9 +39,32 @@ func loadGlobalModules(cfg BinaryConfig) GlobalModules {
 		panic(err)
 	}
 
+	idempotencyStore := idempotency.NewStore(cfg.App.Global.Idempotency, cacheManager)
+
+	paymentManager := payment.NewManager(cfg.App.Global.Payment)
+
+	// the fake gateway settles the payment without charging, it is registered only when it is enabled explicitly.
+	if cfg.App.Global.PaymentFakeGateway.Enabled {
+		fakeGateway, err := payment.NewFakeGateway(cfg.App.Global.PaymentFakeGateway)
+		if err != nil {
+			slog.Error("cannot initialize fake payment gateway", slog.String("err", err.Error()))
+			panic(err)
+		}
+
+		paymentManager.Register(payment.GwNameFake, fakeGateway)
+	}
+
+	shippingManager := shipping.NewManager(cfg.App.Global.Shipping)
+	shippingManager.Register(shipping.CalcNameWeight, shipping.NewWeightCalculator(cfg.App.Global.ShippingWeight))
+	shippingManager.Register(shipping.CalcNameItemCount, shipping.NewItemCountCalculator(cfg.App.Global.ShippingItemCount))
+
 	return GlobalModules{
-		DBConnManager: dbManager,
-		CacheManager:  &cacheManager,
-		AuthManager:   authManager,
+		DBConnManager:    dbManager,
+		CacheManager:     &cacheManager,
+		AuthManager:      authManager,
+		IdempotencyStore: idempotencyStore,
+		PaymentManager:   &paymentManager,
+		ShippingManager:  &shippingManager,
+		WebhookClient:    webhook.NewClient(cfg.App.Global.WebhookClient),
 	}
 }
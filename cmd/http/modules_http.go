@@ -1,28 +1,85 @@
 package main
 
 import (
+	useren "github.com/rendyananta/example-online-book-store/internal/entity/user"
 	"github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/address"
 	"github.com/rendyananta/example-online-book-store/internal/presenter/http/book"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/cart"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/exchangerate"
 	"github.com/rendyananta/example-online-book-store/internal/presenter/http/order"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/payment"
 	"github.com/rendyananta/example-online-book-store/internal/presenter/http/user"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/voucher"
+	"github.com/rendyananta/example-online-book-store/internal/presenter/http/webhook"
 	"github.com/rendyananta/example-online-book-store/pkg/auth"
+	"github.com/rendyananta/example-online-book-store/pkg/idempotency"
 )
 
 func loadHTTPHandlers(_ BinaryConfig, globalModules GlobalModules, repoModules RepoModules, useCaseModules UseCaseModules) HTTPHandlers {
 	authMiddleware := auth.NewMiddleware(globalModules.AuthManager, &http.AppResponseWriter{})
+	staffMiddleware := authMiddleware.RequireRole(useren.RoleStaff, useren.RoleAdmin)
+	adminMiddleware := authMiddleware.RequireRole(useren.RoleAdmin)
+	idempotencyMiddleware := idempotency.NewMiddleware(globalModules.IdempotencyStore, &http.AppResponseWriter{})
 
 	return HTTPHandlers{
 		Auth: user.Handler{
-			Register:      useCaseModules.UserRegistration,
-			Authenticator: useCaseModules.UserAuthentication,
+			AuthMiddleware: authMiddleware,
+			Register:       useCaseModules.UserRegistration,
+			Authenticator:  useCaseModules.UserAuthentication,
+			CartMerger:     useCaseModules.Cart,
+			Sessions:       useCaseModules.UserSessions,
+		},
+		UserAdmin: user.AdminHandler{
+			AuthMiddleware: adminMiddleware,
+			RoleAssignment: useCaseModules.UserRoleAssignment,
 		},
 		Book: book.Handler{
 			Queries: useCaseModules.BookQueries,
+			Catalog: useCaseModules.CatalogQueries,
+		},
+		BookAdmin: book.AdminHandler{
+			AuthMiddleware: staffMiddleware,
+			Management:     useCaseModules.BookManagement,
 		},
 		Order: order.Handler{
 			AuthMiddleware:    authMiddleware,
+			Idempotency:       idempotencyMiddleware,
 			PlaceOrderUseCase: useCaseModules.OrderPlacement,
 			Queries:           useCaseModules.OrderQueries,
+			Cancellation:      useCaseModules.OrderStatus,
+			Invoices:          useCaseModules.Invoices,
+		},
+		OrderAdmin: order.AdminHandler{
+			AuthMiddleware:  staffMiddleware,
+			AdminMiddleware: adminMiddleware,
+			Status:          useCaseModules.OrderStatus,
+			Queries:         useCaseModules.OrderQueries,
+			Invoices:        useCaseModules.Invoices,
+		},
+		Payment: payment.Handler{
+			AuthMiddleware: authMiddleware,
+			Payment:        useCaseModules.Payment,
+		},
+		Cart: cart.Handler{
+			AuthMiddleware: authMiddleware,
+			Cart:           useCaseModules.Cart,
+		},
+		VoucherAdmin: voucher.AdminHandler{
+			AuthMiddleware: staffMiddleware,
+			Management:     useCaseModules.VoucherManagement,
+		},
+		Address: address.Handler{
+			AuthMiddleware: authMiddleware,
+			AddressBook:    useCaseModules.AddressBook,
+		},
+		ExchangeRateAdmin: exchangerate.AdminHandler{
+			AuthMiddleware: staffMiddleware,
+			Management:     useCaseModules.ExchangeRates,
+		},
+		WebhookAdmin: webhook.AdminHandler{
+			AuthMiddleware: adminMiddleware,
+			Management:     useCaseModules.WebhookManagement,
 		},
 	}
 }
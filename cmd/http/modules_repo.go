@@ -3,9 +3,17 @@ package main
 import (
 	"log/slog"
 
+	"github.com/rendyananta/example-online-book-store/internal/repo/address"
 	"github.com/rendyananta/example-online-book-store/internal/repo/book"
+	"github.com/rendyananta/example-online-book-store/internal/repo/cart"
+	"github.com/rendyananta/example-online-book-store/internal/repo/exchangerate"
+	"github.com/rendyananta/example-online-book-store/internal/repo/invoice"
 	"github.com/rendyananta/example-online-book-store/internal/repo/order"
+	"github.com/rendyananta/example-online-book-store/internal/repo/outbox"
+	"github.com/rendyananta/example-online-book-store/internal/repo/payment"
 	"github.com/rendyananta/example-online-book-store/internal/repo/user"
+	"github.com/rendyananta/example-online-book-store/internal/repo/voucher"
+	"github.com/rendyananta/example-online-book-store/internal/repo/webhook"
 )
 
 func loadRepoModules(cfg BinaryConfig, globalModules GlobalModules) RepoModules {
@@ -27,9 +35,65 @@ func loadRepoModules(cfg BinaryConfig, globalModules GlobalModules) RepoModules
 		panic(err)
 	}
 
+	paymentRepo, err := payment.NewPaymentRepo(cfg.App.Domain.PaymentRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize payment repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	cartRepo, err := cart.NewCartRepo(cfg.App.Domain.CartRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize cart repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	voucherRepo, err := voucher.NewVoucherRepo(cfg.App.Domain.VoucherRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize voucher repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	addressRepo, err := address.NewAddressRepo(cfg.App.Domain.AddressRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize address repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	exchangeRateRepo, err := exchangerate.NewExchangeRateRepo(cfg.App.Domain.ExchangeRateRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize exchange rate repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	invoiceRepo, err := invoice.NewInvoiceRepo(cfg.App.Domain.InvoiceRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize invoice repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	outboxRepo, err := outbox.NewOutboxRepo(cfg.App.Domain.OutboxRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize outbox repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	webhookRepo, err := webhook.NewWebhookRepo(cfg.App.Domain.WebhookRepo, globalModules.DBConnManager)
+	if err != nil {
+		slog.Error("cannot initialize webhook repo", slog.String("err", err.Error()))
+		panic(err)
+	}
+
 	return RepoModules{
-		BookRepo:  bookRepo,
-		UserRepo:  userRepo,
-		OrderRepo: orderRepo,
+		BookRepo:         bookRepo,
+		UserRepo:         userRepo,
+		OrderRepo:        orderRepo,
+		PaymentRepo:      paymentRepo,
+		CartRepo:         cartRepo,
+		VoucherRepo:      voucherRepo,
+		AddressRepo:      addressRepo,
+		ExchangeRateRepo: exchangeRateRepo,
+		InvoiceRepo:      invoiceRepo,
+		OutboxRepo:       outboxRepo,
+		WebhookRepo:      webhookRepo,
 	}
 }
@@ -3,12 +3,23 @@ package main
 import (
 	"log/slog"
 
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+	addressuc "github.com/rendyananta/example-online-book-store/internal/usecase/address"
 	bookuc "github.com/rendyananta/example-online-book-store/internal/usecase/book"
+	cartuc "github.com/rendyananta/example-online-book-store/internal/usecase/cart"
+	exchangerateuc "github.com/rendyananta/example-online-book-store/internal/usecase/exchangerate"
+	invoiceuc "github.com/rendyananta/example-online-book-store/internal/usecase/invoice"
 	orderuc "github.com/rendyananta/example-online-book-store/internal/usecase/order"
+	outboxuc "github.com/rendyananta/example-online-book-store/internal/usecase/outbox"
+	paymentuc "github.com/rendyananta/example-online-book-store/internal/usecase/payment"
+	"github.com/rendyananta/example-online-book-store/internal/usecase/pricing"
 	useruc "github.com/rendyananta/example-online-book-store/internal/usecase/user"
+	voucheruc "github.com/rendyananta/example-online-book-store/internal/usecase/voucher"
+	webhookuc "github.com/rendyananta/example-online-book-store/internal/usecase/webhook"
 )
 
-func loadUseCaseModules(_ BinaryConfig, globalModules GlobalModules, repoModules RepoModules) UseCaseModules {
+func loadUseCaseModules(cfg BinaryConfig, globalModules GlobalModules, repoModules RepoModules) UseCaseModules {
 	userAuthentication, err := useruc.NewAuthenticatorUseCase(repoModules.UserRepo, globalModules.AuthManager)
 	if err != nil {
 		slog.Error("cannot initialize user auth use case", slog.String("err", err.Error()))
@@ -21,13 +32,56 @@ func loadUseCaseModules(_ BinaryConfig, globalModules GlobalModules, repoModules
 		panic(err)
 	}
 
-	bookQueries, err := bookuc.NewQueryUseCase(repoModules.BookRepo)
+	userRoleAssignment, err := useruc.NewRoleAssignmentUseCase(repoModules.UserRepo)
+	if err != nil {
+		slog.Error("cannot initialize user role assignment use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	userSessions, err := useruc.NewSessionUseCase(globalModules.AuthManager)
+	if err != nil {
+		slog.Error("cannot initialize user sessions use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	exchangeRates, err := exchangerateuc.NewExchangeRateUseCase(cfg.App.Domain.ExchangeRate, repoModules.ExchangeRateRepo)
+	if err != nil {
+		slog.Error("cannot initialize exchange rate use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	bookQueries, err := bookuc.NewQueryUseCase(repoModules.BookRepo, exchangeRates)
 	if err != nil {
 		slog.Error("cannot initialize book queries use case", slog.String("err", err.Error()))
 		panic(err)
 	}
 
-	orderPlacement, err := orderuc.NewPlaceOrderUseCase(repoModules.OrderRepo, repoModules.BookRepo)
+	catalogQueries, err := bookuc.NewCatalogQueriesUseCase(repoModules.BookRepo, exchangeRates)
+	if err != nil {
+		slog.Error("cannot initialize catalog queries use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	bookManagement, err := bookuc.NewManagementUseCase(repoModules.BookRepo)
+	if err != nil {
+		slog.Error("cannot initialize book management use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	pricingPipeline := pricing.NewPipeline(cfg.App.Domain.Pricing)
+	pricingPipeline.Register(pricing.StepNameSubtotal, pricing.NewSubtotalStep())
+	pricingPipeline.Register(pricing.StepNameDiscount, pricing.NewDiscountStep(repoModules.VoucherRepo))
+	pricingPipeline.Register(pricing.StepNameTax, pricing.NewTaxStep(cfg.App.Domain.PricingTax))
+	pricingPipeline.Register(pricing.StepNamePlatformFee, pricing.NewPlatformFeeStep(cfg.App.Domain.PricingPlatformFee))
+	pricingPipeline.Register(pricing.StepNameShipping, pricing.NewShippingStep(globalModules.ShippingManager))
+	pricingPipeline.Register(pricing.StepNameConversion, pricing.NewConversionStep(exchangeRates))
+
+	if err := pricingPipeline.Validate(); err != nil {
+		slog.Error("cannot initialize pricing pipeline", slog.String("err", err.Error()), slog.Any("steps", cfg.App.Domain.Pricing.Steps))
+		panic(err)
+	}
+
+	orderPlacement, err := orderuc.NewPlaceOrderUseCase(repoModules.OrderRepo, repoModules.BookRepo, repoModules.AddressRepo, pricingPipeline)
 	if err != nil {
 		slog.Error("cannot initialize place order use case", slog.String("err", err.Error()))
 		panic(err)
@@ -39,11 +93,93 @@ func loadUseCaseModules(_ BinaryConfig, globalModules GlobalModules, repoModules
 		panic(err)
 	}
 
+	orderStatus, err := orderuc.NewStatusUseCase(repoModules.OrderRepo)
+	if err != nil {
+		slog.Error("cannot initialize order status use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	paymentUseCase, err := paymentuc.NewPaymentUseCase(repoModules.PaymentRepo, repoModules.OrderRepo, globalModules.PaymentManager)
+	if err != nil {
+		slog.Error("cannot initialize payment use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	cartUseCase, err := cartuc.NewCartUseCase(repoModules.CartRepo, repoModules.BookRepo, orderPlacement)
+	if err != nil {
+		slog.Error("cannot initialize cart use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	voucherManagement, err := voucheruc.NewManagementUseCase(repoModules.VoucherRepo)
+	if err != nil {
+		slog.Error("cannot initialize voucher management use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	addressBook, err := addressuc.NewAddressBookUseCase(repoModules.AddressRepo)
+	if err != nil {
+		slog.Error("cannot initialize address book use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	invoices, err := invoiceuc.NewInvoiceUseCase(repoModules.InvoiceRepo)
+	if err != nil {
+		slog.Error("cannot initialize invoice use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	eventDispatcher, err := outboxuc.NewDispatcher(cfg.App.Domain.Outbox, repoModules.OutboxRepo)
+	if err != nil {
+		slog.Error("cannot initialize event dispatcher", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	eventDispatcher.Register(event.TypeOrderPlaced, outboxuc.LogHandler{})
+	eventDispatcher.Register(event.TypeOrderStatusChanged, outboxuc.LogHandler{})
+	eventDispatcher.Register(event.TypeUserRegistered, outboxuc.LogHandler{})
+
+	webhookManagement, err := webhookuc.NewManagementUseCase(repoModules.WebhookRepo, repoModules.WebhookRepo)
+	if err != nil {
+		slog.Error("cannot initialize webhook management use case", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	webhookPublisher, err := webhookuc.NewPublisher(repoModules.WebhookRepo, repoModules.WebhookRepo)
+	if err != nil {
+		slog.Error("cannot initialize webhook publisher", slog.String("err", err.Error()))
+		panic(err)
+	}
+
+	for _, eventType := range webhook.EventTypes {
+		eventDispatcher.Register(eventType, webhookPublisher)
+	}
+
+	webhookSender, err := webhookuc.NewSender(cfg.App.Domain.Webhook, repoModules.WebhookRepo, repoModules.WebhookRepo, globalModules.WebhookClient)
+	if err != nil {
+		slog.Error("cannot initialize webhook sender", slog.String("err", err.Error()))
+		panic(err)
+	}
+
 	return UseCaseModules{
 		UserAuthentication: userAuthentication,
 		UserRegistration:   userRegistration,
+		UserRoleAssignment: userRoleAssignment,
+		UserSessions:       userSessions,
 		BookQueries:        bookQueries,
+		CatalogQueries:     catalogQueries,
+		BookManagement:     bookManagement,
 		OrderPlacement:     orderPlacement,
 		OrderQueries:       orderQueries,
+		OrderStatus:        orderStatus,
+		Payment:            paymentUseCase,
+		Cart:               cartUseCase,
+		VoucherManagement:  voucherManagement,
+		AddressBook:        addressBook,
+		ExchangeRates:      exchangeRates,
+		Invoices:           invoices,
+		EventDispatcher:    eventDispatcher,
+		WebhookManagement:  webhookManagement,
+		WebhookSender:      webhookSender,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/rendyananta/example-online-book-store/internal/config"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
 	"github.com/rendyananta/example-online-book-store/pkg/log"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"log/slog"
 	"os"
 	"strconv"
@@ -18,6 +19,14 @@ import (
 
 const dateLayout = "01/02/06"
 
+// defaultStock is the initial stock of every seeded book.
+const defaultStock = 100
+
+// estimateWeight estimates the book weight in grams from its pages, the dataset has no weight.
+func estimateWeight(pages int64) int64 {
+	return 100 + pages
+}
+
 func main() {
 	appCfg := config.LoadAppConfig()
 
@@ -171,7 +180,8 @@ func main() {
 		if rawPrice == "" {
 			continue
 		}
-		price, err := strconv.ParseFloat(rawPrice, 64)
+		// the dataset prices are in dollars, the extra precision is rounded to the cent.
+		price, err := money.ParseRounded(rawPrice, money.USD, money.RoundHalfUp)
 		if err != nil {
 			slog.Error("can't parse price", slog.String("error", err.Error()), slog.String("price", rawPrice))
 		}
@@ -210,12 +220,13 @@ func main() {
 		coverImg := record[21]
 
 		_, err = defaultConn.Exec(
-			defaultConn.Rebind(`insert into books (id, title, description, price, isbn, language, edition, pages, publisher_id, published_at, first_published_at, cover_img, rating) 
-				values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			defaultConn.Rebind(`insert into books (id, title, description, price, currency, isbn, language, edition, pages, publisher_id, published_at, first_published_at, cover_img, rating, stock, weight) 
+				values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
 			id,
 			title,
 			description,
-			price,
+			price.Amount(),
+			price.Currency(),
 			isbn,
 			language,
 			edition,
@@ -225,6 +236,8 @@ func main() {
 			firstPublishDate,
 			coverImg,
 			rating,
+			defaultStock,
+			estimateWeight(pages),
 		)
 		if err != nil {
 			continue
@@ -263,19 +276,5 @@ func main() {
 				continue
 			}
 		}
-
-		//_, err = defaultConn.Exec(
-		//	defaultConn.Rebind(`insert into book_search_index (id, title, description, genres, authors, publisher)
-		//		values (?, ?, ?, ?, ?, ?)`),
-		//	id,
-		//	title,
-		//	description,
-		//	strings.Join(genres, ","),
-		//	strings.Join(authors, ","),
-		//	publisher,
-		//)
-		//if err != nil {
-		//	slog.Error("error insert into virtual table", slog.String("error", err.Error()))
-		//}
 	}
 }
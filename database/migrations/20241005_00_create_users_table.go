@@ -14,6 +14,7 @@ func (c CreateUsersTable) Up() error {
                        name varchar (255) not null,
                        email varchar(255) not null unique,
                        password varchar(255) not null,
+                       role varchar(20) not null default 'customer',
                        created_at timestamp not null default current_timestamp,
                        updated_at timestamp not null default current_timestamp
         )`
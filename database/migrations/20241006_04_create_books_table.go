@@ -11,7 +11,8 @@ func (c CreateBooksTable) Up() error {
                        id uuid primary key,
                        title varchar (255) not null,
                        description text not null,
-                       price double not null,
+                       price integer not null,
+                       currency varchar(3) not null default 'USD',
                        isbn varchar not null,
                        language varchar(100),
                        edition varchar(255),
@@ -21,24 +22,19 @@ func (c CreateBooksTable) Up() error {
                        first_published_at timestamp,
                        cover_img text,
                        rating double,
+                       stock int not null default 0,
+                       weight int not null default 0,
                        created_at timestamp not null default current_timestamp,
                        updated_at timestamp not null default current_timestamp,
                        deleted_at timestamp
-        );
-
--- 		create virtual table if not exists book_search_index using fts5 (
--- 		    id, title, description, genres, authors, publisher
--- 		);
-`
+        )`
 
 	_, err := c.Conn.Exec(query)
 	return err
 }
 
 func (c CreateBooksTable) Down() error {
-	query := `drop table if exists books;
---  		drop table book_search_index;
-		`
+	query := `drop table if exists books`
 
 	_, err := c.Conn.Exec(query)
 	return err
@@ -10,8 +10,13 @@ func (c CreateOrdersTable) Up() error {
 	query := `create table if not exists orders (
                        id uuid primary key,
                        user_id uuid not null,
-                       grand_total double not null,
+                       grand_total integer not null,
+                       currency varchar(3) not null default 'USD',
+                       base_currency varchar(3) not null default 'USD',
+                       exchange_rate varchar(50) not null default '1',
                        status varchar(255) not null,
+                       shipping_address_id uuid,
+                       shipping_address text,
                        created_at timestamp not null default current_timestamp,
                        updated_at timestamp not null default current_timestamp,
                        deleted_at timestamp
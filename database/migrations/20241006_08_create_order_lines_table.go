@@ -12,9 +12,9 @@ func (c CreateOrderLinesTable) Up() error {
                        order_id uuid not null,
                        line_reference_type varchar(255) not null,
                        line_reference_id uuid not null, -- book / shipping fee / discount / platform fee
-                       amount double not null,
+                       amount integer not null,
                        quantity int not null default 1,
-                       subtotal double not null 
+                       subtotal integer not null 
         )`
 
 	_, err := c.Conn.Exec(query)
@@ -0,0 +1,113 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type CreateBookSearchIndexTable struct {
+	Conn *sqlx.DB
+}
+
+// refreshBookSearchIndex re-indexes the book whose id is matched by the given expression,
+// the book is removed from the index when it has been soft deleted.
+func refreshBookSearchIndex(bookIDExpr string) string {
+	return fmt.Sprintf(`delete from book_search_index where id in (%[1]s);
+			insert into book_search_index (id, title, description, authors, genres, publisher)
+			select b.id, b.title, b.description,
+			       coalesce((select group_concat(a.name, ', ') from books_authors ba
+			                    inner join authors a on a.id = ba.author_id
+			                    where ba.book_id = b.id), ''),
+			       coalesce((select group_concat(g.name, ', ') from books_genres bg
+			                    inner join genres g on g.id = bg.genre_id
+			                    where bg.book_id = b.id), ''),
+			       coalesce((select p.name from publishers p where p.id = b.publisher_id), '')
+			from books b
+			where b.id in (%[1]s) and b.deleted_at is null;`, bookIDExpr)
+}
+
+func (c CreateBookSearchIndexTable) Up() error {
+	query := fmt.Sprintf(`create virtual table if not exists book_search_index using fts5 (
+                       id unindexed, title, description, authors, genres, publisher,
+                       tokenize = 'unicode61 remove_diacritics 2'
+        );
+
+		-- weight title and authors matches above the rest of the columns, the id column is not indexed.
+		insert into book_search_index (book_search_index, rank) values ('rank', 'bm25(0.0, 10.0, 1.0, 5.0, 2.0, 2.0)');
+
+		create trigger if not exists books_search_index_ai after insert on books begin
+			%[1]s
+		end;
+
+		-- the stock changes on every order, thus only the indexed columns refresh the index.
+		create trigger if not exists books_search_index_au after update of title, description, publisher_id, deleted_at on books begin
+			delete from book_search_index where id = old.id;
+			%[1]s
+		end;
+
+		create trigger if not exists books_search_index_ad after delete on books begin
+			delete from book_search_index where id = old.id;
+		end;
+
+		create trigger if not exists books_authors_search_index_ai after insert on books_authors begin
+			%[2]s
+		end;
+
+		create trigger if not exists books_authors_search_index_ad after delete on books_authors begin
+			%[3]s
+		end;
+
+		create trigger if not exists books_genres_search_index_ai after insert on books_genres begin
+			%[2]s
+		end;
+
+		create trigger if not exists books_genres_search_index_ad after delete on books_genres begin
+			%[3]s
+		end;
+
+		create trigger if not exists authors_search_index_au after update of name on authors begin
+			%[4]s
+		end;
+
+		create trigger if not exists genres_search_index_au after update of name on genres begin
+			%[5]s
+		end;
+
+		create trigger if not exists publishers_search_index_au after update of name on publishers begin
+			%[6]s
+		end;
+
+		-- index the books that exist before the index was created.
+		%[7]s
+`,
+		refreshBookSearchIndex("new.id"),
+		refreshBookSearchIndex("new.book_id"),
+		refreshBookSearchIndex("old.book_id"),
+		refreshBookSearchIndex("select book_id from books_authors where author_id = new.id"),
+		refreshBookSearchIndex("select book_id from books_genres where genre_id = new.id"),
+		refreshBookSearchIndex("select id from books where publisher_id = new.id"),
+		refreshBookSearchIndex("select id from books"),
+	)
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateBookSearchIndexTable) Down() error {
+	query := `drop trigger if exists books_search_index_ai;
+		drop trigger if exists books_search_index_au;
+		drop trigger if exists books_search_index_ad;
+		drop trigger if exists books_authors_search_index_ai;
+		drop trigger if exists books_authors_search_index_ad;
+		drop trigger if exists books_genres_search_index_ai;
+		drop trigger if exists books_genres_search_index_ad;
+		drop trigger if exists authors_search_index_au;
+		drop trigger if exists genres_search_index_au;
+		drop trigger if exists publishers_search_index_au;
+		drop table if exists book_search_index;
+		`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateBookStockAdjustmentsTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateBookStockAdjustmentsTable) Up() error {
+	query := `create table if not exists book_stock_adjustments (
+                       id uuid primary key,
+                       book_id uuid not null,
+                       order_id uuid,
+                       user_id uuid,
+                       quantity int not null,
+                       stock int not null,
+                       reason text not null,
+                       created_at timestamp not null default current_timestamp
+        );
+
+		create index if not exists book_stock_adjustments_book_id_index on book_stock_adjustments (book_id, id);`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateBookStockAdjustmentsTable) Down() error {
+	query := `drop table if exists book_stock_adjustments`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
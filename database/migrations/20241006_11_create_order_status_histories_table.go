@@ -0,0 +1,30 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateOrderStatusHistoriesTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateOrderStatusHistoriesTable) Up() error {
+	query := `create table if not exists order_status_histories (
+                       id uuid primary key,
+                       order_id uuid not null,
+                       from_status varchar(255),
+                       status varchar(255) not null,
+                       reason text,
+                       created_at timestamp not null default current_timestamp
+        );
+
+		create index if not exists order_status_histories_order_id_index on order_status_histories (order_id, id);`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateOrderStatusHistoriesTable) Down() error {
+	query := `drop table if exists order_status_histories`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateOrderTransactionsTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateOrderTransactionsTable) Up() error {
+	query := `create table if not exists order_transactions (
+                       id uuid primary key,
+                       order_id uuid not null,
+                       gateway varchar(100) not null,
+                       reference varchar(255) not null,
+                       amount integer not null,
+                       currency varchar(3) not null default 'USD',
+                       status varchar(50) not null,
+                       payment_url text,
+                       payload text,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp,
+                       unique (gateway, reference)
+        );
+
+		create index if not exists order_transactions_order_id_index on order_transactions (order_id);`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateOrderTransactionsTable) Down() error {
+	query := `drop table if exists order_transactions`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
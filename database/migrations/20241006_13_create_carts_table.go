@@ -0,0 +1,35 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateCartsTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateCartsTable) Up() error {
+	query := `create table if not exists carts (
+                       id uuid primary key,
+                       user_id uuid unique,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp
+        );
+
+		create table if not exists cart_items (
+                       cart_id uuid not null,
+                       book_id uuid not null,
+                       quantity int not null,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp,
+                       primary key (cart_id, book_id)
+        );`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateCartsTable) Down() error {
+	query := `drop table if exists cart_items; drop table if exists carts`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
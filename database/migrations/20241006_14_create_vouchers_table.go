@@ -0,0 +1,39 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateVouchersTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateVouchersTable) Up() error {
+	query := `create table if not exists vouchers (
+                       id uuid primary key,
+                       code varchar(100) not null unique,
+                       description text,
+                       discount_type varchar(50) not null,
+                       percentage double not null default 0,
+                       amount integer not null default 0,
+                       max_discount integer not null default 0,
+                       min_spend integer not null default 0,
+                       currency varchar(3) not null default 'USD',
+                       scope_type varchar(50),
+                       scope_id uuid,
+                       usage_limit int not null default 0,
+                       used_count int not null default 0,
+                       starts_at timestamp,
+                       ends_at timestamp,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp
+        );`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateVouchersTable) Down() error {
+	query := `drop table if exists vouchers`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
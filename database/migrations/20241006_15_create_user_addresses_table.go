@@ -0,0 +1,38 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateUserAddressesTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateUserAddressesTable) Up() error {
+	query := `create table if not exists user_addresses (
+                       id uuid primary key,
+                       user_id uuid not null,
+                       label varchar(100),
+                       recipient_name varchar(255) not null,
+                       phone varchar(50) not null,
+                       line1 varchar(255) not null,
+                       line2 varchar(255),
+                       city varchar(100) not null,
+                       region varchar(100) not null,
+                       postal_code varchar(20) not null,
+                       country varchar(2) not null,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp,
+                       deleted_at timestamp
+        );
+
+		create index if not exists user_addresses_user_id_index on user_addresses (user_id);`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateUserAddressesTable) Down() error {
+	query := `drop table if exists user_addresses`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateExchangeRatesTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateExchangeRatesTable) Up() error {
+	query := `create table if not exists exchange_rates (
+                       base_currency varchar(3) not null,
+                       currency varchar(3) not null,
+                       rate varchar(50) not null,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp,
+                       primary key (base_currency, currency)
+        )`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateExchangeRatesTable) Down() error {
+	query := `drop table if exists exchange_rates`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateInvoicesTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateInvoicesTable) Up() error {
+	query := `create table if not exists invoices (
+                       id uuid primary key,
+                       number integer not null unique,
+                       order_id uuid not null unique,
+                       issued_at timestamp not null default current_timestamp
+        )`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateInvoicesTable) Down() error {
+	query := `drop table if exists invoices`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateOutboxEventsTable struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateOutboxEventsTable) Up() error {
+	query := `create table if not exists outbox_events (
+                       id uuid primary key,
+                       type varchar(100) not null,
+                       aggregate_id varchar(36) not null,
+                       payload text not null,
+                       attempts integer not null default 0,
+                       available_at timestamp not null,
+                       last_error text,
+                       dispatched_at timestamp,
+                       failed_at timestamp,
+                       created_at timestamp not null default current_timestamp
+        );
+
+		create index if not exists outbox_events_pending_index on outbox_events (dispatched_at, failed_at, available_at);`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateOutboxEventsTable) Down() error {
+	query := `drop table if exists outbox_events`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+type CreateWebhookTables struct {
+	Conn *sqlx.DB
+}
+
+func (c CreateWebhookTables) Up() error {
+	query := `create table if not exists webhook_subscriptions (
+                       id uuid primary key,
+                       url text not null,
+                       secret varchar(255) not null,
+                       event_types text not null,
+                       active boolean not null default true,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp,
+                       deleted_at timestamp
+        );
+
+		create table if not exists webhook_deliveries (
+                       id uuid primary key,
+                       subscription_id uuid not null,
+                       event_id uuid not null,
+                       event_type varchar(100) not null,
+                       payload text not null,
+                       status varchar(20) not null default 'pending',
+                       attempts integer not null default 0,
+                       available_at timestamp not null,
+                       response_status integer,
+                       response_body text,
+                       last_error text,
+                       delivered_at timestamp,
+                       created_at timestamp not null default current_timestamp,
+                       updated_at timestamp not null default current_timestamp,
+                       unique (subscription_id, event_id)
+        );
+
+		create index if not exists webhook_deliveries_pending_index on webhook_deliveries (status, available_at);
+		create index if not exists webhook_deliveries_subscription_index on webhook_deliveries (subscription_id, created_at);`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
+
+func (c CreateWebhookTables) Down() error {
+	query := `drop table if exists webhook_deliveries;
+		drop table if exists webhook_subscriptions;`
+
+	_, err := c.Conn.Exec(query)
+	return err
+}
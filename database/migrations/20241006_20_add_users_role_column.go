@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+// AddUsersRoleColumn adds the role to the users table created before the roles are introduced.
+type AddUsersRoleColumn struct {
+	Conn *sqlx.DB
+}
+
+func (c AddUsersRoleColumn) Up() error {
+	return addMissingColumns(c.Conn, "users", column{name: "role", definition: "varchar(20) not null default 'customer'"})
+}
+
+// Down keeps the column, it belongs to the users table which is dropped by its own migration.
+func (c AddUsersRoleColumn) Down() error {
+	return nil
+}
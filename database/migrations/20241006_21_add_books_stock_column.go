@@ -0,0 +1,44 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+// AddBooksStockColumn adds the stock to the books table created before the stock is tracked, the existing books
+// are given the initial stock thus they can still be ordered until the staff adjusts them.
+type AddBooksStockColumn struct {
+	Conn         *sqlx.DB
+	InitialStock int
+}
+
+func (c AddBooksStockColumn) Up() error {
+	tx, err := c.Conn.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	declared, err := columnType(tx, "books", "stock")
+	if err != nil {
+		return err
+	}
+
+	// the stock is tracked already, running the migration again keeps the adjusted stock.
+	if declared != "" {
+		return nil
+	}
+
+	if err = addMissingColumns(tx, "books", column{name: "stock", definition: "int not null default 0"}); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`update books set stock = ?`, c.InitialStock); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Down keeps the column, it belongs to the books table which is dropped by its own migration.
+func (c AddBooksStockColumn) Down() error {
+	return nil
+}
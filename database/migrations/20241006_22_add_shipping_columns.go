@@ -0,0 +1,37 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+// AddShippingColumns adds the shipping address of the orders and the weight of the books to the tables created
+// before the shipping is introduced, the existing orders have no shipping address.
+type AddShippingColumns struct {
+	Conn *sqlx.DB
+}
+
+func (c AddShippingColumns) Up() error {
+	tx, err := c.Conn.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = addMissingColumns(tx, "orders",
+		column{name: "shipping_address_id", definition: "uuid"},
+		column{name: "shipping_address", definition: "text"},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = addMissingColumns(tx, "books", column{name: "weight", definition: "int not null default 0"}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Down keeps the columns, they belong to the orders and books tables which are dropped by their own migrations.
+func (c AddShippingColumns) Down() error {
+	return nil
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+// ConvertMoneyColumns converts the prices of the tables created before the money values are kept in the minor
+// units, the existing prices are in USD.
+type ConvertMoneyColumns struct {
+	Conn *sqlx.DB
+}
+
+func (c ConvertMoneyColumns) Up() error {
+	tx, err := c.Conn.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	currency := column{name: "currency", definition: "varchar(3) not null default 'USD'"}
+
+	for _, table := range []string{"books", "orders", "order_transactions"} {
+		if err = addMissingColumns(tx, table, currency); err != nil {
+			return err
+		}
+	}
+
+	err = addMissingColumns(tx, "vouchers", column{name: "percentage", definition: "double not null default 0"}, currency)
+	if err != nil {
+		return err
+	}
+
+	// the percentage vouchers kept the percent in the amount.
+	amountType, err := columnType(tx, "vouchers", "amount")
+	if err != nil {
+		return err
+	}
+
+	if amountType == "double" {
+		_, err = tx.Exec(`update vouchers set percentage = amount, amount = 0 where discount_type = 'percentage'`)
+		if err != nil {
+			return err
+		}
+	}
+
+	moneyColumns := []struct {
+		table   string
+		columns []string
+	}{
+		{table: "books", columns: []string{"price"}},
+		{table: "orders", columns: []string{"grand_total"}},
+		{table: "order_lines", columns: []string{"amount", "subtotal"}},
+		{table: "order_transactions", columns: []string{"amount"}},
+		{table: "vouchers", columns: []string{"amount", "max_discount", "min_spend"}},
+	}
+
+	for _, money := range moneyColumns {
+		if err = convertToMinorUnits(tx, money.table, money.columns...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Down keeps the minor units, the columns belong to the tables which are dropped by their own migrations.
+func (c ConvertMoneyColumns) Down() error {
+	return nil
+}
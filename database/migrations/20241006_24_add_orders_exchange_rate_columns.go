@@ -0,0 +1,21 @@
+package migrations
+
+import "github.com/jmoiron/sqlx"
+
+// AddOrdersExchangeRateColumns adds the base currency and the exchange rate to the orders table created before
+// the exchange rates are introduced, the existing orders are charged in USD.
+type AddOrdersExchangeRateColumns struct {
+	Conn *sqlx.DB
+}
+
+func (c AddOrdersExchangeRateColumns) Up() error {
+	return addMissingColumns(c.Conn, "orders",
+		column{name: "base_currency", definition: "varchar(3) not null default 'USD'"},
+		column{name: "exchange_rate", definition: "varchar(50) not null default '1'"},
+	)
+}
+
+// Down keeps the columns, they belong to the orders table which is dropped by its own migration.
+func (c AddOrdersExchangeRateColumns) Down() error {
+	return nil
+}
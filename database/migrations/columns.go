@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// column is the column added to the table which is created before the column is introduced.
+type column struct {
+	name       string
+	definition string
+}
+
+// columnType returns the declared type of the column, it is empty when the table has no such column.
+func columnType(q sqlx.Queryer, table string, name string) (string, error) {
+	var declared string
+
+	err := sqlx.Get(q, &declared, `select type from pragma_table_info(?) where name = ?`, table, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(declared), nil
+}
+
+// addMissingColumns adds the columns the table does not have yet, thus running the migration again is a no-op.
+func addMissingColumns(e sqlx.Ext, table string, columns ...column) error {
+	for _, c := range columns {
+		declared, err := columnType(e, table, c.name)
+		if err != nil {
+			return err
+		}
+
+		if declared != "" {
+			continue
+		}
+
+		if _, err = e.Exec(fmt.Sprintf(`alter table %s add column %s %s`, table, c.name, c.definition)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertToMinorUnits replaces the double money columns of the table by the integer columns holding the minor
+// units, e.g. 19.99 becomes 1999. The columns which are already integer are left as is.
+func convertToMinorUnits(e sqlx.Ext, table string, names ...string) error {
+	for _, name := range names {
+		declared, err := columnType(e, table, name)
+		if err != nil {
+			return err
+		}
+
+		if declared != "double" {
+			continue
+		}
+
+		// the column is renamed first to keep its name, sqlite cannot change the type of the column.
+		query := fmt.Sprintf(`alter table %[1]s rename column %[2]s to %[2]s_double;
+			alter table %[1]s add column %[2]s integer not null default 0;
+			update %[1]s set %[2]s = cast(round(%[2]s_double * 100) as integer);
+			alter table %[1]s drop column %[2]s_double;`, table, name)
+
+		if _, err = e.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
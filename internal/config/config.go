@@ -1,13 +1,29 @@
 package config
 
 import (
+	addressrp "github.com/rendyananta/example-online-book-store/internal/repo/address"
 	bookrp "github.com/rendyananta/example-online-book-store/internal/repo/book"
+	cartrp "github.com/rendyananta/example-online-book-store/internal/repo/cart"
+	exchangeraterp "github.com/rendyananta/example-online-book-store/internal/repo/exchangerate"
+	invoicerp "github.com/rendyananta/example-online-book-store/internal/repo/invoice"
 	orderrp "github.com/rendyananta/example-online-book-store/internal/repo/order"
+	outboxrp "github.com/rendyananta/example-online-book-store/internal/repo/outbox"
+	paymentrp "github.com/rendyananta/example-online-book-store/internal/repo/payment"
 	userrp "github.com/rendyananta/example-online-book-store/internal/repo/user"
+	voucherrp "github.com/rendyananta/example-online-book-store/internal/repo/voucher"
+	webhookrp "github.com/rendyananta/example-online-book-store/internal/repo/webhook"
+	exchangerateuc "github.com/rendyananta/example-online-book-store/internal/usecase/exchangerate"
+	outboxuc "github.com/rendyananta/example-online-book-store/internal/usecase/outbox"
+	"github.com/rendyananta/example-online-book-store/internal/usecase/pricing"
+	webhookuc "github.com/rendyananta/example-online-book-store/internal/usecase/webhook"
 	"github.com/rendyananta/example-online-book-store/pkg/auth"
 	"github.com/rendyananta/example-online-book-store/pkg/cache"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/idempotency"
 	"github.com/rendyananta/example-online-book-store/pkg/log"
+	"github.com/rendyananta/example-online-book-store/pkg/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
+	"github.com/rendyananta/example-online-book-store/pkg/webhook"
 )
 
 type App struct {
@@ -16,15 +32,37 @@ type App struct {
 }
 
 type Global struct {
-	Log           log.Config
-	DB            db.Config
-	Cache         cache.Config
-	CacheDBDriver cache.DriverDatabaseConfig
-	Auth          auth.Config
+	Log                log.Config
+	DB                 db.Config
+	Cache              cache.Config
+	CacheDBDriver      cache.DriverDatabaseConfig
+	Auth               auth.Config
+	Idempotency        idempotency.Config
+	Payment            payment.Config
+	PaymentFakeGateway payment.FakeGatewayConfig
+	Shipping           shipping.Config
+	ShippingWeight     shipping.WeightCalculatorConfig
+	ShippingItemCount  shipping.ItemCountCalculatorConfig
+	WebhookClient      webhook.ClientConfig
 }
 
 type Domain struct {
-	UserRepo  userrp.Config
-	BookRepo  bookrp.Config
-	OrderRepo orderrp.Config
+	UserRepo         userrp.Config
+	BookRepo         bookrp.Config
+	OrderRepo        orderrp.Config
+	PaymentRepo      paymentrp.Config
+	CartRepo         cartrp.Config
+	VoucherRepo      voucherrp.Config
+	AddressRepo      addressrp.Config
+	ExchangeRateRepo exchangeraterp.Config
+	InvoiceRepo      invoicerp.Config
+	OutboxRepo       outboxrp.Config
+	WebhookRepo      webhookrp.Config
+
+	Pricing            pricing.Config
+	PricingTax         pricing.TaxConfig
+	PricingPlatformFee pricing.PlatformFeeConfig
+	ExchangeRate       exchangerateuc.Config
+	Outbox             outboxuc.Config
+	Webhook            webhookuc.Config
 }
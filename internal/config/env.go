@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 )
 
 func LoadFromEnvBool(key string, defaultValue bool) bool {
@@ -49,6 +51,65 @@ func LoadFromEnvInt64(key string, defaultValue int64) int64 {
 	return i
 }
 
+func LoadFromEnvFloat64(key string, defaultValue float64) float64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultValue
+	}
+
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return f
+}
+
+// LoadFromEnvMoney reads the decimal amount, e.g. "1.50", in the currency of the default value.
+func LoadFromEnvMoney(key string, defaultValue money.Money) money.Money {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultValue
+	}
+
+	m, err := money.Parse(val, defaultValue.Currency())
+	if err != nil {
+		return defaultValue
+	}
+
+	return m
+}
+
+// LoadFromEnvCurrency reads the three letters currency code, e.g. "USD".
+func LoadFromEnvCurrency(key string, defaultValue money.Currency) money.Currency {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultValue
+	}
+
+	currency, err := money.ParseCurrency(val)
+	if err != nil {
+		return defaultValue
+	}
+
+	return currency
+}
+
+// LoadFromEnvRoundingMode reads the rounding mode name, i.e. half_up, half_even, ceiling or floor.
+func LoadFromEnvRoundingMode(key string, defaultValue money.RoundingMode) money.RoundingMode {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultValue
+	}
+
+	mode, err := money.ParseRoundingMode(val)
+	if err != nil {
+		return defaultValue
+	}
+
+	return mode
+}
+
 func LoadFromEnvString(key string, defaultValue string) string {
 	val := os.Getenv(key)
 	if val == "" {
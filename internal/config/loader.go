@@ -3,6 +3,6 @@ package config
 func LoadAppConfig() App {
 	return App{
 		Global: loadGlobalConfig(),
-		Domain: Domain{},
+		Domain: loadDomainConfig(),
 	}
 }
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	exchangerateuc "github.com/rendyananta/example-online-book-store/internal/usecase/exchangerate"
+	outboxuc "github.com/rendyananta/example-online-book-store/internal/usecase/outbox"
+	"github.com/rendyananta/example-online-book-store/internal/usecase/pricing"
+	webhookuc "github.com/rendyananta/example-online-book-store/internal/usecase/webhook"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func loadDomainConfig() Domain {
+	return Domain{
+		Pricing: pricing.Config{
+			Steps: LoadFromEnvStringSlice("PRICING_STEPS", pricing.DefaultSteps),
+		},
+		PricingTax: pricing.TaxConfig{
+			Rate:        LoadFromEnvFloat64("PRICING_TAX_RATE", 0),
+			RegionRates: loadTaxRegionRates("PRICING_TAX_REGION_RATES"),
+			Rounding:    LoadFromEnvRoundingMode("PRICING_TAX_ROUNDING", money.RoundHalfUp),
+		},
+		PricingPlatformFee: pricing.PlatformFeeConfig{
+			Flat:       LoadFromEnvMoney("PRICING_PLATFORM_FEE_FLAT", money.Zero(money.DefaultCurrency)),
+			Percentage: LoadFromEnvFloat64("PRICING_PLATFORM_FEE_PERCENTAGE", 0),
+		},
+		ExchangeRate: exchangerateuc.Config{
+			BaseCurrency: LoadFromEnvCurrency("EXCHANGE_RATE_BASE_CURRENCY", money.DefaultCurrency),
+		},
+		Outbox: outboxuc.Config{
+			PollInterval:    LoadFromEnvTimeDuration("OUTBOX_POLL_INTERVAL", 0),
+			BatchSize:       LoadFromEnvInt("OUTBOX_BATCH_SIZE", 0),
+			MaxAttempts:     LoadFromEnvInt("OUTBOX_MAX_ATTEMPTS", 0),
+			RetryBackoff:    LoadFromEnvTimeDuration("OUTBOX_RETRY_BACKOFF", 0),
+			MaxRetryBackoff: LoadFromEnvTimeDuration("OUTBOX_MAX_RETRY_BACKOFF", 0),
+			ClaimTimeout:    LoadFromEnvTimeDuration("OUTBOX_CLAIM_TIMEOUT", 0),
+		},
+		Webhook: webhookuc.Config{
+			PollInterval:    LoadFromEnvTimeDuration("WEBHOOK_POLL_INTERVAL", 0),
+			BatchSize:       LoadFromEnvInt("WEBHOOK_BATCH_SIZE", 0),
+			MaxAttempts:     LoadFromEnvInt("WEBHOOK_MAX_ATTEMPTS", 0),
+			RetryBackoff:    LoadFromEnvTimeDuration("WEBHOOK_RETRY_BACKOFF", 0),
+			MaxRetryBackoff: LoadFromEnvTimeDuration("WEBHOOK_MAX_RETRY_BACKOFF", 0),
+			ClaimTimeout:    LoadFromEnvTimeDuration("WEBHOOK_CLAIM_TIMEOUT", 0),
+		},
+	}
+}
+
+// loadTaxRegionRates reads the comma separated region tax rates in percent, each formatted as region:rate,
+// e.g. "bali:10,papua:0". Malformed entries are skipped.
+func loadTaxRegionRates(key string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, entry := range LoadFromEnvStringSlice(key, nil) {
+		region, rawRate, found := strings.Cut(entry, ":")
+		if !found {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rawRate), 64)
+		if err != nil {
+			continue
+		}
+
+		rates[strings.ToLower(strings.TrimSpace(region))] = rate
+	}
+
+	return rates
+}
@@ -1,10 +1,17 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/rendyananta/example-online-book-store/pkg/auth"
 	"github.com/rendyananta/example-online-book-store/pkg/cache"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/idempotency"
 	"github.com/rendyananta/example-online-book-store/pkg/log"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	"github.com/rendyananta/example-online-book-store/pkg/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
+	"github.com/rendyananta/example-online-book-store/pkg/webhook"
 )
 
 func loadGlobalConfig() Global {
@@ -29,8 +36,76 @@ func loadGlobalConfig() Global {
 			DefaultDriver: LoadFromEnvString("CACHE_DRIVER", cache.DrvNameDatabase),
 		},
 		Auth: auth.Config{
-			TokenLifetime: LoadFromEnvTimeDuration("AUTH_TOKEN_LIFETIME", 0),
-			CipherKeys:    LoadFromEnvStringSlice("AUTH_CIPHER_KEYS", nil),
+			Strategy:             LoadFromEnvString("AUTH_STRATEGY", auth.StrategySession),
+			TokenLifetime:        LoadFromEnvTimeDuration("AUTH_TOKEN_LIFETIME", 0),
+			RefreshTokenLifetime: LoadFromEnvTimeDuration("AUTH_REFRESH_TOKEN_LIFETIME", 0),
+			CipherKeys:           LoadFromEnvStringSlice("AUTH_CIPHER_KEYS", nil),
+			SigningAlgorithm:     LoadFromEnvString("AUTH_SIGNING_ALGORITHM", auth.AlgorithmHS256),
+			SigningKeys:          LoadFromEnvStringSlice("AUTH_SIGNING_KEYS", nil),
+			Denylist:             LoadFromEnvBool("AUTH_DENYLIST", false),
+		},
+		Idempotency: idempotency.Config{
+			TTL:         LoadFromEnvTimeDuration("IDEMPOTENCY_TTL", 0),
+			LockTimeout: LoadFromEnvTimeDuration("IDEMPOTENCY_LOCK_TIMEOUT", 0),
+		},
+		Payment: payment.Config{
+			DefaultGateway: LoadFromEnvString("PAYMENT_DEFAULT_GATEWAY", ""),
+		},
+		PaymentFakeGateway: payment.FakeGatewayConfig{
+			Enabled: LoadFromEnvBool("PAYMENT_FAKE_ENABLED", false),
+			Secret:  LoadFromEnvString("PAYMENT_FAKE_SECRET", ""),
+		},
+		Shipping: shipping.Config{
+			DefaultCalculator: LoadFromEnvString("SHIPPING_CALCULATOR", shipping.CalcNameWeight),
+		},
+		ShippingWeight: shipping.WeightCalculatorConfig{
+			Rates: shipping.RegionRates{
+				Default: shipping.Rate{
+					BaseFee: LoadFromEnvMoney("SHIPPING_WEIGHT_BASE_FEE", money.New(200, money.DefaultCurrency)),
+					PerUnit: LoadFromEnvMoney("SHIPPING_WEIGHT_PER_KG_FEE", money.New(150, money.DefaultCurrency)),
+				},
+				Regions: loadShippingRegionRates("SHIPPING_WEIGHT_REGION_RATES"),
+			},
+			DefaultItemWeight: LoadFromEnvInt("SHIPPING_WEIGHT_DEFAULT_ITEM_WEIGHT", 0),
+		},
+		ShippingItemCount: shipping.ItemCountCalculatorConfig{
+			Rates: shipping.RegionRates{
+				Default: shipping.Rate{
+					BaseFee: LoadFromEnvMoney("SHIPPING_ITEM_COUNT_BASE_FEE", money.New(200, money.DefaultCurrency)),
+					PerUnit: LoadFromEnvMoney("SHIPPING_ITEM_COUNT_PER_ITEM_FEE", money.New(50, money.DefaultCurrency)),
+				},
+				Regions: loadShippingRegionRates("SHIPPING_ITEM_COUNT_REGION_RATES"),
+			},
+		},
+		WebhookClient: webhook.ClientConfig{
+			Timeout:         LoadFromEnvTimeDuration("WEBHOOK_TIMEOUT", 0),
+			MaxResponseSize: LoadFromEnvInt64("WEBHOOK_MAX_RESPONSE_SIZE", 0),
 		},
 	}
 }
+
+// loadShippingRegionRates reads the comma separated region rates, each formatted as region:base_fee:per_unit_fee,
+// e.g. "bali:4:2,papua:8:5", in the default currency. Malformed entries are skipped.
+func loadShippingRegionRates(key string) map[string]shipping.Rate {
+	rates := make(map[string]shipping.Rate)
+	for _, entry := range LoadFromEnvStringSlice(key, nil) {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			continue
+		}
+
+		baseFee, err := money.Parse(parts[1], money.DefaultCurrency)
+		if err != nil {
+			continue
+		}
+
+		perUnit, err := money.Parse(parts[2], money.DefaultCurrency)
+		if err != nil {
+			continue
+		}
+
+		rates[strings.ToLower(strings.TrimSpace(parts[0]))] = shipping.Rate{BaseFee: baseFee, PerUnit: perUnit}
+	}
+
+	return rates
+}
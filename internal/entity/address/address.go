@@ -0,0 +1,32 @@
+package address
+
+import "time"
+
+// Address is the shipping address in the user address book.
+type Address struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"-"`
+	Label         string     `json:"label"`
+	RecipientName string     `json:"recipient_name"`
+	Phone         string     `json:"phone"`
+	Line1         string     `json:"line1"`
+	Line2         string     `json:"line2"`
+	City          string     `json:"city"`
+	Region        string     `json:"region"`
+	PostalCode    string     `json:"postal_code"`
+	Country       string     `json:"country"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+type WriteParam struct {
+	Label         string
+	RecipientName string
+	Phone         string
+	Line1         string
+	Line2         string
+	City          string
+	Region        string
+	PostalCode    string
+	Country       string
+}
@@ -1,16 +1,120 @@
 package book
 
-import "time"
+import (
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
 
 type PaginationParam struct {
 	PerPage int
-	LastID  string
+	Cursor  string
+	Sort    Sort
+	Filter  Filter
+	// Currency the listed prices are converted into, empty keeps the prices of the books.
+	Currency money.Currency
+}
+
+// Sort is the books ordering, prefixed by "-" for descending order. Empty sort orders the books
+// by their id, or by the relevance for the search result.
+type Sort = string
+
+const (
+	SortDefault         Sort = ""
+	SortPrice           Sort = "price"
+	SortPriceDesc       Sort = "-price"
+	SortRating          Sort = "rating"
+	SortRatingDesc      Sort = "-rating"
+	SortPublishedAt     Sort = "published_at"
+	SortPublishedAtDesc Sort = "-published_at"
+	SortTitle           Sort = "title"
+	SortTitleDesc       Sort = "-title"
+)
+
+// Filter narrows the books catalog, every non-zero field is combined using AND condition,
+// while the ids inside the same field are combined using OR condition. The price range may be given
+// in the requested currency, it is converted into the base currency before filtering.
+type Filter struct {
+	GenreIDs       []string
+	AuthorIDs      []string
+	PublisherIDs   []string
+	Language       string
+	MinPrice       money.Money
+	MaxPrice       money.Money
+	MinRating      float64
+	PublishedFrom  *time.Time
+	PublishedUntil *time.Time
 }
 
 type PaginationResult struct {
-	Data    []Book
+	Data     []Book
+	PageInfo pagination.PageInfo
+}
+
+// WriteParam is the book data written by the staff, the publisher, authors and genres are referenced by their id.
+type WriteParam struct {
+	Title            string
+	Description      string
+	Price            money.Money
+	ISBN             string
+	Language         string
+	Edition          string
+	Pages            int
+	PublishedAt      *time.Time
+	FirstPublishedAt *time.Time
+	CoverImg         string
+	Rating           float64
+	Weight           int
+	PublisherID      string
+	AuthorIDs        []string
+	GenreIDs         []string
+}
+
+// ListParam paginates the authors, genres and publishers, which are ordered by their name.
+type ListParam struct {
 	PerPage int
-	LastID  string
+	Cursor  string
+}
+
+type AuthorsResult struct {
+	Data     []Author
+	PageInfo pagination.PageInfo
+}
+
+type GenresResult struct {
+	Data     []Genre
+	PageInfo pagination.PageInfo
+}
+
+type PublishersResult struct {
+	Data     []Publisher
+	PageInfo pagination.PageInfo
+}
+
+// StockAdjustParam changes the book stock by the quantity, negative quantity takes the stock out.
+type StockAdjustParam struct {
+	BookID   string
+	UserID   string
+	Quantity int
+	Reason   string
+}
+
+// StockAdjustment is the audit trail of the book stock changes, made either by the staff or by the placed orders.
+type StockAdjustment struct {
+	ID        string     `json:"id"`
+	BookID    string     `json:"book_id"`
+	OrderID   string     `json:"order_id,omitempty"`
+	UserID    string     `json:"user_id,omitempty"`
+	Quantity  int        `json:"quantity"`
+	Stock     int        `json:"stock"`
+	Reason    string     `json:"reason"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type StockAdjustmentsResult struct {
+	Data     []StockAdjustment
+	PageInfo pagination.PageInfo
 }
 
 type Publisher struct {
@@ -29,19 +133,21 @@ type Genre struct {
 }
 
 type Book struct {
-	ID               string     `json:"id"`
-	Title            string     `json:"title"`
-	Description      string     `json:"description"`
-	Price            float64    `json:"price"`
-	ISBN             string     `json:"isbn"`
-	Language         string     `json:"language"`
-	Edition          string     `json:"edition"`
-	Pages            int        `json:"pages"`
-	PublishedAt      *time.Time `json:"published_at"`
-	FirstPublishedAt *time.Time `json:"first_published_at"`
-	CoverImg         string     `json:"cover_img"`
-	Rating           float64    `json:"rating"`
-	Publisher        Publisher  `json:"publisher"`
-	Authors          []Author   `json:"authors"`
-	Genres           []Genre    `json:"genres"`
+	ID               string      `json:"id"`
+	Title            string      `json:"title"`
+	Description      string      `json:"description"`
+	Price            money.Money `json:"price"`
+	ISBN             string      `json:"isbn"`
+	Language         string      `json:"language"`
+	Edition          string      `json:"edition"`
+	Pages            int         `json:"pages"`
+	PublishedAt      *time.Time  `json:"published_at"`
+	FirstPublishedAt *time.Time  `json:"first_published_at"`
+	CoverImg         string      `json:"cover_img"`
+	Rating           float64     `json:"rating"`
+	Stock            int         `json:"stock"`
+	Weight           int         `json:"weight"` // in grams
+	Publisher        Publisher   `json:"publisher"`
+	Authors          []Author    `json:"authors"`
+	Genres           []Genre     `json:"genres"`
 }
@@ -0,0 +1,30 @@
+package book
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrInvalidSort = errors.New("invalid sort")
+
+	ErrInvalidReference  = errors.New("unknown publisher, author or genre")
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+// InsufficientStockError is returned when the book stock is less than the requested quantity,
+// it matches ErrInsufficientStock using errors.Is.
+type InsufficientStockError struct {
+	BookID    string `json:"book_id"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock of book %s: requested %d, available %d", e.BookID, e.Requested, e.Available)
+}
+
+func (e *InsufficientStockError) Is(target error) bool {
+	return target == ErrInsufficientStock
+}
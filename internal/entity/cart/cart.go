@@ -0,0 +1,46 @@
+package cart
+
+import (
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// Owner refers to the user cart when the user id is set, otherwise to the guest cart of the cart id.
+type Owner struct {
+	UserID string
+	CartID string
+}
+
+type ItemParam struct {
+	BookID   string
+	Quantity int
+}
+
+type CheckoutParam struct {
+	ShippingAddressID string
+	VoucherCodes      []string
+	// Currency the order is charged in, empty keeps the currency of the books.
+	Currency money.Currency
+}
+
+// Cart is the books picked before checkout, the prices are taken from the books on every read.
+type Cart struct {
+	ID        string      `json:"id"`
+	UserID    string      `json:"user_id,omitempty"`
+	Items     []Item      `json:"items"`
+	Total     money.Money `json:"total"`
+	CreatedAt *time.Time  `json:"created_at"`
+	UpdatedAt *time.Time  `json:"updated_at"`
+}
+
+// Item is the book in the cart, the item of the removed book is kept unavailable until the user removes it.
+type Item struct {
+	BookID    string      `json:"book_id"`
+	Book      *book.Book  `json:"book,omitempty"`
+	Quantity  int         `json:"quantity"`
+	Price     money.Money `json:"price"`
+	Subtotal  money.Money `json:"subtotal"`
+	Available bool        `json:"available"`
+}
@@ -0,0 +1,9 @@
+package cart
+
+import "errors"
+
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrEmpty       = errors.New("cart is empty")
+	ErrInvalidItem = errors.New("unknown book")
+)
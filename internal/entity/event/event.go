@@ -0,0 +1,40 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+)
+
+type Type = string
+
+const (
+	// TypeOrderPlaced carries the placed order.Main with its lines.
+	TypeOrderPlaced Type = "order.placed"
+	// TypeOrderStatusChanged carries the OrderStatusChanged.
+	TypeOrderStatusChanged Type = "order.status_changed"
+	// TypeUserRegistered carries the registered user.User, without the password.
+	TypeUserRegistered Type = "user.registered"
+)
+
+// Event is the domain event, it is written into the outbox in the same transaction as the change it describes
+// and is delivered to the handlers afterward. The event may be delivered more than once, the handlers use
+// the ID to recognize the redelivery.
+type Event struct {
+	ID          string          `json:"id"`
+	Type        Type            `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	CreatedAt   *time.Time      `json:"created_at"`
+}
+
+// OrderStatusChanged is the payload of the order status transition.
+type OrderStatusChanged struct {
+	OrderID    string       `json:"order_id"`
+	FromStatus order.Status `json:"from_status"`
+	Status     order.Status `json:"status"`
+	Reason     string       `json:"reason,omitempty"`
+	ChangedAt  *time.Time   `json:"changed_at"`
+}
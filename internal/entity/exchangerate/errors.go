@@ -0,0 +1,9 @@
+package exchangerate
+
+import "errors"
+
+var (
+	ErrNotFound            = errors.New("exchange rate not found")
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	ErrBaseCurrency        = errors.New("the base currency cannot have an exchange rate")
+)
@@ -0,0 +1,17 @@
+package exchangerate
+
+import (
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// ExchangeRate is the locally managed rate of the currency against the base currency,
+// e.g. 15750.5 IDR for 1 USD.
+type ExchangeRate struct {
+	BaseCurrency money.Currency `json:"base_currency"`
+	Currency     money.Currency `json:"currency"`
+	Rate         money.Rate     `json:"rate"`
+	CreatedAt    *time.Time     `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time     `json:"updated_at,omitempty"`
+}
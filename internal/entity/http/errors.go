@@ -2,4 +2,7 @@ package http
 
 import "errors"
 
-var ErrUnauthorized = errors.New("unauthorized")
+var (
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrNotAcceptable = errors.New("not acceptable")
+)
@@ -0,0 +1,8 @@
+package invoice
+
+import "errors"
+
+var (
+	ErrNotFound       = errors.New("invoice not found")
+	ErrNotInvoiceable = errors.New("cancelled order cannot be invoiced")
+)
@@ -0,0 +1,69 @@
+package invoice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// Invoice is the bill of the order. The number is assigned sequentially when the invoice of the order is first
+// issued and is kept, the items and the totals are built from the order.
+type Invoice struct {
+	ID       string     `json:"id"`
+	Number   int64      `json:"number"`
+	OrderID  string     `json:"order_id"`
+	IssuedAt *time.Time `json:"issued_at"`
+
+	OrderStatus  order.Status     `json:"order_status"`
+	OrderedAt    *time.Time       `json:"ordered_at"`
+	BillTo       *address.Address `json:"bill_to,omitempty"`
+	Currency     money.Currency   `json:"currency"`
+	BaseCurrency money.Currency   `json:"base_currency"`
+	ExchangeRate money.Rate       `json:"exchange_rate"`
+	Items        []Item           `json:"items"`
+	Subtotal     money.Money      `json:"subtotal"`
+	Adjustments  []Adjustment     `json:"adjustments"`
+	GrandTotal   money.Money      `json:"grand_total"`
+}
+
+// Code is the printed invoice number, e.g. INV-000042.
+func (i Invoice) Code() string {
+	return fmt.Sprintf("INV-%06d", i.Number)
+}
+
+// Item is the book bought by the order.
+type Item struct {
+	BookID    string      `json:"book_id"`
+	Title     string      `json:"title"`
+	ISBN      string      `json:"isbn,omitempty"`
+	Authors   []string    `json:"authors,omitempty"`
+	Quantity  int         `json:"quantity"`
+	UnitPrice money.Money `json:"unit_price"`
+	Amount    money.Money `json:"amount"`
+}
+
+// Adjustment is the order line added on top of the items subtotal, e.g. the discount or the shipping fee.
+type Adjustment struct {
+	Type   order.LineReferenceType `json:"type"`
+	Label  string                  `json:"label"`
+	Amount money.Money             `json:"amount"`
+}
+
+var adjustmentLabels = map[order.LineReferenceType]string{
+	order.LineReferenceTypeDiscount:    "Discount",
+	order.LineReferenceTypeShippingFee: "Shipping",
+	order.LineReferenceTypeTax:         "Tax",
+	order.LineReferenceTypePlatformFee: "Platform fee",
+}
+
+// AdjustmentLabel is the printed name of the order line type.
+func AdjustmentLabel(lineType order.LineReferenceType) string {
+	if label, ok := adjustmentLabels[lineType]; ok {
+		return label
+	}
+
+	return lineType
+}
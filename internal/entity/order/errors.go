@@ -0,0 +1,12 @@
+package order
+
+import "errors"
+
+var (
+	ErrNotFound                = errors.New("not found")
+	ErrOrderLineInvalid        = errors.New("order line invalid")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrNotCancellable          = errors.New("order can no longer be cancelled")
+	ErrShippingAddressRequired = errors.New("shipping address is required")
+	ErrCurrencyMismatch        = errors.New("cannot price the order in different currencies")
+)
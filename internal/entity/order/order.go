@@ -3,27 +3,22 @@ package order
 import (
 	"time"
 
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 )
 
 type PaginationParam struct {
 	PerPage int
-	LastID  string
+	Cursor  string
 }
 
 type PaginationResult struct {
-	Data    []Main
-	PerPage int
-	LastID  string
+	Data     []Main
+	PageInfo pagination.PageInfo
 }
 
-type Status = string
-
-const (
-	StatusCreated Status = "created"
-	StatusDone    Status = "done"
-)
-
 type LineReferenceType = string
 type LineReference interface {
 	book.Book
@@ -31,25 +26,46 @@ type LineReference interface {
 
 const (
 	LineReferenceTypeBook LineReferenceType = "book"
+	// LineReferenceTypeDiscount refers to the applied voucher, its amount and subtotal are negative.
+	LineReferenceTypeDiscount LineReferenceType = "discount"
+	// LineReferenceTypeShippingFee refers to the shipping address of the order.
+	LineReferenceTypeShippingFee LineReferenceType = "shipping_fee"
+	// LineReferenceTypeTax refers to the region of the shipping address.
+	LineReferenceTypeTax         LineReferenceType = "tax"
+	LineReferenceTypePlatformFee LineReferenceType = "platform_fee"
 )
 
 type Main struct {
-	ID         string     `json:"id"`
-	UserID     string     `json:"user_id"`
-	GrandTotal float64    `json:"grand_total"`
-	Status     Status     `json:"status"`
-	Lines      []Line     `json:"lines"`
-	CreatedAt  *time.Time `json:"created_at"`
-	UpdatedAt  *time.Time `json:"updated_at"`
+	ID         string      `json:"id"`
+	UserID     string      `json:"user_id"`
+	GrandTotal money.Money `json:"grand_total"`
+	// Currency is the currency the order is charged in, it is requested when placing the order and
+	// defaults to the currency of the books.
+	Currency money.Currency `json:"currency"`
+	// BaseCurrency is the currency of the books, the ExchangeRate converted their prices into the order currency.
+	// The rate is kept on the order thus later rate changes do not alter the placed order.
+	BaseCurrency money.Currency `json:"base_currency"`
+	ExchangeRate money.Rate     `json:"exchange_rate"`
+	Status       Status         `json:"status"`
+	Lines        []Line         `json:"lines"`
+	Histories    []History      `json:"histories,omitempty"`
+	// VoucherCodes are applied when placing the order, the applied vouchers are kept as the discount lines.
+	VoucherCodes []string `json:"-"`
+	// ShippingAddressID refers to the address book entry when placing the order, the order keeps the copy of
+	// the address thus later changes in the address book do not alter the placed order.
+	ShippingAddressID string           `json:"-"`
+	ShippingAddress   *address.Address `json:"shipping_address,omitempty"`
+	CreatedAt         *time.Time       `json:"created_at"`
+	UpdatedAt         *time.Time       `json:"updated_at"`
 }
 
 type Line struct {
-	ID                string  `json:"id"`
-	OrderID           string  `json:"order_id"`
-	LineReferenceType string  `json:"line_reference_type"`
-	LineReferenceID   string  `json:"line_reference_id"`
-	LineItem          any     `json:"line_item"`
-	Amount            float64 `json:"amount"`
-	Quantity          int     `json:"quantity"`
-	Subtotal          float64 `json:"subtotal"`
+	ID                string      `json:"id"`
+	OrderID           string      `json:"order_id"`
+	LineReferenceType string      `json:"line_reference_type"`
+	LineReferenceID   string      `json:"line_reference_id"`
+	LineItem          any         `json:"line_item"`
+	Amount            money.Money `json:"amount"`
+	Quantity          int         `json:"quantity"`
+	Subtotal          money.Money `json:"subtotal"`
 }
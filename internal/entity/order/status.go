@@ -0,0 +1,85 @@
+package order
+
+import "time"
+
+type Status = string
+
+const (
+	StatusPendingPayment Status = "pending_payment"
+	StatusPaid           Status = "paid"
+	StatusProcessing     Status = "processing"
+	StatusShipped        Status = "shipped"
+	StatusDelivered      Status = "delivered"
+	StatusCancelled      Status = "cancelled"
+	StatusRefunded       Status = "refunded"
+)
+
+// statusTransitions lists the next statuses allowed from each status, the cancelled and refunded orders are final.
+var statusTransitions = map[Status][]Status{
+	StatusPendingPayment: {StatusPaid, StatusCancelled},
+	StatusPaid:           {StatusProcessing, StatusCancelled, StatusRefunded},
+	StatusProcessing:     {StatusShipped, StatusCancelled, StatusRefunded},
+	StatusShipped:        {StatusDelivered},
+	StatusDelivered:      {StatusRefunded},
+}
+
+// customerCancellableStatuses are the statuses in which the customer may cancel the order, the paid order
+// has to be refunded by the staff thus its payment is not left behind on the cancelled order.
+var customerCancellableStatuses = []Status{StatusPendingPayment}
+
+// CustomerCanCancel tells whether the customer may cancel the order in the status.
+func CustomerCanCancel(status Status) bool {
+	for _, cancellable := range customerCancellableStatuses {
+		if cancellable == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unshippedStatuses are the statuses in which the books of the order have not left the store yet.
+var unshippedStatuses = []Status{StatusPendingPayment, StatusPaid, StatusProcessing}
+
+// ReleasesReservation tells whether moving the order from the status into the next status gives the reserved stock
+// and the voucher usage back, i.e. the order is cancelled or refunded before it is shipped.
+func ReleasesReservation(from Status, to Status) bool {
+	if to != StatusCancelled && to != StatusRefunded {
+		return false
+	}
+
+	for _, unshipped := range unshippedStatuses {
+		if unshipped == from {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanTransition tells whether the order in the from status can be moved into the to status.
+func CanTransition(from Status, to Status) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransitionParam moves the order into the status, the reason is kept on the order history.
+type TransitionParam struct {
+	OrderID string
+	Status  Status
+	Reason  string
+}
+
+// History is the timestamped status transition of the order, the initial status has no previous status.
+type History struct {
+	ID         string     `json:"id"`
+	FromStatus Status     `json:"from_status,omitempty"`
+	Status     Status     `json:"status"`
+	Reason     string     `json:"reason,omitempty"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
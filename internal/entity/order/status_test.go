@@ -0,0 +1,74 @@
+package order
+
+import "testing"
+
+func TestCustomerCanCancel(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   bool
+	}{
+		{status: StatusPendingPayment, want: true},
+		{status: StatusPaid, want: false},
+		{status: StatusProcessing, want: false},
+		{status: StatusShipped, want: false},
+		{status: StatusCancelled, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := CustomerCanCancel(tt.status); got != tt.want {
+				t.Errorf("CustomerCanCancel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from Status
+		to   Status
+		want bool
+	}{
+		{from: StatusPendingPayment, to: StatusPaid, want: true},
+		{from: StatusPendingPayment, to: StatusCancelled, want: true},
+		{from: StatusPendingPayment, to: StatusShipped, want: false},
+		{from: StatusPaid, to: StatusProcessing, want: true},
+		{from: StatusProcessing, to: StatusShipped, want: true},
+		{from: StatusShipped, to: StatusDelivered, want: true},
+		{from: StatusShipped, to: StatusCancelled, want: false},
+		{from: StatusDelivered, to: StatusRefunded, want: true},
+		{from: StatusCancelled, to: StatusPaid, want: false},
+		{from: StatusRefunded, to: StatusPaid, want: false},
+		{from: StatusPaid, to: StatusPaid, want: false},
+		{from: "unknown", to: StatusPaid, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"_"+tt.to, func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleasesReservation(t *testing.T) {
+	tests := []struct {
+		from Status
+		to   Status
+		want bool
+	}{
+		{from: StatusPendingPayment, to: StatusCancelled, want: true},
+		{from: StatusPaid, to: StatusCancelled, want: true},
+		{from: StatusPaid, to: StatusRefunded, want: true},
+		{from: StatusProcessing, to: StatusRefunded, want: true},
+		{from: StatusDelivered, to: StatusRefunded, want: false},
+		{from: StatusPaid, to: StatusProcessing, want: false},
+		{from: StatusShipped, to: StatusDelivered, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"_"+tt.to, func(t *testing.T) {
+			if got := ReleasesReservation(tt.from, tt.to); got != tt.want {
+				t.Errorf("ReleasesReservation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
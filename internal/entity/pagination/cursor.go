@@ -0,0 +1,50 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points to the last item of a page. Key holds the sort key value of the item and ID breaks the tie
+// between the items sharing the same sort key, thus the next page can be fetched using keyset pagination
+// whatever the ordering is. Sort records the ordering the cursor is issued for. Backward cursor points to the
+// first item of a page instead, it is used to fetch the previous page.
+type Cursor struct {
+	Sort     string `json:"s,omitempty"`
+	Key      any    `json:"k,omitempty"`
+	ID       string `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// Encode returns the opaque token of the cursor to be passed to the client.
+func (c Cursor) Encode() string {
+	contents, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(contents)
+}
+
+// DecodeCursor parses the opaque token, empty token results in empty cursor which points to the first page.
+func DecodeCursor(token string) (Cursor, error) {
+	var cursor Cursor
+
+	if token == "" {
+		return cursor, nil
+	}
+
+	contents, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(contents, &cursor); err != nil || cursor.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCursor_Encode(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{
+			name:   "can encode id only cursor",
+			cursor: Cursor{ID: "01926c92-1827-7817-975f-5f5d6db677af"},
+		},
+		{
+			name:   "can encode numeric sort key",
+			cursor: Cursor{Sort: "-price", Key: 12.99, ID: "1"},
+		},
+		{
+			name:   "can encode text sort key",
+			cursor: Cursor{Sort: "title", Key: "The Hunger Games", ID: "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Errorf("DecodeCursor() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.cursor) {
+				t.Errorf("DecodeCursor() got = %v, want %v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    Cursor
+		wantErr bool
+	}{
+		{
+			name:    "can decode empty token as first page",
+			token:   "",
+			want:    Cursor{},
+			wantErr: false,
+		},
+		{
+			name:    "can reject non base64 token",
+			token:   "not a cursor!",
+			want:    Cursor{},
+			wantErr: true,
+		},
+		{
+			name:    "can reject cursor without id",
+			token:   Cursor{Key: 1.5}.Encode(),
+			want:    Cursor{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DecodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeCursor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
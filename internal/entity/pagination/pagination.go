@@ -1,17 +1,70 @@
 package pagination
 
-type Param struct {
-	PerPage int
-	LastID  string
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+type PageInfo struct {
+	PerPage int    `json:"per_page"`
+	Next    string `json:"next"`
+	Prev    string `json:"prev"`
+	HasMore bool   `json:"has_more"`
 }
 
-type Base struct {
-	Data    interface{} `json:"data"`
-	PerPage int         `json:"per_page"`
-	LastID  string      `json:"last_id"`
+// Limit returns the page size to be fetched, it falls back to the default when the client does not ask for any
+// and is capped to the server side maximum.
+func Limit(perPage int) int {
+	if perPage <= 0 {
+		return DefaultPerPage
+	}
+
+	if perPage > MaxPerPage {
+		return MaxPerPage
+	}
+
+	return perPage
 }
 
-type PageInfo struct {
-	PerPage int    `json:"per_page"`
-	LastID  string `json:"last_id"`
+// Paginate trims the rows of a keyset query into a page. The query is expected to fetch one row more than
+// the page size, following the direction of the cursor, the extra row tells there are more items that way.
+// The rows fetched backward are reversed back into the page order. cursorOf builds the cursor pointing to
+// the given row.
+func Paginate[T any](rows []T, cursor Cursor, perPage int, cursorOf func(row T) Cursor) ([]T, PageInfo) {
+	hasExtraRow := len(rows) > perPage
+	if hasExtraRow {
+		rows = rows[:perPage]
+	}
+
+	if cursor.Backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	pageInfo := PageInfo{PerPage: perPage}
+	if len(rows) == 0 {
+		return rows, pageInfo
+	}
+
+	// going backward means the page was reached from the one after, thus there are always more items.
+	hasNext := hasExtraRow
+	hasPrev := cursor.ID != ""
+	if cursor.Backward {
+		hasNext = true
+		hasPrev = hasExtraRow
+	}
+
+	if hasNext {
+		pageInfo.Next = cursorOf(rows[len(rows)-1]).Encode()
+		pageInfo.HasMore = true
+	}
+
+	if hasPrev {
+		prev := cursorOf(rows[0])
+		prev.Backward = true
+		pageInfo.Prev = prev.Encode()
+	}
+
+	return rows, pageInfo
 }
@@ -0,0 +1,116 @@
+package pagination
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		perPage int
+		want    int
+	}{
+		{
+			name:    "can default the page size",
+			perPage: 0,
+			want:    DefaultPerPage,
+		},
+		{
+			name:    "can keep the requested page size",
+			perPage: 5,
+			want:    5,
+		},
+		{
+			name:    "can cap the page size",
+			perPage: MaxPerPage + 1,
+			want:    MaxPerPage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Limit(tt.perPage); got != tt.want {
+				t.Errorf("Limit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	cursorOf := func(row string) Cursor {
+		return Cursor{ID: row}
+	}
+
+	tests := []struct {
+		name         string
+		rows         []string
+		cursor       Cursor
+		want         []string
+		wantPageInfo PageInfo
+	}{
+		{
+			name:   "can paginate the first page",
+			rows:   []string{"1", "2", "3"},
+			cursor: Cursor{},
+			want:   []string{"1", "2"},
+			wantPageInfo: PageInfo{
+				PerPage: 2,
+				Next:    Cursor{ID: "2"}.Encode(),
+				HasMore: true,
+			},
+		},
+		{
+			name:   "can paginate the last page",
+			rows:   []string{"3"},
+			cursor: Cursor{ID: "2"},
+			want:   []string{"3"},
+			wantPageInfo: PageInfo{
+				PerPage: 2,
+				Prev:    Cursor{ID: "3", Backward: true}.Encode(),
+			},
+		},
+		{
+			name:   "can paginate backward",
+			rows:   []string{"4", "3", "2"},
+			cursor: Cursor{ID: "5", Backward: true},
+			want:   []string{"3", "4"},
+			wantPageInfo: PageInfo{
+				PerPage: 2,
+				Next:    Cursor{ID: "4"}.Encode(),
+				Prev:    Cursor{ID: "3", Backward: true}.Encode(),
+				HasMore: true,
+			},
+		},
+		{
+			name:   "can paginate backward to the first page",
+			rows:   []string{"2", "1"},
+			cursor: Cursor{ID: "3", Backward: true},
+			want:   []string{"1", "2"},
+			wantPageInfo: PageInfo{
+				PerPage: 2,
+				Next:    Cursor{ID: "2"}.Encode(),
+				HasMore: true,
+			},
+		},
+		{
+			name:   "can paginate empty rows",
+			rows:   []string{},
+			cursor: Cursor{ID: "3"},
+			want:   []string{},
+			wantPageInfo: PageInfo{
+				PerPage: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotPageInfo := Paginate(tt.rows, tt.cursor, 2, cursorOf)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotPageInfo, tt.wantPageInfo) {
+				t.Errorf("Paginate() gotPageInfo = %v, want %v", gotPageInfo, tt.wantPageInfo)
+			}
+		})
+	}
+}
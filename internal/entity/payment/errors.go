@@ -0,0 +1,11 @@
+package payment
+
+import "errors"
+
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrOrderNotPayable = errors.New("order is not pending the payment")
+	ErrAmountMismatch  = errors.New("paid amount does not match the order")
+	ErrAlreadySettled  = errors.New("payment already settled")
+	ErrNotOrderOwner   = errors.New("order belongs to another user")
+)
@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type Status = string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// CreateIntentParam asks the gateway to collect the payment of the user order, empty gateway uses the default one.
+type CreateIntentParam struct {
+	UserID  string
+	OrderID string
+	Gateway string
+}
+
+// Transaction is the payment of the order on the gateway, it is settled by the gateway notification.
+type Transaction struct {
+	ID         string      `json:"id"`
+	OrderID    string      `json:"order_id"`
+	Gateway    string      `json:"gateway"`
+	Reference  string      `json:"reference"`
+	Amount     money.Money `json:"amount"`
+	Status     Status      `json:"status"`
+	PaymentURL string      `json:"payment_url"`
+	CreatedAt  *time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time  `json:"updated_at"`
+}
@@ -1,11 +1,28 @@
 package user
 
+import "time"
+
 type AuthenticateParam struct {
 	Email    string
 	Password string
+	// UserAgent is kept with the issued session, thus the user tells the sessions apart.
+	UserAgent string
+}
+
+// Session is the logged in session of the user.
+type Session struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiredAt time.Time `json:"expired_at"`
 }
 
 type AuthenticateResult struct {
-	User  User   `json:"user"`
-	Token string `json:"token"`
+	User      User      `json:"user"`
+	Token     string    `json:"token"`
+	ExpiredAt time.Time `json:"expired_at"`
+	// RefreshToken issues the next pair of tokens once the token is expired, it can be used only once.
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiredAt time.Time `json:"refresh_expired_at"`
 }
@@ -6,4 +6,7 @@ var (
 	ErrEmailAlreadyRegistered = errors.New("email already registered")
 	ErrEmailIsNotRegistered   = errors.New("email is not registered")
 	ErrNotFound               = errors.New("not found")
+	ErrSessionNotFound        = errors.New("session not found")
+	ErrInvalidRefreshToken    = errors.New("invalid refresh token")
+	ErrRefreshTokenReused     = errors.New("refresh token reused")
 )
@@ -1,8 +1,18 @@
 package user
 
+// Role is the access level of the user, it is embedded on the issued token as the session type.
+type Role = string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleStaff    Role = "staff"
+	RoleAdmin    Role = "admin"
+)
+
 type User struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"-"`
+	Role     Role   `json:"role"`
 }
@@ -0,0 +1,13 @@
+package voucher
+
+import "errors"
+
+var (
+	ErrNotFound           = errors.New("voucher not found")
+	ErrCodeAlreadyExists  = errors.New("voucher code already exists")
+	ErrInvalidRule        = errors.New("invalid voucher rule")
+	ErrNotActive          = errors.New("voucher is not active")
+	ErrUsageLimitReached  = errors.New("voucher usage limit reached")
+	ErrMinimumSpendNotMet = errors.New("minimum spend of the voucher is not met")
+	ErrNotEligible        = errors.New("voucher is not applicable to the ordered books")
+)
@@ -0,0 +1,173 @@
+package voucher
+
+import (
+	"slices"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type DiscountType = string
+
+const (
+	// DiscountTypePercentage takes the percentage of the eligible subtotal, capped by the max discount.
+	DiscountTypePercentage DiscountType = "percentage"
+	// DiscountTypeFixed takes the amount off the eligible subtotal.
+	DiscountTypeFixed DiscountType = "fixed"
+)
+
+type ScopeType = string
+
+const (
+	ScopeTypeGenre     ScopeType = "genre"
+	ScopeTypeAuthor    ScopeType = "author"
+	ScopeTypePublisher ScopeType = "publisher"
+)
+
+// Voucher is the discount rule redeemed by its code, the rule without the scope applies to every ordered book.
+// Zero max discount and zero usage limit mean unlimited.
+type Voucher struct {
+	ID           string       `json:"id"`
+	Code         string       `json:"code"`
+	Description  string       `json:"description"`
+	DiscountType DiscountType `json:"discount_type"`
+	Percentage   float64      `json:"percentage,omitempty"`
+	Amount       money.Money  `json:"amount"`
+	MaxDiscount  money.Money  `json:"max_discount"`
+	MinSpend     money.Money  `json:"min_spend"`
+	ScopeType    ScopeType    `json:"scope_type,omitempty"`
+	ScopeID      string       `json:"scope_id,omitempty"`
+	UsageLimit   int          `json:"usage_limit"`
+	UsedCount    int          `json:"used_count"`
+	StartsAt     *time.Time   `json:"starts_at"`
+	EndsAt       *time.Time   `json:"ends_at"`
+	CreatedAt    *time.Time   `json:"created_at"`
+	UpdatedAt    *time.Time   `json:"updated_at"`
+}
+
+// Item is the ordered book evaluated by the voucher rule.
+type Item struct {
+	Book     book.Book
+	Subtotal money.Money
+}
+
+// Validate checks the rule definition before the voucher is stored.
+func (v Voucher) Validate() error {
+	switch v.DiscountType {
+	case DiscountTypePercentage:
+		if v.Percentage <= 0 || v.Percentage > 100 {
+			return ErrInvalidRule
+		}
+	case DiscountTypeFixed:
+		if !v.Amount.IsPositive() {
+			return ErrInvalidRule
+		}
+	}
+
+	if v.MaxDiscount.IsNegative() || v.MinSpend.IsNegative() || !v.sameCurrency(v.Currency()) {
+		return ErrInvalidRule
+	}
+
+	if (v.ScopeType == "") != (v.ScopeID == "") {
+		return ErrInvalidRule
+	}
+
+	if v.StartsAt != nil && v.EndsAt != nil && !v.EndsAt.After(*v.StartsAt) {
+		return ErrInvalidRule
+	}
+
+	return nil
+}
+
+// Active reports whether the voucher can be redeemed at the time, regardless of the ordered books.
+func (v Voucher) Active(now time.Time) error {
+	if v.StartsAt != nil && now.Before(*v.StartsAt) {
+		return ErrNotActive
+	}
+
+	if v.EndsAt != nil && !now.Before(*v.EndsAt) {
+		return ErrNotActive
+	}
+
+	if v.UsageLimit > 0 && v.UsedCount >= v.UsageLimit {
+		return ErrUsageLimitReached
+	}
+
+	return nil
+}
+
+// Applies reports whether the book is in the voucher scope.
+func (v Voucher) Applies(b book.Book) bool {
+	switch v.ScopeType {
+	case ScopeTypeGenre:
+		return slices.ContainsFunc(b.Genres, func(genre book.Genre) bool { return genre.ID == v.ScopeID })
+	case ScopeTypeAuthor:
+		return slices.ContainsFunc(b.Authors, func(author book.Author) bool { return author.ID == v.ScopeID })
+	case ScopeTypePublisher:
+		return b.Publisher.ID == v.ScopeID
+	}
+
+	return true
+}
+
+// Discount calculates the discount of the ordered items, the minimum spend is compared against
+// the subtotal of the items in the voucher scope and the discount never exceeds that subtotal.
+// The percentage discount is rounded down to the minor unit.
+func (v Voucher) Discount(items []Item, now time.Time) (money.Money, error) {
+	if err := v.Active(now); err != nil {
+		return money.Money{}, err
+	}
+
+	var eligible money.Money
+	for _, item := range items {
+		if v.Applies(item.Book) {
+			eligible = eligible.Add(item.Subtotal)
+		}
+	}
+
+	if eligible.IsZero() || !v.sameCurrency(eligible.Currency()) {
+		return money.Money{}, ErrNotEligible
+	}
+
+	if eligible.Cmp(v.MinSpend) < 0 {
+		return money.Money{}, ErrMinimumSpendNotMet
+	}
+
+	var discount money.Money
+	switch v.DiscountType {
+	case DiscountTypePercentage:
+		discount = eligible.Percent(v.Percentage, money.RoundFloor)
+		if v.MaxDiscount.IsPositive() {
+			discount = money.Min(discount, v.MaxDiscount)
+		}
+	case DiscountTypeFixed:
+		discount = v.Amount
+	default:
+		return money.Money{}, ErrInvalidRule
+	}
+
+	return money.Min(discount, eligible), nil
+}
+
+// Currency is the currency of the money rules, the default currency is used when every rule is zero.
+func (v Voucher) Currency() money.Currency {
+	for _, m := range []money.Money{v.Amount, v.MaxDiscount, v.MinSpend} {
+		if !m.IsZero() && m.Currency() != "" {
+			return m.Currency()
+		}
+	}
+
+	return money.DefaultCurrency
+}
+
+// sameCurrency reports whether the money rules are stated in the currency, the zero amount has no currency to compare.
+func (v Voucher) sameCurrency(currency money.Currency) bool {
+	for _, m := range []money.Money{v.Amount, v.MaxDiscount, v.MinSpend} {
+		if !m.IsZero() && m.Currency() != currency {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,152 @@
+package voucher
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestVoucher_Discount(t *testing.T) {
+	now := time.Date(2024, 10, 6, 0, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	items := []Item{
+		{
+			Book: book.Book{
+				ID:        "1",
+				Publisher: book.Publisher{ID: "p1"},
+				Authors:   []book.Author{{ID: "a1"}},
+				Genres:    []book.Genre{{ID: "g1"}, {ID: "g2"}},
+			},
+			Subtotal: money.New(4000, money.USD),
+		},
+		{
+			Book: book.Book{
+				ID:        "2",
+				Publisher: book.Publisher{ID: "p2"},
+				Authors:   []book.Author{{ID: "a2"}},
+				Genres:    []book.Genre{{ID: "g2"}},
+			},
+			Subtotal: money.New(1050, money.USD),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		voucher Voucher
+		want    money.Money
+		wantErr error
+	}{
+		{
+			name:    "can take the percentage of every book",
+			voucher: Voucher{DiscountType: DiscountTypePercentage, Percentage: 10},
+			want:    money.New(505, money.USD),
+		},
+		{
+			name:    "can cap the percentage discount",
+			voucher: Voucher{DiscountType: DiscountTypePercentage, Percentage: 10, MaxDiscount: money.New(300, money.USD)},
+			want:    money.New(300, money.USD),
+		},
+		{
+			name:    "can round the percentage discount down",
+			voucher: Voucher{DiscountType: DiscountTypePercentage, Percentage: 33, ScopeType: ScopeTypePublisher, ScopeID: "p2"},
+			want:    money.New(346, money.USD),
+		},
+		{
+			name:    "can take the fixed amount",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(750, money.USD)},
+			want:    money.New(750, money.USD),
+		},
+		{
+			name:    "can limit the discount to the eligible subtotal",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(2000, money.USD), ScopeType: ScopeTypeAuthor, ScopeID: "a2"},
+			want:    money.New(1050, money.USD),
+		},
+		{
+			name:    "can scope the discount by genre",
+			voucher: Voucher{DiscountType: DiscountTypePercentage, Percentage: 50, ScopeType: ScopeTypeGenre, ScopeID: "g1"},
+			want:    money.New(2000, money.USD),
+		},
+		{
+			name:    "can scope the discount by publisher",
+			voucher: Voucher{DiscountType: DiscountTypePercentage, Percentage: 50, ScopeType: ScopeTypePublisher, ScopeID: "p2"},
+			want:    money.New(525, money.USD),
+		},
+		{
+			name:    "can reject the order without book in scope",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(500, money.USD), ScopeType: ScopeTypeGenre, ScopeID: "g9"},
+			wantErr: ErrNotEligible,
+		},
+		{
+			name:    "can compare the minimum spend against the eligible subtotal",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(500, money.USD), MinSpend: money.New(2000, money.USD), ScopeType: ScopeTypeGenre, ScopeID: "g1"},
+			want:    money.New(500, money.USD),
+		},
+		{
+			name:    "can reject the order below the minimum spend",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(500, money.USD), MinSpend: money.New(2000, money.USD), ScopeType: ScopeTypePublisher, ScopeID: "p2"},
+			wantErr: ErrMinimumSpendNotMet,
+		},
+		{
+			name:    "can reject the order in another currency",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(500, money.EUR)},
+			wantErr: ErrNotEligible,
+		},
+		{
+			name:    "can reject the voucher before its validity window",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(500, money.USD), StartsAt: &tomorrow},
+			wantErr: ErrNotActive,
+		},
+		{
+			name:    "can reject the expired voucher",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(500, money.USD), StartsAt: &yesterday, EndsAt: &now},
+			wantErr: ErrNotActive,
+		},
+		{
+			name:    "can reject the fully used voucher",
+			voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(500, money.USD), UsageLimit: 2, UsedCount: 2},
+			wantErr: ErrUsageLimitReached,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.voucher.Discount(items, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Discount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("Discount() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVoucher_Validate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		voucher Voucher
+		wantErr error
+	}{
+		{name: "valid", voucher: Voucher{DiscountType: DiscountTypePercentage, Percentage: 100}},
+		{name: "percentage above 100", voucher: Voucher{DiscountType: DiscountTypePercentage, Percentage: 101}, wantErr: ErrInvalidRule},
+		{name: "fixed without amount", voucher: Voucher{DiscountType: DiscountTypeFixed}, wantErr: ErrInvalidRule},
+		{name: "mixed currencies", voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(100, money.USD), MinSpend: money.New(100, money.EUR)}, wantErr: ErrInvalidRule},
+		{name: "scope without id", voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(100, money.USD), ScopeType: ScopeTypeGenre}, wantErr: ErrInvalidRule},
+		{name: "ends before starts", voucher: Voucher{DiscountType: DiscountTypeFixed, Amount: money.New(100, money.USD), StartsAt: &now, EndsAt: &now}, wantErr: ErrInvalidRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.voucher.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrNotFound             = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrUnsupportedEventType = errors.New("event type is not supported by the webhooks")
+)
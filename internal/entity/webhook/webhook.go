@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+)
+
+// EventTypes are the event types the subscription can receive.
+var EventTypes = []event.Type{
+	event.TypeOrderPlaced,
+	event.TypeOrderStatusChanged,
+}
+
+// Subscription is the receiver of the events, the payloads are signed with the secret.
+type Subscription struct {
+	ID         string       `json:"id"`
+	URL        string       `json:"url"`
+	Secret     string       `json:"-"`
+	EventTypes []event.Type `json:"event_types"`
+	Active     bool         `json:"active"`
+	CreatedAt  *time.Time   `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time   `json:"updated_at,omitempty"`
+}
+
+// Accepts reports whether the subscription receives the event type.
+func (s Subscription) Accepts(eventType event.Type) bool {
+	return s.Active && slices.Contains(s.EventTypes, eventType)
+}
+
+type WriteParam struct {
+	URL string
+	// Secret is kept unchanged on update when it is empty.
+	Secret     string
+	EventTypes []event.Type
+	Active     bool
+}
+
+type DeliveryStatus = string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// Payload is the body posted to the receiver.
+type Payload struct {
+	ID        string          `json:"id"`
+	Type      event.Type      `json:"type"`
+	CreatedAt *time.Time      `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Delivery is the event sent to one subscription, it keeps the outcome of the last attempt.
+type Delivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      event.Type      `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	ResponseBody   string          `json:"response_body,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+}
+
+// AttemptResult is the outcome of the delivery attempt, the response status is zero when the receiver
+// was not reached.
+type AttemptResult struct {
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+}
@@ -0,0 +1,199 @@
+package address
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type addressBookUseCase interface {
+	List(ctx context.Context, userID string) ([]address.Address, error)
+	Get(ctx context.Context, userID, id string) (address.Address, error)
+	Create(ctx context.Context, userID string, param address.WriteParam) (address.Address, error)
+	Update(ctx context.Context, userID, id string, param address.WriteParam) (address.Address, error)
+	Delete(ctx context.Context, userID, id string) error
+}
+
+type Handler struct {
+	AuthMiddleware authMiddleware
+	AddressBook    addressBookUseCase
+}
+
+func (h Handler) Handle(server *http.ServeMux) {
+	server.Handle("GET /addresses", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleList)))
+	server.Handle("POST /addresses", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleCreate)))
+	server.Handle("GET /addresses/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDetail)))
+	server.Handle("PUT /addresses/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleUpdate)))
+	server.Handle("DELETE /addresses/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDelete)))
+}
+
+func userIDOf(r *http.Request) (string, error) {
+	userSession, _ := r.Context().Value(auth.CtxKeyUserSession).(*auth.UserSession)
+	if userSession == nil {
+		return "", auth.ErrUnauthenticated
+	}
+
+	return userSession.ID, nil
+}
+
+type WriteAddressRequest struct {
+	Label         string `json:"label" validate:"max=100"`
+	RecipientName string `json:"recipient_name" validate:"required,max=255"`
+	Phone         string `json:"phone" validate:"required,max=50"`
+	Line1         string `json:"line1" validate:"required,max=255"`
+	Line2         string `json:"line2" validate:"max=255"`
+	City          string `json:"city" validate:"required,max=100"`
+	Region        string `json:"region" validate:"required,max=100"`
+	PostalCode    string `json:"postal_code" validate:"required,max=20"`
+	Country       string `json:"country" validate:"required,len=2,alpha"`
+}
+
+func (request WriteAddressRequest) param() address.WriteParam {
+	return address.WriteParam{
+		Label:         request.Label,
+		RecipientName: request.RecipientName,
+		Phone:         request.Phone,
+		Line1:         request.Line1,
+		Line2:         request.Line2,
+		City:          request.City,
+		Region:        request.Region,
+		PostalCode:    request.PostalCode,
+		Country:       request.Country,
+	}
+}
+
+func decodeWriteAddressRequest(r *http.Request) (WriteAddressRequest, error) {
+	var request WriteAddressRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			return request, err
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		return request, err
+	}
+
+	return request, nil
+}
+
+func (h Handler) handleList(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userID, err := userIDOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.AddressBook.List(r.Context(), userID)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleDetail(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userID, err := userIDOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.AddressBook.Get(r.Context(), userID, r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleCreate(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userID, err := userIDOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	request, err := decodeWriteAddressRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.AddressBook.Create(r.Context(), userID, request.param())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleUpdate(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userID, err := userIDOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	request, err := decodeWriteAddressRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.AddressBook.Update(r.Context(), userID, r.PathValue("id"), request.param())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleDelete(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userID, err := userIDOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	if err = h.AddressBook.Delete(r.Context(), userID, r.PathValue("id")); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Write(rw, r, nil)
+}
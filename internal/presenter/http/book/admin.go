@@ -0,0 +1,239 @@
+package book
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type managementUseCase interface {
+	Create(ctx context.Context, param book.WriteParam) (book.Book, error)
+	Update(ctx context.Context, id string, param book.WriteParam) (book.Book, error)
+	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (book.Book, error)
+	AdjustStock(ctx context.Context, param book.StockAdjustParam) (book.StockAdjustment, error)
+	StockAdjustments(ctx context.Context, bookID string, param book.ListParam) (book.StockAdjustmentsResult, error)
+}
+
+// AdminHandler serves the catalog management endpoints for the staff.
+type AdminHandler struct {
+	AuthMiddleware authMiddleware
+	Management     managementUseCase
+}
+
+func (h AdminHandler) Handle(server *http.ServeMux) {
+	server.Handle("POST /admin/books", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleCreate)))
+	server.Handle("PUT /admin/books/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleUpdate)))
+	server.Handle("DELETE /admin/books/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDelete)))
+	server.Handle("POST /admin/books/{id}/restore", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleRestore)))
+	server.Handle("POST /admin/books/{id}/stock", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleAdjustStock)))
+	server.Handle("GET /admin/books/{id}/stock", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleStockAdjustments)))
+}
+
+type WriteBookRequest struct {
+	Title            string      `json:"title" validate:"required,max=255"`
+	Description      string      `json:"description" validate:"required"`
+	Price            money.Money `json:"price" validate:"gte=0"`
+	ISBN             string      `json:"isbn" validate:"required"`
+	Language         string      `json:"language" validate:"max=100"`
+	Edition          string      `json:"edition" validate:"max=255"`
+	Pages            int         `json:"pages" validate:"gte=0"`
+	PublishedAt      string      `json:"published_at" validate:"omitempty,datetime=2006-01-02"`
+	FirstPublishedAt string      `json:"first_published_at" validate:"omitempty,datetime=2006-01-02"`
+	CoverImg         string      `json:"cover_img" validate:"omitempty,url"`
+	Rating           float64     `json:"rating" validate:"gte=0,lte=5"`
+	Weight           int         `json:"weight" validate:"gte=0"`
+	PublisherID      string      `json:"publisher_id" validate:"required"`
+	AuthorIDs        []string    `json:"author_ids" validate:"gt=0,dive,required"`
+	GenreIDs         []string    `json:"genre_ids" validate:"dive,required"`
+}
+
+type AdjustStockRequest struct {
+	Quantity int    `json:"quantity" validate:"required"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+}
+
+func parseDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
+
+func writeParamFromRequest(r *http.Request) (book.WriteParam, error) {
+	var request WriteBookRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			return book.WriteParam{}, err
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		return book.WriteParam{}, err
+	}
+
+	return book.WriteParam{
+		Title:            request.Title,
+		Description:      request.Description,
+		Price:            request.Price,
+		ISBN:             request.ISBN,
+		Language:         request.Language,
+		Edition:          request.Edition,
+		Pages:            request.Pages,
+		PublishedAt:      parseDate(request.PublishedAt),
+		FirstPublishedAt: parseDate(request.FirstPublishedAt),
+		CoverImg:         request.CoverImg,
+		Rating:           request.Rating,
+		Weight:           request.Weight,
+		PublisherID:      request.PublisherID,
+		AuthorIDs:        request.AuthorIDs,
+		GenreIDs:         request.GenreIDs,
+	}, nil
+}
+
+func (h AdminHandler) handleCreate(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	param, err := writeParamFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Management.Create(r.Context(), param)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleUpdate(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	param, err := writeParamFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Management.Update(r.Context(), r.PathValue("id"), param)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleDelete(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	if err := h.Management.Delete(r.Context(), r.PathValue("id")); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleRestore(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	item, err := h.Management.Restore(r.Context(), r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleAdjustStock(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request AdjustStockRequest
+
+	userSession, ok := r.Context().Value(auth.CtxKeyUserSession).(*auth.UserSession)
+	if !ok || userSession == nil {
+		arw.Write(rw, r, auth.ErrUnauthenticated)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	adjustment, err := h.Management.AdjustStock(r.Context(), book.StockAdjustParam{
+		BookID:   r.PathValue("id"),
+		UserID:   userSession.ID,
+		Quantity: request.Quantity,
+		Reason:   request.Reason,
+	})
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = adjustment
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleStockAdjustments(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	param, err := listParamFromQuery(r.URL.Query())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Management.StockAdjustments(r.Context(), r.PathValue("id"), param)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result.Data
+	arw.Meta = result.PageInfo
+	arw.Write(rw, r, nil)
+}
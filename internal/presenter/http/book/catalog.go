@@ -0,0 +1,178 @@
+package book
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+)
+
+type catalogUseCase interface {
+	GetAuthors(ctx context.Context, param book.ListParam) (book.AuthorsResult, error)
+	AuthorDetailByID(ctx context.Context, id string) (book.Author, error)
+	BooksByAuthor(ctx context.Context, authorID string, param book.PaginationParam) (book.PaginationResult, error)
+	GetGenres(ctx context.Context, param book.ListParam) (book.GenresResult, error)
+	GenreDetailByID(ctx context.Context, id string) (book.Genre, error)
+	BooksByGenre(ctx context.Context, genreID string, param book.PaginationParam) (book.PaginationResult, error)
+	GetPublishers(ctx context.Context, param book.ListParam) (book.PublishersResult, error)
+	PublisherDetailByID(ctx context.Context, id string) (book.Publisher, error)
+	BooksByPublisher(ctx context.Context, publisherID string, param book.PaginationParam) (book.PaginationResult, error)
+}
+
+func (h Handler) handleCatalog(server *http.ServeMux) {
+	server.HandleFunc("GET /authors", h.handleAuthorIndex)
+	server.HandleFunc("GET /authors/{id}", h.handleAuthorDetail)
+	server.HandleFunc("GET /authors/{id}/books", h.handleAuthorBooks)
+	server.HandleFunc("GET /genres", h.handleGenreIndex)
+	server.HandleFunc("GET /genres/{id}", h.handleGenreDetail)
+	server.HandleFunc("GET /genres/{id}/books", h.handleGenreBooks)
+	server.HandleFunc("GET /publishers", h.handlePublisherIndex)
+	server.HandleFunc("GET /publishers/{id}", h.handlePublisherDetail)
+	server.HandleFunc("GET /publishers/{id}/books", h.handlePublisherBooks)
+}
+
+func listParamFromQuery(query url.Values) (book.ListParam, error) {
+	perPage, cursor, err := apphttp.PaginationFromQuery(query)
+	if err != nil {
+		return book.ListParam{}, err
+	}
+
+	return book.ListParam{
+		PerPage: perPage,
+		Cursor:  cursor,
+	}, nil
+}
+
+// booksOf writes the books listing scoped by the catalog item of the path id.
+func (h Handler) booksOf(rw http.ResponseWriter, r *http.Request, paginate func(ctx context.Context, id string, param book.PaginationParam) (book.PaginationResult, error)) {
+	arw := apphttp.AppResponseWriter{}
+
+	param, err := paginationParamFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	paginationResult, err := paginate(r.Context(), r.PathValue("id"), param)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = paginationResult.Data
+	arw.Meta = paginationResult.PageInfo
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleAuthorIndex(rw http.ResponseWriter, r *http.Request) {
+	arw := apphttp.AppResponseWriter{}
+
+	param, err := listParamFromQuery(r.URL.Query())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Catalog.GetAuthors(r.Context(), param)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result.Data
+	arw.Meta = result.PageInfo
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleAuthorDetail(rw http.ResponseWriter, r *http.Request) {
+	arw := apphttp.AppResponseWriter{}
+
+	item, err := h.Catalog.AuthorDetailByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleAuthorBooks(rw http.ResponseWriter, r *http.Request) {
+	h.booksOf(rw, r, h.Catalog.BooksByAuthor)
+}
+
+func (h Handler) handleGenreIndex(rw http.ResponseWriter, r *http.Request) {
+	arw := apphttp.AppResponseWriter{}
+
+	param, err := listParamFromQuery(r.URL.Query())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Catalog.GetGenres(r.Context(), param)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result.Data
+	arw.Meta = result.PageInfo
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleGenreDetail(rw http.ResponseWriter, r *http.Request) {
+	arw := apphttp.AppResponseWriter{}
+
+	item, err := h.Catalog.GenreDetailByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleGenreBooks(rw http.ResponseWriter, r *http.Request) {
+	h.booksOf(rw, r, h.Catalog.BooksByGenre)
+}
+
+func (h Handler) handlePublisherIndex(rw http.ResponseWriter, r *http.Request) {
+	arw := apphttp.AppResponseWriter{}
+
+	param, err := listParamFromQuery(r.URL.Query())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Catalog.GetPublishers(r.Context(), param)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result.Data
+	arw.Meta = result.PageInfo
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handlePublisherDetail(rw http.ResponseWriter, r *http.Request) {
+	arw := apphttp.AppResponseWriter{}
+
+	item, err := h.Catalog.PublisherDetailByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handlePublisherBooks(rw http.ResponseWriter, r *http.Request) {
+	h.booksOf(rw, r, h.Catalog.BooksByPublisher)
+}
@@ -0,0 +1,93 @@
+package book
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+const filterDateLayout = time.DateOnly
+
+type FilterRequest struct {
+	Genres         []string `json:"genre"`
+	Authors        []string `json:"author"`
+	Publishers     []string `json:"publisher"`
+	Language       string   `json:"language"`
+	MinPrice       string   `json:"min_price" validate:"omitempty,numeric"`
+	MaxPrice       string   `json:"max_price" validate:"omitempty,numeric"`
+	MinRating      string   `json:"min_rating" validate:"omitempty,numeric"`
+	PublishedFrom  string   `json:"published_from" validate:"omitempty,datetime=2006-01-02"`
+	PublishedUntil string   `json:"published_until" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// queryValues accept both of repeated and comma separated query values, e.g. genre=1&genre=2 or genre=1,2.
+func queryValues(query url.Values, key string) []string {
+	var values []string
+
+	for _, value := range query[key] {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+
+	return values
+}
+
+// filterFromQuery reads the filter from the query string, the price range is given in the currency,
+// or in the default currency when it is empty.
+func filterFromQuery(query url.Values, currency money.Currency) (book.Filter, error) {
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	request := FilterRequest{
+		Genres:         queryValues(query, "genre"),
+		Authors:        queryValues(query, "author"),
+		Publishers:     queryValues(query, "publisher"),
+		Language:       query.Get("language"),
+		MinPrice:       query.Get("min_price"),
+		MaxPrice:       query.Get("max_price"),
+		MinRating:      query.Get("min_rating"),
+		PublishedFrom:  query.Get("published_from"),
+		PublishedUntil: query.Get("published_until"),
+	}
+
+	if err := validator.Struct(request); err != nil {
+		return book.Filter{}, err
+	}
+
+	filter := book.Filter{
+		GenreIDs:     request.Genres,
+		AuthorIDs:    request.Authors,
+		PublisherIDs: request.Publishers,
+		Language:     request.Language,
+	}
+
+	// the values are already validated, thus parsing errors are not expected.
+	// the price range more precise than the minor unit is narrowed to the prices within the range.
+	filter.MinPrice, _ = money.ParseRounded(request.MinPrice, currency, money.RoundCeiling)
+	filter.MaxPrice, _ = money.ParseRounded(request.MaxPrice, currency, money.RoundFloor)
+	filter.MinRating, _ = strconv.ParseFloat(request.MinRating, 64)
+
+	if request.PublishedFrom != "" {
+		publishedFrom, _ := time.Parse(filterDateLayout, request.PublishedFrom)
+		filter.PublishedFrom = &publishedFrom
+	}
+
+	if request.PublishedUntil != "" {
+		// include the books published within the given day.
+		publishedUntil, _ := time.Parse(filterDateLayout, request.PublishedUntil)
+		publishedUntil = publishedUntil.Add(24*time.Hour - time.Second)
+		filter.PublishedUntil = &publishedUntil
+	}
+
+	return filter, nil
+}
@@ -5,32 +5,39 @@ import (
 	"net/http"
 
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
-	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
 	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 )
 
 type queriesUseCase interface {
 	GetAll(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error)
-	DetailByID(ctx context.Context, id string) (book.Book, error)
+	DetailByID(ctx context.Context, id string, currency money.Currency) (book.Book, error)
 	Search(ctx context.Context, searchQuery string, param book.PaginationParam) (book.PaginationResult, error)
 }
 
 type Handler struct {
 	Queries queriesUseCase
+	Catalog catalogUseCase
 }
 
 func (h Handler) Handle(server *http.ServeMux) {
 	server.HandleFunc("GET /books", h.handleIndex)
 	server.HandleFunc("GET /books/search", h.handleSearch)
 	server.HandleFunc("GET /books/{id}", h.handleDetail)
+
+	h.handleCatalog(server)
 }
 
 func (h Handler) handleIndex(rw http.ResponseWriter, r *http.Request) {
 	arw := apphttp.AppResponseWriter{}
 
-	paginationResult, err := h.Queries.GetAll(r.Context(), book.PaginationParam{
-		LastID: r.URL.Query().Get("last_id"),
-	})
+	param, err := paginationParamFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	paginationResult, err := h.Queries.GetAll(r.Context(), param)
 
 	if err != nil {
 		arw.Write(rw, r, err)
@@ -38,17 +45,20 @@ func (h Handler) handleIndex(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	arw.Data = paginationResult.Data
-	arw.Meta = pagination.PageInfo{
-		PerPage: paginationResult.PerPage,
-		LastID:  paginationResult.LastID,
-	}
+	arw.Meta = paginationResult.PageInfo
 	arw.Write(rw, r, nil)
 }
 
 func (h Handler) handleDetail(rw http.ResponseWriter, r *http.Request) {
 	var arw = &apphttp.AppResponseWriter{}
 
-	item, err := h.Queries.DetailByID(r.Context(), r.PathValue("id"))
+	currency, err := apphttp.CurrencyFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Queries.DetailByID(r.Context(), r.PathValue("id"), currency)
 
 	if err != nil {
 		arw.Write(rw, r, err)
@@ -68,9 +78,13 @@ func (h Handler) handleSearch(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	paginationResult, err := h.Queries.Search(r.Context(), query, book.PaginationParam{
-		LastID: r.URL.Query().Get("last_id"),
-	})
+	param, err := paginationParamFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	paginationResult, err := h.Queries.Search(r.Context(), query, param)
 
 	if err != nil {
 		arw.Write(rw, r, err)
@@ -78,9 +92,6 @@ func (h Handler) handleSearch(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	arw.Data = paginationResult.Data
-	arw.Meta = pagination.PageInfo{
-		PerPage: paginationResult.PerPage,
-		LastID:  paginationResult.LastID,
-	}
+	arw.Meta = paginationResult.PageInfo
 	arw.Write(rw, r, nil)
 }
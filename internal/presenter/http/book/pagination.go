@@ -0,0 +1,49 @@
+package book
+
+import (
+	"net/http"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type SortRequest struct {
+	Sort string `json:"sort" validate:"omitempty,oneof=price -price rating -rating published_at -published_at title -title"`
+}
+
+// paginationParamFromRequest reads the books pagination from the query string, the prices are listed
+// in the requested currency.
+func paginationParamFromRequest(r *http.Request) (book.PaginationParam, error) {
+	query := r.URL.Query()
+	perPage, cursor, err := apphttp.PaginationFromQuery(query)
+	if err != nil {
+		return book.PaginationParam{}, err
+	}
+
+	currency, err := apphttp.CurrencyFromRequest(r)
+	if err != nil {
+		return book.PaginationParam{}, err
+	}
+
+	request := SortRequest{
+		Sort: query.Get("sort"),
+	}
+
+	if err := validator.Struct(request); err != nil {
+		return book.PaginationParam{}, err
+	}
+
+	filter, err := filterFromQuery(query, currency)
+	if err != nil {
+		return book.PaginationParam{}, err
+	}
+
+	return book.PaginationParam{
+		PerPage:  perPage,
+		Cursor:   cursor,
+		Sort:     request.Sort,
+		Filter:   filter,
+		Currency: currency,
+	}, nil
+}
@@ -0,0 +1,253 @@
+package cart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/cart"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type cartUseCase interface {
+	CreateGuest(ctx context.Context) (cart.Cart, error)
+	Get(ctx context.Context, owner cart.Owner) (cart.Cart, error)
+	AddItem(ctx context.Context, owner cart.Owner, param cart.ItemParam) (cart.Cart, error)
+	UpdateItem(ctx context.Context, owner cart.Owner, param cart.ItemParam) (cart.Cart, error)
+	RemoveItem(ctx context.Context, owner cart.Owner, bookID string) (cart.Cart, error)
+	Checkout(ctx context.Context, userID string, param cart.CheckoutParam) (order.Main, error)
+}
+
+type Handler struct {
+	AuthMiddleware authMiddleware
+	Cart           cartUseCase
+}
+
+func (h Handler) Handle(server *http.ServeMux) {
+	server.Handle("GET /cart", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDetail)))
+	server.Handle("POST /cart/items", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleAddItem)))
+	server.Handle("PUT /cart/items/{book_id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleUpdateItem)))
+	server.Handle("DELETE /cart/items/{book_id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleRemoveItem)))
+	server.Handle("POST /cart/checkout", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleCheckout)))
+
+	// the guest cart is identified by its id, it is merged into the user cart by sending the cart_id at login.
+	server.HandleFunc("POST /guest-carts", h.handleCreateGuest)
+	server.HandleFunc("GET /guest-carts/{id}", h.handleDetail)
+	server.HandleFunc("POST /guest-carts/{id}/items", h.handleAddItem)
+	server.HandleFunc("PUT /guest-carts/{id}/items/{book_id}", h.handleUpdateItem)
+	server.HandleFunc("DELETE /guest-carts/{id}/items/{book_id}", h.handleRemoveItem)
+}
+
+// ownerOf refers to the guest cart of the path id, otherwise to the cart of the logged-in user.
+func ownerOf(r *http.Request) (cart.Owner, error) {
+	if cartID := r.PathValue("id"); cartID != "" {
+		return cart.Owner{CartID: cartID}, nil
+	}
+
+	userSession, _ := r.Context().Value(auth.CtxKeyUserSession).(*auth.UserSession)
+	if userSession == nil {
+		return cart.Owner{}, auth.ErrUnauthenticated
+	}
+
+	return cart.Owner{UserID: userSession.ID}, nil
+}
+
+func (h Handler) handleCreateGuest(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	result, err := h.Cart.CreateGuest(r.Context())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleDetail(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	owner, err := ownerOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Cart.Get(r.Context(), owner)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+type AddItemRequest struct {
+	BookID   string `json:"book_id" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+}
+
+func (h Handler) handleAddItem(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	owner, err := ownerOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	var request AddItemRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err = validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Cart.AddItem(r.Context(), owner, cart.ItemParam{
+		BookID:   request.BookID,
+		Quantity: request.Quantity,
+	})
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+type UpdateItemRequest struct {
+	Quantity int `json:"quantity" validate:"required,gt=0"`
+}
+
+func (h Handler) handleUpdateItem(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	owner, err := ownerOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	var request UpdateItemRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err = validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Cart.UpdateItem(r.Context(), owner, cart.ItemParam{
+		BookID:   r.PathValue("book_id"),
+		Quantity: request.Quantity,
+	})
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleRemoveItem(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	owner, err := ownerOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	result, err := h.Cart.RemoveItem(r.Context(), owner, r.PathValue("book_id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = result
+	arw.Write(rw, r, nil)
+}
+
+type CheckoutRequest struct {
+	ShippingAddressID string   `json:"shipping_address_id" validate:"required"`
+	VoucherCodes      []string `json:"voucher_codes" validate:"max=5,dive,required,max=100"`
+}
+
+func (h Handler) handleCheckout(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	owner, err := ownerOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	var request CheckoutRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err = validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	currency, err := apphttp.CurrencyFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	orderDetail, err := h.Cart.Checkout(r.Context(), owner.UserID, cart.CheckoutParam{
+		ShippingAddressID: request.ShippingAddressID,
+		VoucherCodes:      request.VoucherCodes,
+		Currency:          currency,
+	})
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = orderDetail
+	arw.Write(rw, r, nil)
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// HeaderCurrency requests the prices in the currency, the currency query string takes precedence over it.
+const HeaderCurrency = "X-Currency"
+
+// CurrencyFromRequest reads the requested currency from the currency query string or the X-Currency header,
+// empty currency keeps the prices in the currency of the books.
+func CurrencyFromRequest(r *http.Request) (money.Currency, error) {
+	value := r.URL.Query().Get("currency")
+	if value == "" {
+		value = r.Header.Get(HeaderCurrency)
+	}
+
+	if value == "" {
+		return "", nil
+	}
+
+	return money.ParseCurrency(value)
+}
@@ -0,0 +1,106 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type managementUseCase interface {
+	List(ctx context.Context) ([]exchangerate.ExchangeRate, error)
+	Set(ctx context.Context, currency money.Currency, rate money.Rate) (exchangerate.ExchangeRate, error)
+	Delete(ctx context.Context, currency money.Currency) error
+}
+
+// AdminHandler serves the exchange rate management endpoints for the staff.
+type AdminHandler struct {
+	AuthMiddleware authMiddleware
+	Management     managementUseCase
+}
+
+func (h AdminHandler) Handle(server *http.ServeMux) {
+	server.Handle("GET /admin/exchange-rates", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleIndex)))
+	server.Handle("PUT /admin/exchange-rates/{currency}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleSet)))
+	server.Handle("DELETE /admin/exchange-rates/{currency}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDelete)))
+}
+
+func (h AdminHandler) handleIndex(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	items, err := h.Management.List(r.Context())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = items
+	arw.Write(rw, r, nil)
+}
+
+// SetExchangeRateRequest is the amount of the currency for one unit of the base currency, e.g. "15750.5".
+type SetExchangeRateRequest struct {
+	Rate *money.Rate `json:"rate" validate:"required"`
+}
+
+func (h AdminHandler) handleSet(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request SetExchangeRateRequest
+
+	currency, err := money.ParseCurrency(r.PathValue("currency"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err = validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Management.Set(r.Context(), currency, *request.Rate)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleDelete(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	currency, err := money.ParseCurrency(r.PathValue("currency"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	if err = h.Management.Delete(r.Context(), currency); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Write(rw, r, nil)
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	httpen "github.com/rendyananta/example-online-book-store/internal/entity/http"
+)
+
+// NegotiateContentType picks the offered media type which is the most preferred by the Accept header,
+// the offers are listed in the server preference. The request without the Accept header gets the first offer.
+func NegotiateContentType(r *http.Request, offers ...string) (string, error) {
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" && len(offers) > 0 {
+		return offers[0], nil
+	}
+
+	best := ""
+	bestQuality := 0.0
+	bestSpecificity := -1
+
+	for _, offer := range offers {
+		quality, specificity := acceptQuality(accept, offer)
+		if quality > bestQuality || (quality == bestQuality && quality > 0 && specificity > bestSpecificity) {
+			best, bestQuality, bestSpecificity = offer, quality, specificity
+		}
+	}
+
+	if best == "" {
+		return "", httpen.ErrNotAcceptable
+	}
+
+	return best, nil
+}
+
+// acceptQuality finds the quality of the most specific media range of the Accept header matching the offer,
+// e.g. "application/pdf" is more specific than "application/*" which is more specific than "*/*".
+func acceptQuality(accept string, offer string) (float64, int) {
+	offerType, offerSubtype, _ := strings.Cut(offer, "/")
+
+	quality := 0.0
+	specificity := -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		rangeType, rangeSubtype, _ := strings.Cut(mediaType, "/")
+
+		var rangeSpecificity int
+		switch {
+		case rangeType == offerType && rangeSubtype == offerSubtype:
+			rangeSpecificity = 2
+		case rangeType == offerType && rangeSubtype == "*":
+			rangeSpecificity = 1
+		case rangeType == "*" && rangeSubtype == "*":
+			rangeSpecificity = 0
+		default:
+			continue
+		}
+
+		if rangeSpecificity <= specificity {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		quality, specificity = q, rangeSpecificity
+	}
+
+	return quality, specificity
+}
@@ -0,0 +1,70 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type statusUseCase interface {
+	Transition(ctx context.Context, param order.TransitionParam) (order.Main, error)
+}
+
+// AdminHandler serves the order administration endpoints for the staff, the invoices of every order are
+// served to the admin only.
+type AdminHandler struct {
+	AuthMiddleware  authMiddleware
+	AdminMiddleware authMiddleware
+	Status          statusUseCase
+	Queries         queriesUseCase
+	Invoices        invoiceUseCase
+}
+
+func (h AdminHandler) Handle(server *http.ServeMux) {
+	server.Handle("POST /admin/orders/{id}/status", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleTransition)))
+	server.Handle("GET /admin/orders/{id}/invoice", h.AdminMiddleware.Handle(http.HandlerFunc(h.handleInvoice)))
+}
+
+type TransitionRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending_payment paid processing shipped delivered cancelled refunded"`
+	Reason string `json:"reason" validate:"max=255"`
+}
+
+func (h AdminHandler) handleTransition(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request TransitionRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Status.Transition(r.Context(), order.TransitionParam{
+		OrderID: r.PathValue("id"),
+		Status:  request.Status,
+		Reason:  request.Reason,
+	})
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
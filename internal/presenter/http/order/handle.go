@@ -27,16 +27,25 @@ type queriesUseCase interface {
 	GetDetailByID(ctx context.Context, orderID string) (order.Main, error)
 }
 
+type cancellationUseCase interface {
+	Cancel(ctx context.Context, orderID string, reason string) (order.Main, error)
+}
+
 type Handler struct {
 	AuthMiddleware    authMiddleware
+	Idempotency       authMiddleware
 	PlaceOrderUseCase placeOrderUseCase
 	Queries           queriesUseCase
+	Cancellation      cancellationUseCase
+	Invoices          invoiceUseCase
 }
 
 func (h Handler) Handle(server *http.ServeMux) {
 	server.Handle("GET /orders", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleIndex)))
-	server.Handle("POST /orders/place", h.AuthMiddleware.Handle(http.HandlerFunc(h.handlePlaceOrder)))
+	server.Handle("POST /orders/place", h.AuthMiddleware.Handle(h.Idempotency.Handle(http.HandlerFunc(h.handlePlaceOrder))))
 	server.Handle("GET /orders/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDetail)))
+	server.Handle("POST /orders/{id}/cancel", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleCancel)))
+	server.Handle("GET /orders/{id}/invoice", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleInvoice)))
 }
 
 func (h Handler) handleIndex(rw http.ResponseWriter, r *http.Request) {
@@ -48,16 +57,24 @@ func (h Handler) handleIndex(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	perPage, cursor, err := apphttp.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
 	result, err := h.Queries.PaginateOrdersByUserID(ctx, userSession.ID, order.PaginationParam{
-		LastID: r.URL.Query().Get("last_id"),
+		PerPage: perPage,
+		Cursor:  cursor,
 	})
 
 	if err != nil {
-		arw.Write(rw, r, auth.ErrUnauthenticated)
+		arw.Write(rw, r, err)
 		return
 	}
 
-	arw.Data = result
+	arw.Data = result.Data
+	arw.Meta = result.PageInfo
 
 	arw.Write(rw, r, nil)
 }
@@ -75,6 +92,7 @@ func (h Handler) handleDetail(rw http.ResponseWriter, r *http.Request) {
 
 	if item.UserID != userSession.ID {
 		arw.Write(rw, r, httpen.ErrUnauthorized)
+		return
 	}
 
 	arw.Data = item
@@ -88,7 +106,9 @@ type LineItem struct {
 }
 
 type PlaceOrderRequest struct {
-	Lines []LineItem `json:"lines" validate:"gt=0,dive"`
+	Lines             []LineItem `json:"lines" validate:"gt=0,dive"`
+	ShippingAddressID string     `json:"shipping_address_id" validate:"required"`
+	VoucherCodes      []string   `json:"voucher_codes" validate:"max=5,dive,required,max=100"`
 }
 
 func (h Handler) handlePlaceOrder(rw http.ResponseWriter, r *http.Request) {
@@ -118,6 +138,12 @@ func (h Handler) handlePlaceOrder(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	currency, err := apphttp.CurrencyFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
 	orderLines := make([]order.Line, 0, len(request.Lines))
 	for _, line := range request.Lines {
 		orderLines = append(orderLines, order.Line{
@@ -128,8 +154,11 @@ func (h Handler) handlePlaceOrder(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	orderParam := order.Main{
-		UserID: userSession.ID,
-		Lines:  orderLines,
+		UserID:            userSession.ID,
+		Lines:             orderLines,
+		ShippingAddressID: request.ShippingAddressID,
+		VoucherCodes:      request.VoucherCodes,
+		Currency:          currency,
 	}
 
 	orderDetail, err := h.PlaceOrderUseCase.PlaceOrder(ctx, orderParam)
@@ -141,3 +170,50 @@ func (h Handler) handlePlaceOrder(rw http.ResponseWriter, r *http.Request) {
 	arw.Data = orderDetail
 	arw.Write(rw, r, nil)
 }
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+func (h Handler) handleCancel(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	ctx := r.Context()
+	userSession := ctx.Value(auth.CtxKeyUserSession).(*auth.UserSession)
+
+	var request CancelOrderRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Queries.GetDetailByID(ctx, r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	if item.UserID != userSession.ID {
+		arw.Write(rw, r, httpen.ErrUnauthorized)
+		return
+	}
+
+	item, err = h.Cancellation.Cancel(ctx, item.ID, request.Reason)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
@@ -0,0 +1,124 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	httpen "github.com/rendyananta/example-online-book-store/internal/entity/http"
+	"github.com/rendyananta/example-online-book-store/internal/entity/invoice"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+)
+
+const (
+	contentTypeHTML = "text/html"
+	contentTypePDF  = "application/pdf"
+
+	invoiceIssuer = "Online Book Store"
+)
+
+type invoiceUseCase interface {
+	Issue(ctx context.Context, detail order.Main) (invoice.Invoice, error)
+}
+
+//go:embed invoice.html
+var invoiceHTML string
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"date": formatDate,
+	"join": strings.Join,
+}).Parse(invoiceHTML))
+
+type invoiceView struct {
+	invoice.Invoice
+	Issuer string
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format("2 January 2006")
+}
+
+// handleInvoice renders the invoice of the customer's own order.
+func (h Handler) handleInvoice(rw http.ResponseWriter, r *http.Request) {
+	userSession := r.Context().Value(auth.CtxKeyUserSession).(*auth.UserSession)
+
+	renderInvoice(rw, r, h.Queries, h.Invoices, func(item order.Main) error {
+		if item.UserID != userSession.ID {
+			return httpen.ErrUnauthorized
+		}
+
+		return nil
+	})
+}
+
+// handleInvoice renders the invoice of any order for the admin.
+func (h AdminHandler) handleInvoice(rw http.ResponseWriter, r *http.Request) {
+	renderInvoice(rw, r, h.Queries, h.Invoices, func(order.Main) error {
+		return nil
+	})
+}
+
+// renderInvoice renders the invoice of the order as HTML or PDF, picked by the Accept header. The order which
+// the user is not allowed to see is rejected by the authorize.
+func renderInvoice(rw http.ResponseWriter, r *http.Request, queries queriesUseCase, invoices invoiceUseCase, authorize func(item order.Main) error) {
+	var arw = &apphttp.AppResponseWriter{}
+	ctx := r.Context()
+
+	contentType, err := apphttp.NegotiateContentType(r, contentTypeHTML, contentTypePDF)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := queries.GetDetailByID(ctx, r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	if err = authorize(item); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	issued, err := invoices.Issue(ctx, item)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	view := invoiceView{Invoice: issued, Issuer: invoiceIssuer}
+
+	var body []byte
+	switch contentType {
+	case contentTypePDF:
+		body, err = renderInvoicePDF(view)
+		rw.Header().Set("Content-Disposition", `inline; filename="`+issued.Code()+`.pdf"`)
+	default:
+		var buf bytes.Buffer
+		err = invoiceTemplate.Execute(&buf, view)
+		body = buf.Bytes()
+		contentType += "; charset=utf-8"
+	}
+
+	if err != nil {
+		rw.Header().Del("Content-Disposition")
+		arw.Write(rw, r, err)
+		return
+	}
+
+	rw.Header().Set("Content-Type", contentType)
+	rw.Header().Add("Vary", "Accept")
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(body)
+}
@@ -0,0 +1,159 @@
+package order
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rendyananta/example-online-book-store/pkg/pdf"
+)
+
+// the layout of the invoice page in points.
+const (
+	pdfMarginLeft   = 50
+	pdfMarginRight  = pdf.A4Width - 50
+	pdfMarginTop    = 60
+	pdfMarginBottom = pdf.A4Height - 70
+
+	pdfColQuantity  = 370
+	pdfColUnitPrice = 460
+	pdfItemWidth    = 270
+
+	pdfFontSize      = 10
+	pdfSmallFontSize = 8
+	pdfLineHeight    = 14
+)
+
+// invoicePDF lays out the invoice from the top of the page, the items table continues on the next page
+// when the page is full.
+type invoicePDF struct {
+	doc *pdf.Document
+	y   float64
+}
+
+func renderInvoicePDF(view invoiceView) ([]byte, error) {
+	p := &invoicePDF{doc: pdf.New()}
+	p.doc.SetTitle("Invoice " + view.Code())
+	p.doc.AddPage()
+	p.y = pdfMarginTop
+
+	p.header(view)
+	p.billTo(view)
+	p.itemsHeader()
+
+	for _, item := range view.Items {
+		title := pdf.Wrap(item.Title, pdf.FontHelvetica, pdfFontSize, pdfItemWidth)
+
+		var details []string
+		if len(item.Authors) > 0 {
+			details = append(details, pdf.Wrap(strings.Join(item.Authors, ", "), pdf.FontHelvetica, pdfSmallFontSize, pdfItemWidth)...)
+		}
+
+		if item.ISBN != "" {
+			details = append(details, "ISBN "+item.ISBN)
+		}
+
+		p.ensureSpace(float64(len(title))*pdfLineHeight + float64(len(details))*(pdfLineHeight-2))
+
+		p.doc.TextRight(pdfColQuantity, p.y, pdf.FontHelvetica, pdfFontSize, strconv.Itoa(item.Quantity))
+		p.doc.TextRight(pdfColUnitPrice, p.y, pdf.FontHelvetica, pdfFontSize, item.UnitPrice.String())
+		p.doc.TextRight(pdfMarginRight, p.y, pdf.FontHelvetica, pdfFontSize, item.Amount.String())
+
+		for _, line := range title {
+			p.doc.Text(pdfMarginLeft, p.y, pdf.FontHelvetica, pdfFontSize, line)
+			p.y += pdfLineHeight
+		}
+
+		for _, line := range details {
+			p.doc.Text(pdfMarginLeft, p.y-2, pdf.FontHelvetica, pdfSmallFontSize, line)
+			p.y += pdfLineHeight - 2
+		}
+
+		p.doc.Line(pdfMarginLeft, p.y-8, pdfMarginRight, p.y-8, 0.25)
+		p.y += 4
+	}
+
+	p.ensureSpace(float64(len(view.Adjustments)+3) * pdfLineHeight)
+	p.total("Subtotal", view.Subtotal.String(), pdf.FontHelvetica)
+	for _, adjustment := range view.Adjustments {
+		p.total(adjustment.Label, adjustment.Amount.String(), pdf.FontHelvetica)
+	}
+
+	p.doc.Line(pdfColQuantity, p.y-8, pdfMarginRight, p.y-8, 1)
+	p.y += 4
+	p.total("Total", view.GrandTotal.String(), pdf.FontHelveticaBold)
+
+	if view.BaseCurrency != view.Currency {
+		p.y += pdfLineHeight
+		p.doc.Text(pdfMarginLeft, p.y, pdf.FontHelvetica, pdfSmallFontSize, "Converted from "+string(view.BaseCurrency)+
+			" at 1 "+string(view.BaseCurrency)+" = "+view.ExchangeRate.String()+" "+string(view.Currency)+".")
+	}
+
+	return p.doc.Bytes()
+}
+
+func (p *invoicePDF) header(view invoiceView) {
+	p.doc.Text(pdfMarginLeft, p.y+10, pdf.FontHelveticaBold, 22, "Invoice")
+	p.doc.Text(pdfMarginLeft, p.y+28, pdf.FontHelvetica, pdfFontSize, view.Issuer)
+
+	p.doc.TextRight(pdfMarginRight, p.y, pdf.FontHelveticaBold, 12, view.Code())
+	p.doc.TextRight(pdfMarginRight, p.y+16, pdf.FontHelvetica, pdfFontSize, "Issued "+formatDate(view.IssuedAt))
+	p.doc.TextRight(pdfMarginRight, p.y+30, pdf.FontHelvetica, pdfFontSize, "Order "+view.OrderID)
+	p.doc.TextRight(pdfMarginRight, p.y+44, pdf.FontHelvetica, pdfFontSize, "Ordered "+formatDate(view.OrderedAt))
+	p.doc.TextRight(pdfMarginRight, p.y+58, pdf.FontHelvetica, pdfFontSize, "Status "+view.OrderStatus)
+	p.y += 90
+}
+
+func (p *invoicePDF) billTo(view invoiceView) {
+	if view.BillTo == nil {
+		return
+	}
+
+	address := view.BillTo
+	lines := []string{
+		address.RecipientName,
+		address.Line1,
+		address.Line2,
+		address.City + ", " + address.Region + " " + address.PostalCode,
+		address.Country,
+		address.Phone,
+	}
+
+	p.doc.Text(pdfMarginLeft, p.y, pdf.FontHelveticaBold, pdfFontSize, "Bill to")
+	p.y += pdfLineHeight
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		p.doc.Text(pdfMarginLeft, p.y, pdf.FontHelvetica, pdfFontSize, line)
+		p.y += pdfLineHeight
+	}
+
+	p.y += pdfLineHeight
+}
+
+func (p *invoicePDF) itemsHeader() {
+	p.doc.Text(pdfMarginLeft, p.y, pdf.FontHelveticaBold, pdfFontSize, "Item")
+	p.doc.TextRight(pdfColQuantity, p.y, pdf.FontHelveticaBold, pdfFontSize, "Quantity")
+	p.doc.TextRight(pdfColUnitPrice, p.y, pdf.FontHelveticaBold, pdfFontSize, "Unit price")
+	p.doc.TextRight(pdfMarginRight, p.y, pdf.FontHelveticaBold, pdfFontSize, "Amount")
+	p.doc.Line(pdfMarginLeft, p.y+6, pdfMarginRight, p.y+6, 1)
+	p.y += pdfLineHeight + 8
+}
+
+func (p *invoicePDF) total(label string, amount string, font pdf.Font) {
+	p.doc.TextRight(pdfColUnitPrice, p.y, font, pdfFontSize, label)
+	p.doc.TextRight(pdfMarginRight, p.y, font, pdfFontSize, amount)
+	p.y += pdfLineHeight
+}
+
+// ensureSpace moves to the next page when the height does not fit the current page.
+func (p *invoicePDF) ensureSpace(height float64) {
+	if p.y+height <= pdfMarginBottom {
+		return
+	}
+
+	p.doc.AddPage()
+	p.y = pdfMarginTop
+	p.itemsHeader()
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type PaginationRequest struct {
+	PerPage string `json:"per_page" validate:"omitempty,number"`
+	Cursor  string `json:"cursor"`
+}
+
+// PaginationFromQuery reads the requested page size and cursor from the query string. The page size
+// is capped to the server side maximum by the pagination itself, zero page size means the default one.
+func PaginationFromQuery(query url.Values) (int, string, error) {
+	request := PaginationRequest{
+		PerPage: query.Get("per_page"),
+		Cursor:  query.Get("cursor"),
+	}
+
+	if err := validator.Struct(request); err != nil {
+		return 0, "", err
+	}
+
+	var perPage int
+	if request.PerPage != "" {
+		var err error
+		if perPage, err = strconv.Atoi(request.PerPage); err != nil {
+			return 0, "", err
+		}
+	}
+
+	return perPage, request.Cursor, nil
+}
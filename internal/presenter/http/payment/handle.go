@@ -0,0 +1,103 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/payment"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+// maxNotificationSize limits the notification payload read from the gateway.
+const maxNotificationSize = 64 << 10
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type paymentUseCase interface {
+	CreateIntent(ctx context.Context, param payment.CreateIntentParam) (payment.Transaction, error)
+	HandleNotification(ctx context.Context, gateway string, header http.Header, payload []byte) (payment.Transaction, error)
+}
+
+type Handler struct {
+	AuthMiddleware authMiddleware
+	Payment        paymentUseCase
+}
+
+func (h Handler) Handle(server *http.ServeMux) {
+	server.Handle("POST /orders/{id}/payments", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleCreateIntent)))
+
+	// the notification is sent by the gateway, it is verified by the gateway signature instead of the user session.
+	server.HandleFunc("POST /payments/{gateway}/notifications", h.handleNotification)
+}
+
+type CreateIntentRequest struct {
+	Gateway string `json:"gateway" validate:"max=100"`
+}
+
+func (h Handler) handleCreateIntent(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	ctx := r.Context()
+	userSession := ctx.Value(auth.CtxKeyUserSession).(*auth.UserSession)
+	if userSession == nil {
+		arw.Write(rw, r, auth.ErrUnauthenticated)
+		return
+	}
+
+	var request CreateIntentRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	transaction, err := h.Payment.CreateIntent(ctx, payment.CreateIntentParam{
+		UserID:  userSession.ID,
+		OrderID: r.PathValue("id"),
+		Gateway: request.Gateway,
+	})
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = transaction
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleNotification(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxNotificationSize))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	transaction, err := h.Payment.HandleNotification(r.Context(), r.PathValue("gateway"), r.Header, payload)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = transaction
+	arw.Write(rw, r, nil)
+}
@@ -0,0 +1,65 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type roleAssignmentUseCase interface {
+	AssignRole(ctx context.Context, id string, role user.Role) (user.User, error)
+}
+
+// AdminHandler serves the user administration endpoints for the admin.
+type AdminHandler struct {
+	AuthMiddleware authMiddleware
+	RoleAssignment roleAssignmentUseCase
+}
+
+func (h AdminHandler) Handle(server *http.ServeMux) {
+	server.Handle("PUT /admin/users/{id}/role", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleAssignRole)))
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=customer staff admin"`
+}
+
+func (h AdminHandler) handleAssignRole(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request AssignRoleRequest
+	var err error
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err = validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	u, err := h.RoleAssignment.AssignRole(r.Context(), r.PathValue("id"), request.Role)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = u
+	arw.Write(rw, r, nil)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/rendyananta/example-online-book-store/internal/entity/user"
 	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
 	"github.com/rendyananta/example-online-book-store/pkg/validator"
+	"log/slog"
 	"net/http"
 )
 
@@ -18,16 +19,29 @@ type registerUseCase interface {
 
 type authenticatorUseCase interface {
 	Authenticate(ctx context.Context, param user.AuthenticateParam) (user.AuthenticateResult, error)
+	Refresh(ctx context.Context, refreshToken string) (user.AuthenticateResult, error)
+}
+
+type cartMerger interface {
+	Merge(ctx context.Context, guestCartID string, userID string) error
 }
 
 type Handler struct {
-	Register      registerUseCase
-	Authenticator authenticatorUseCase
+	AuthMiddleware authMiddleware
+	Register       registerUseCase
+	Authenticator  authenticatorUseCase
+	CartMerger     cartMerger
+	Sessions       sessionUseCase
 }
 
 func (h Handler) Handle(server *http.ServeMux) {
 	server.HandleFunc("POST /auth/register", h.handleRegister)
 	server.HandleFunc("POST /auth/token", h.handleToken)
+	server.HandleFunc("POST /auth/refresh", h.handleRefresh)
+	server.Handle("POST /auth/logout", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleLogout)))
+	server.Handle("GET /auth/sessions", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleSessions)))
+	server.Handle("DELETE /auth/sessions", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleRevokeAllSessions)))
+	server.Handle("DELETE /auth/sessions/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleRevokeSession)))
 }
 
 type RegisterRequest struct {
@@ -40,6 +54,11 @@ type RegisterRequest struct {
 type AuthenticateRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	CartID   string `json:"cart_id"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 func (h Handler) handleRegister(rw http.ResponseWriter, r *http.Request) {
@@ -107,8 +126,9 @@ func (h Handler) handleToken(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	authenticateResult, err := h.Authenticator.Authenticate(ctx, user.AuthenticateParam{
-		Email:    request.Email,
-		Password: request.Password,
+		Email:     request.Email,
+		Password:  request.Password,
+		UserAgent: r.UserAgent(),
 	})
 
 	if err != nil {
@@ -116,7 +136,44 @@ func (h Handler) handleToken(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the guest cart is merged on the best effort, failing to merge should not prevent the user from logging in.
+	if request.CartID != "" && h.CartMerger != nil {
+		if err = h.CartMerger.Merge(ctx, request.CartID, authenticateResult.User.ID); err != nil {
+			slog.Warn("cannot merge the guest cart", slog.String("err", err.Error()), slog.String("cart_id", request.CartID))
+		}
+	}
+
 	arw.Data = authenticateResult
 	arw.Write(rw, r, nil)
 	return
 }
+
+func (h Handler) handleRefresh(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request RefreshRequest
+	var err error
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err = validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	refreshResult, err := h.Authenticator.Refresh(r.Context(), request.RefreshToken)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = refreshResult
+	arw.Write(rw, r, nil)
+}
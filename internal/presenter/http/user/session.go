@@ -0,0 +1,97 @@
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+)
+
+type sessionUseCase interface {
+	Logout(ctx context.Context, token string) error
+	Sessions(ctx context.Context, userID string, currentSessionID string) ([]user.Session, error)
+	Revoke(ctx context.Context, userID string, sessionID string) error
+	RevokeAll(ctx context.Context, userID string) error
+}
+
+func userSessionOf(r *http.Request) (*auth.UserSession, error) {
+	userSession, _ := r.Context().Value(auth.CtxKeyUserSession).(*auth.UserSession)
+	if userSession == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	return userSession, nil
+}
+
+func (h Handler) handleLogout(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	token, err := auth.TokenFromRequest(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	if err = h.Sessions.Logout(r.Context(), token); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleSessions(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userSession, err := userSessionOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	items, err := h.Sessions.Sessions(r.Context(), userSession.ID, userSession.SessionID)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = items
+	arw.Write(rw, r, nil)
+}
+
+func (h Handler) handleRevokeSession(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userSession, err := userSessionOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	if err = h.Sessions.Revoke(r.Context(), userSession.ID, r.PathValue("id")); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Write(rw, r, nil)
+}
+
+// handleRevokeAllSessions logs the user out everywhere, including the session of the request.
+func (h Handler) handleRevokeAllSessions(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	userSession, err := userSessionOf(r)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	if err = h.Sessions.RevokeAll(r.Context(), userSession.ID); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Write(rw, r, nil)
+}
@@ -0,0 +1,119 @@
+package voucher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type managementUseCase interface {
+	Create(ctx context.Context, param voucher.Voucher) (voucher.Voucher, error)
+	GetByCode(ctx context.Context, code string) (voucher.Voucher, error)
+}
+
+// AdminHandler serves the voucher management endpoints for the staff.
+type AdminHandler struct {
+	AuthMiddleware authMiddleware
+	Management     managementUseCase
+}
+
+func (h AdminHandler) Handle(server *http.ServeMux) {
+	server.Handle("POST /admin/vouchers", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleCreate)))
+	server.Handle("GET /admin/vouchers/{code}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDetail)))
+}
+
+type CreateVoucherRequest struct {
+	Code         string      `json:"code" validate:"required,max=100,alphanum"`
+	Description  string      `json:"description"`
+	DiscountType string      `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	Percentage   float64     `json:"percentage" validate:"required_if=DiscountType percentage,gte=0,lte=100"`
+	Amount       money.Money `json:"amount" validate:"required_if=DiscountType fixed,gte=0"`
+	MaxDiscount  money.Money `json:"max_discount" validate:"gte=0"`
+	MinSpend     money.Money `json:"min_spend" validate:"gte=0"`
+	ScopeType    string      `json:"scope_type" validate:"omitempty,oneof=genre author publisher"`
+	ScopeID      string      `json:"scope_id" validate:"required_with=ScopeType"`
+	UsageLimit   int         `json:"usage_limit" validate:"gte=0"`
+	StartsAt     string      `json:"starts_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	EndsAt       string      `json:"ends_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+func parseDateTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	dateTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return &dateTime
+}
+
+func (h AdminHandler) handleCreate(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request CreateVoucherRequest
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			arw.Write(rw, r, err)
+			return
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Management.Create(r.Context(), voucher.Voucher{
+		Code:         request.Code,
+		Description:  request.Description,
+		DiscountType: request.DiscountType,
+		Percentage:   request.Percentage,
+		Amount:       request.Amount,
+		MaxDiscount:  request.MaxDiscount,
+		MinSpend:     request.MinSpend,
+		ScopeType:    request.ScopeType,
+		ScopeID:      request.ScopeID,
+		UsageLimit:   request.UsageLimit,
+		StartsAt:     parseDateTime(request.StartsAt),
+		EndsAt:       parseDateTime(request.EndsAt),
+	})
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleDetail(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	item, err := h.Management.GetByCode(r.Context(), r.PathValue("code"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
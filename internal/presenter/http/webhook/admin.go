@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	validatorpkg "github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+	apphttp "github.com/rendyananta/example-online-book-store/internal/presenter/http"
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type authMiddleware interface {
+	Handle(next http.Handler) http.Handler
+}
+
+type managementUseCase interface {
+	List(ctx context.Context) ([]webhook.Subscription, error)
+	Get(ctx context.Context, id string) (webhook.Subscription, error)
+	Create(ctx context.Context, param webhook.WriteParam) (webhook.Subscription, error)
+	Update(ctx context.Context, id string, param webhook.WriteParam) (webhook.Subscription, error)
+	Delete(ctx context.Context, id string) error
+	Deliveries(ctx context.Context, subscriptionID string, status webhook.DeliveryStatus, limit int) ([]webhook.Delivery, error)
+	Replay(ctx context.Context, subscriptionID, deliveryID string) (webhook.Delivery, error)
+}
+
+// AdminHandler serves the webhook subscription management endpoints for the admin.
+type AdminHandler struct {
+	AuthMiddleware authMiddleware
+	Management     managementUseCase
+}
+
+func (h AdminHandler) Handle(server *http.ServeMux) {
+	server.Handle("GET /admin/webhooks", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleIndex)))
+	server.Handle("POST /admin/webhooks", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleCreate)))
+	server.Handle("GET /admin/webhooks/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDetail)))
+	server.Handle("PUT /admin/webhooks/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleUpdate)))
+	server.Handle("DELETE /admin/webhooks/{id}", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDelete)))
+	server.Handle("GET /admin/webhooks/{id}/deliveries", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleDeliveries)))
+	server.Handle("POST /admin/webhooks/{id}/deliveries/{delivery_id}/replay", h.AuthMiddleware.Handle(http.HandlerFunc(h.handleReplay)))
+}
+
+func (h AdminHandler) handleIndex(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	items, err := h.Management.List(r.Context())
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = items
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleDetail(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	item, err := h.Management.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+// CreateSubscriptionRequest is the receiver of the events, the payloads are signed with the secret which is
+// never returned. The subscription is active when omitted.
+type CreateSubscriptionRequest struct {
+	URL        string       `json:"url" validate:"required,http_url,max=2048"`
+	Secret     string       `json:"secret" validate:"required,min=16,max=255"`
+	EventTypes []event.Type `json:"event_types" validate:"required,min=1,dive,required"`
+	Active     *bool        `json:"active"`
+}
+
+// UpdateSubscriptionRequest replaces the subscription, the secret is kept unchanged when omitted and
+// the subscription is active when omitted.
+type UpdateSubscriptionRequest struct {
+	URL        string       `json:"url" validate:"required,http_url,max=2048"`
+	Secret     string       `json:"secret" validate:"omitempty,min=16,max=255"`
+	EventTypes []event.Type `json:"event_types" validate:"required,min=1,dive,required"`
+	Active     *bool        `json:"active"`
+}
+
+func writeParam(url, secret string, eventTypes []event.Type, active *bool) webhook.WriteParam {
+	param := webhook.WriteParam{
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+
+	if active != nil {
+		param.Active = *active
+	}
+
+	return param
+}
+
+func decodeRequest(r *http.Request, request any) error {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			return err
+		}
+	}
+
+	err := validator.Struct(request)
+	var validationErrors validatorpkg.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		return err
+	}
+
+	return nil
+}
+
+func (h AdminHandler) handleCreate(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request CreateSubscriptionRequest
+
+	if err := decodeRequest(r, &request); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Management.Create(r.Context(), writeParam(request.URL, request.Secret, request.EventTypes, request.Active))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusCreated
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleUpdate(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+	var request UpdateSubscriptionRequest
+
+	if err := decodeRequest(r, &request); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	item, err := h.Management.Update(r.Context(), r.PathValue("id"), writeParam(request.URL, request.Secret, request.EventTypes, request.Active))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleDelete(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	if err := h.Management.Delete(r.Context(), r.PathValue("id")); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Write(rw, r, nil)
+}
+
+// DeliveriesRequest filters the delivery log, the limit is capped to 50 latest deliveries.
+type DeliveriesRequest struct {
+	Status string `json:"status" validate:"omitempty,oneof=pending delivered failed"`
+	Limit  string `json:"limit" validate:"omitempty,number"`
+}
+
+func (h AdminHandler) handleDeliveries(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	request := DeliveriesRequest{
+		Status: r.URL.Query().Get("status"),
+		Limit:  r.URL.Query().Get("limit"),
+	}
+
+	if err := validator.Struct(request); err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(request.Limit)
+
+	items, err := h.Management.Deliveries(r.Context(), r.PathValue("id"), request.Status, limit)
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.Data = items
+	arw.Write(rw, r, nil)
+}
+
+func (h AdminHandler) handleReplay(rw http.ResponseWriter, r *http.Request) {
+	var arw = &apphttp.AppResponseWriter{}
+
+	item, err := h.Management.Replay(r.Context(), r.PathValue("id"), r.PathValue("delivery_id"))
+	if err != nil {
+		arw.Write(rw, r, err)
+		return
+	}
+
+	arw.StatusCode = http.StatusAccepted
+	arw.Data = item
+	arw.Write(rw, r, nil)
+}
@@ -7,8 +7,23 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/cart"
+	"github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+	httpen "github.com/rendyananta/example-online-book-store/internal/entity/http"
+	"github.com/rendyananta/example-online-book-store/internal/entity/invoice"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/internal/entity/payment"
 	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
 	"github.com/rendyananta/example-online-book-store/pkg/auth"
+	"github.com/rendyananta/example-online-book-store/pkg/idempotency"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	paymentpkg "github.com/rendyananta/example-online-book-store/pkg/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
 )
 
 type Response struct {
@@ -32,10 +47,237 @@ var errWithResponse = map[error]Response{
 		Message:        "invalid credentials",
 		HTTPStatusCode: http.StatusUnauthorized,
 	},
+	user.ErrNotFound: {
+		Message:        "not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	user.ErrSessionNotFound: {
+		Message:        "session not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	user.ErrInvalidRefreshToken: {
+		Message:        "invalid refresh token",
+		HTTPStatusCode: http.StatusUnauthorized,
+	},
+	user.ErrRefreshTokenReused: {
+		Message:        "refresh token is already used, the session is revoked",
+		HTTPStatusCode: http.StatusUnauthorized,
+	},
+	pagination.ErrInvalidCursor: {
+		Message:        "invalid cursor",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	book.ErrNotFound: {
+		Message:        "not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	book.ErrInvalidReference: {
+		Message:        "unknown publisher, author or genre",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	book.ErrInvalidSort: {
+		Message:        "invalid sort",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	order.ErrOrderLineInvalid: {
+		Message:        "order line invalid",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	order.ErrNotFound: {
+		Message:        "not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	order.ErrInvalidStatusTransition: {
+		Message:        "invalid order status transition",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	order.ErrNotCancellable: {
+		Message:        "order can no longer be cancelled",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	cart.ErrNotFound: {
+		Message:        "not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	cart.ErrEmpty: {
+		Message:        "cart is empty",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	cart.ErrInvalidItem: {
+		Message:        "unknown book",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	voucher.ErrNotFound: {
+		Message:        "voucher not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	voucher.ErrCodeAlreadyExists: {
+		Message:        "voucher code already exists",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	voucher.ErrInvalidRule: {
+		Message:        "invalid voucher rule",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	voucher.ErrNotActive: {
+		Message:        "voucher is not active",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	voucher.ErrUsageLimitReached: {
+		Message:        "voucher usage limit reached",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	voucher.ErrMinimumSpendNotMet: {
+		Message:        "minimum spend of the voucher is not met",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	voucher.ErrNotEligible: {
+		Message:        "voucher is not applicable to the ordered books",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	address.ErrNotFound: {
+		Message:        "address not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	address.ErrLimitReached: {
+		Message:        "address book is full",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	order.ErrShippingAddressRequired: {
+		Message:        "shipping address is required",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	order.ErrCurrencyMismatch: {
+		Message:        "the order items are priced in different currencies",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	money.ErrInvalidAmount: {
+		Message:        "invalid amount",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	money.ErrInvalidCurrency: {
+		Message:        "invalid currency",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	money.ErrInvalidRate: {
+		Message:        "invalid exchange rate",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	exchangerate.ErrNotFound: {
+		Message:        "exchange rate not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	exchangerate.ErrUnsupportedCurrency: {
+		Message:        "currency is not supported",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	exchangerate.ErrBaseCurrency: {
+		Message:        "the base currency cannot have an exchange rate",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	invoice.ErrNotFound: {
+		Message:        "invoice not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	invoice.ErrNotInvoiceable: {
+		Message:        "cancelled order cannot be invoiced",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	webhook.ErrNotFound: {
+		Message:        "webhook subscription not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	webhook.ErrDeliveryNotFound: {
+		Message:        "webhook delivery not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	webhook.ErrUnsupportedEventType: {
+		Message:        "event type is not supported by the webhooks",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	shipping.ErrEmptyParcel: {
+		Message:        "nothing to ship",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	payment.ErrNotFound: {
+		Message:        "not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	payment.ErrOrderNotPayable: {
+		Message:        "order is not pending the payment",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	payment.ErrAmountMismatch: {
+		Message:        "paid amount does not match the order",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	payment.ErrNotOrderOwner: {
+		Message:        "order belongs to another user",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	paymentpkg.ErrGatewayUnregistered: {
+		Message:        "unknown payment gateway",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	paymentpkg.ErrInvalidSignature: {
+		Message:        "invalid signature",
+		HTTPStatusCode: http.StatusUnauthorized,
+	},
+	paymentpkg.ErrInvalidNotification: {
+		Message:        "invalid notification",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	auth.ErrUnauthenticated: {
 		Message:        "unauthenticated",
 		HTTPStatusCode: http.StatusUnauthorized,
 	},
+	auth.ErrForbidden: {
+		Message:        "forbidden",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	auth.ErrSessionsNotTracked: {
+		Message:        "sessions are not tracked by the signed tokens",
+		HTTPStatusCode: http.StatusNotImplemented,
+	},
+	auth.ErrRevocationDisabled: {
+		Message:        "revoking the signed tokens requires the denylist",
+		HTTPStatusCode: http.StatusNotImplemented,
+	},
+	idempotency.ErrInvalidKey: {
+		Message:        "invalid idempotency key",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	idempotency.ErrKeyReused: {
+		Message:        "idempotency key is already used by another request",
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+	},
+	idempotency.ErrRequestInProgress: {
+		Message:        "request with the same idempotency key is in progress",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	httpen.ErrUnauthorized: {
+		Message:        "unauthorized",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	httpen.ErrNotAcceptable: {
+		Message:        "not acceptable",
+		HTTPStatusCode: http.StatusNotAcceptable,
+	},
+}
+
+// responseOf finds the response of the error, the wrapped errors are matched using errors.Is.
+func responseOf(err error) (Response, bool) {
+	if resp, ok := errWithResponse[err]; ok {
+		return resp, true
+	}
+
+	for knownErr, resp := range errWithResponse {
+		if errors.Is(err, knownErr) {
+			return resp, true
+		}
+	}
+
+	return Response{}, false
 }
 
 type AppResponseWriter struct {
@@ -96,12 +338,28 @@ func (d AppResponseWriter) renderError(w http.ResponseWriter, r *http.Request, e
 		}
 
 		if bytesBuff, err := json.Marshal(resp); err == nil {
+			w.WriteHeader(resp.HTTPStatusCode)
 			w.Write(bytesBuff)
 			return
 		}
 	}
 
-	if resp, ok := errWithResponse[err]; ok {
+	var stockErr *book.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		resp := Response{
+			HTTPStatusCode: http.StatusConflict,
+			Message:        "insufficient stock",
+			Errors:         stockErr,
+		}
+
+		if bytesBuff, err := json.Marshal(resp); err == nil {
+			w.WriteHeader(resp.HTTPStatusCode)
+			w.Write(bytesBuff)
+			return
+		}
+	}
+
+	if resp, ok := responseOf(err); ok {
 		w.WriteHeader(resp.HTTPStatusCode)
 
 		if bytesBuff, err := json.Marshal(resp); err == nil {
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/pkg/validator"
+)
+
+type testValidationRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestAppResponseWriter_Write(t *testing.T) {
+	validator.SetUp()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantErrors bool
+	}{
+		{
+			name:       "can respond ok",
+			err:        nil,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "can respond the validation error as unprocessable entity",
+			err:        validator.Struct(testValidationRequest{}),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: true,
+		},
+		{
+			name:       "can respond the unknown error as internal server error",
+			err:        errors.New("unknown"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			AppResponseWriter{}.Write(rec, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Write() status = %v, want %v", rec.Code, tt.wantStatus)
+			}
+
+			var got struct {
+				Errors map[string][]string `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("Write() body = %s, error = %v", rec.Body.String(), err)
+			}
+
+			if (len(got.Errors["name"]) > 0) != tt.wantErrors {
+				t.Errorf("Write() errors = %v, wantErrors %v", got.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package address
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewAddressRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+// nullableString stores the empty string as null.
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+func addressFromTable(item tableAddress) address.Address {
+	result := address.Address{
+		ID:            item.ID,
+		UserID:        item.UserID,
+		Label:         item.Label.String,
+		RecipientName: item.RecipientName,
+		Phone:         item.Phone,
+		Line1:         item.Line1,
+		Line2:         item.Line2.String,
+		City:          item.City,
+		Region:        item.Region,
+		PostalCode:    item.PostalCode,
+		Country:       item.Country,
+	}
+
+	if item.CreatedAt.Valid {
+		result.CreatedAt = &item.CreatedAt.Time
+	}
+
+	if item.UpdatedAt.Valid {
+		result.UpdatedAt = &item.UpdatedAt.Time
+	}
+
+	return result
+}
+
+func (r *Repo) Create(ctx context.Context, userID string, param address.WriteParam) (address.Address, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return address.Address{}, err
+	}
+
+	now := time.Now()
+
+	_, err = r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryInsertAddress), id.String(), userID, nullableString(param.Label),
+		param.RecipientName, param.Phone, param.Line1, nullableString(param.Line2), param.City, param.Region,
+		param.PostalCode, param.Country, now, now)
+	if err != nil {
+		slog.Error("error create address", slog.String("error", err.Error()), slog.String("user_id", userID))
+		return address.Address{}, err
+	}
+
+	return r.FindByID(ctx, userID, id.String())
+}
+
+func (r *Repo) Update(ctx context.Context, userID, id string, param address.WriteParam) (address.Address, error) {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryUpdateAddress), nullableString(param.Label),
+		param.RecipientName, param.Phone, param.Line1, nullableString(param.Line2), param.City, param.Region,
+		param.PostalCode, param.Country, time.Now(), id, userID)
+	if err != nil {
+		slog.Error("error update address", slog.String("error", err.Error()), slog.String("id", id))
+		return address.Address{}, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return address.Address{}, ErrNotFound
+	}
+
+	return r.FindByID(ctx, userID, id)
+}
+
+// Delete removes the address from the address book, the placed orders keep their own copy of the address.
+func (r *Repo) Delete(ctx context.Context, userID, id string) error {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryDeleteAddress), time.Now(), id, userID)
+	if err != nil {
+		slog.Error("error delete address", slog.String("error", err.Error()), slog.String("id", id))
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FindByID finds the address owned by the user, the address of the other user is not found.
+func (r *Repo) FindByID(ctx context.Context, userID, id string) (address.Address, error) {
+	var result tableAddress
+	err := r.dbConn.GetContext(ctx, &result, r.dbConn.Rebind(queryGetAddressByID), id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return address.Address{}, ErrNotFound
+	}
+
+	if err != nil {
+		return address.Address{}, err
+	}
+
+	return addressFromTable(result), nil
+}
+
+func (r *Repo) FindByUserID(ctx context.Context, userID string) ([]address.Address, error) {
+	var result []tableAddress
+	if err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(queryGetAddressesByUserID), userID); err != nil {
+		return nil, err
+	}
+
+	addresses := make([]address.Address, 0, len(result))
+	for _, item := range result {
+		addresses = append(addresses, addressFromTable(item))
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,67 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+)
+
+func TestRepo_AddressBook(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateUserAddressesTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	param := address.WriteParam{
+		Label:         "Home",
+		RecipientName: "Rendy",
+		Phone:         "+6281234567890",
+		Line1:         "Jl. Merdeka 1",
+		City:          "Jakarta Pusat",
+		Region:        "DKI Jakarta",
+		PostalCode:    "10110",
+		Country:       "ID",
+	}
+
+	created, err := r.Create(ctx, "u1", param)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if created.UserID != "u1" || created.Label != "Home" || created.Line2 != "" || created.Region != "DKI Jakarta" {
+		t.Errorf("Create() got = %+v", created)
+	}
+
+	if _, err = r.FindByID(ctx, "u2", created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByID() of the other user error = %v, want %v", err, ErrNotFound)
+	}
+
+	param.Line2 = "Apartment 5"
+	updated, err := r.Update(ctx, "u1", created.ID, param)
+	if err != nil || updated.Line2 != "Apartment 5" {
+		t.Errorf("Update() got = %+v, %v", updated, err)
+	}
+
+	if _, err = r.Update(ctx, "u2", created.ID, param); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() of the other user error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err = r.Delete(ctx, "u1", created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if err = r.Delete(ctx, "u1", created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() twice error = %v, want %v", err, ErrNotFound)
+	}
+
+	addresses, err := r.FindByUserID(ctx, "u1")
+	if err != nil || len(addresses) != 0 {
+		t.Errorf("FindByUserID() got = %v, %v, want empty", addresses, err)
+	}
+}
@@ -0,0 +1,9 @@
+package address
+
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+)
+
+var (
+	ErrNotFound = address.ErrNotFound
+)
@@ -0,0 +1,19 @@
+package address
+
+const (
+	queryInsertAddress = `insert into user_addresses (id, user_id, label, recipient_name, phone, line1, line2, city, region,
+					postal_code, country, created_at, updated_at)
+					values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	queryUpdateAddress = `update user_addresses set label = ?, recipient_name = ?, phone = ?, line1 = ?, line2 = ?, city = ?,
+					region = ?, postal_code = ?, country = ?, updated_at = ?
+					where id = ? and user_id = ? and deleted_at is null`
+
+	queryDeleteAddress = `update user_addresses set deleted_at = ? where id = ? and user_id = ? and deleted_at is null`
+
+	queryGetAddressByID = `select id, user_id, label, recipient_name, phone, line1, line2, city, region, postal_code, country,
+					created_at, updated_at from user_addresses where id = ? and user_id = ? and deleted_at is null`
+
+	queryGetAddressesByUserID = `select id, user_id, label, recipient_name, phone, line1, line2, city, region, postal_code, country,
+					created_at, updated_at from user_addresses where user_id = ? and deleted_at is null order by created_at, id`
+)
@@ -0,0 +1,19 @@
+package address
+
+import "database/sql"
+
+type tableAddress struct {
+	ID            string         `db:"id"`
+	UserID        string         `db:"user_id"`
+	Label         sql.NullString `db:"label"`
+	RecipientName string         `db:"recipient_name"`
+	Phone         string         `db:"phone"`
+	Line1         string         `db:"line1"`
+	Line2         sql.NullString `db:"line2"`
+	City          string         `db:"city"`
+	Region        string         `db:"region"`
+	PostalCode    string         `db:"postal_code"`
+	Country       string         `db:"country"`
+	CreatedAt     sql.NullTime   `db:"created_at"`
+	UpdatedAt     sql.NullTime   `db:"updated_at"`
+}
@@ -2,10 +2,14 @@ package book
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"log/slog"
 	"sync"
 	"time"
@@ -19,8 +23,10 @@ type dbConnManager interface {
 type dbConnection interface {
 	Preparex(query string) (*sqlx.Stmt, error)
 	Rebind(query string) string
+	Beginx() (*sqlx.Tx, error)
 
 	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 }
 
 type preparedQueryGetter interface {
@@ -32,9 +38,11 @@ type Config struct {
 	DBConn string
 }
 
+// preparedStmt holds the static queries, the listings are built per request from their filters and cursors.
 type preparedStmt struct {
-	bookPagination       preparedQueryGetter
-	bookSearchPagination preparedQueryGetter
+	getAuthorByID    preparedQueryGetter
+	getGenreByID     preparedQueryGetter
+	getPublisherByID preparedQueryGetter
 }
 
 type Repo struct {
@@ -54,6 +62,7 @@ func NewBookRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
 	}
 
 	r := &Repo{
+		cfg:    cfg,
 		dbConn: conn,
 	}
 
@@ -67,12 +76,17 @@ func NewBookRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
 func (r *Repo) boot() error {
 	var err error
 
-	r.preparedStmt.bookPagination, err = r.dbConn.Preparex(r.dbConn.Rebind(queryPaginateAllBooks))
+	r.preparedStmt.getAuthorByID, err = r.dbConn.Preparex(r.dbConn.Rebind(queryGetAuthorByID))
 	if err != nil {
 		return err
 	}
 
-	r.preparedStmt.bookSearchPagination, err = r.dbConn.Preparex(r.dbConn.Rebind(queryPaginateBooksSearchResult))
+	r.preparedStmt.getGenreByID, err = r.dbConn.Preparex(r.dbConn.Rebind(queryGetGenreByID))
+	if err != nil {
+		return err
+	}
+
+	r.preparedStmt.getPublisherByID, err = r.dbConn.Preparex(r.dbConn.Rebind(queryGetPublisherByID))
 	if err != nil {
 		return err
 	}
@@ -103,7 +117,7 @@ func (r *Repo) compileBooksResult(ctx context.Context, result []tableBook) ([]bo
 			ID:               item.ID,
 			Title:            item.Title,
 			Description:      item.Description,
-			Price:            item.Price,
+			Price:            money.New(item.Price, money.Currency(item.Currency)),
 			ISBN:             item.ISBN,
 			Language:         item.Language,
 			Edition:          item.Edition,
@@ -112,6 +126,8 @@ func (r *Repo) compileBooksResult(ctx context.Context, result []tableBook) ([]bo
 			FirstPublishedAt: firstPublishedAt,
 			CoverImg:         item.CoverImg,
 			Rating:           item.Rating,
+			Stock:            item.Stock,
+			Weight:           item.Weight,
 			Publisher: book.Publisher{
 				ID:   item.PublisherID,
 				Name: item.PublisherName,
@@ -179,72 +195,94 @@ func (r *Repo) compileBooksResult(ctx context.Context, result []tableBook) ([]bo
 }
 
 func (r *Repo) PaginateBookSearch(ctx context.Context, searchQuery string, param book.PaginationParam) (book.PaginationResult, error) {
-	if param.PerPage == 0 {
-		param.PerPage = defaultPaginationLength
+	matchQuery := ftsMatchQuery(searchQuery)
+	if matchQuery == "" {
+		return book.PaginationResult{
+			Data:     []book.Book{},
+			PageInfo: pagination.PageInfo{PerPage: pagination.Limit(param.PerPage)},
+		}, nil
 	}
 
-	var result []tableBook
+	sort, ok := sortOrders[param.Sort]
+	if !ok {
+		return book.PaginationResult{}, ErrInvalidSort
+	}
 
-	searchQuery = fmt.Sprintf("%s%s%s", "%", searchQuery, "%")
+	if param.Sort == book.SortDefault {
+		sort = relevanceSortOrder
+	}
+
+	return r.paginate(ctx, queryPaginateBooksSearchResult, sort, param, matchQuery)
+}
+
+func (r *Repo) PaginateAllBooks(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error) {
+	sort, ok := sortOrders[param.Sort]
+	if !ok {
+		return book.PaginationResult{}, ErrInvalidSort
+	}
 
-	err := r.preparedStmt.bookSearchPagination.SelectContext(ctx, &result, searchQuery, param.LastID, searchQuery, param.PerPage)
+	return r.paginate(ctx, queryPaginateAllBooks, sort, param)
+}
+
+// paginate runs the books query template using keyset pagination, the leading args are bound before
+// the cursor and filter arguments.
+func (r *Repo) paginate(ctx context.Context, queryTemplate string, sort sortOrder, param book.PaginationParam, leadingArgs ...any) (book.PaginationResult, error) {
+	perPage := pagination.Limit(param.PerPage)
+
+	cursor, err := pagination.DecodeCursor(param.Cursor)
 	if err != nil {
 		return book.PaginationResult{}, err
 	}
 
-	books, err := r.compileBooksResult(ctx, result)
-	lastID := ""
-	if len(books) > 0 {
-		lastID = books[len(books)-1].ID
+	if cursor.ID != "" && cursor.Sort != param.Sort {
+		return book.PaginationResult{}, pagination.ErrInvalidCursor
 	}
 
-	if err != nil {
-		return book.PaginationResult{
-			Data:    books,
-			PerPage: param.PerPage,
-			LastID:  lastID,
-		}, err
+	if cursor.Backward {
+		sort = sort.reversed()
 	}
 
-	return book.PaginationResult{
-		Data:    books,
-		PerPage: param.PerPage,
-		LastID:  lastID,
-	}, nil
-}
+	cursorCondition, cursorArgs := sort.cursorCondition(cursor)
+	filterCondition, filterArgs := filterConditions(param.Filter)
 
-func (r *Repo) PaginateAllBooks(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error) {
-	if param.PerPage == 0 {
-		param.PerPage = defaultPaginationLength
+	args := append(leadingArgs, cursorArgs...)
+	args = append(args, filterArgs...)
+	args = append(args, perPage+1)
+
+	query, args, err := sqlx.In(fmt.Sprintf(queryTemplate, sort.sortKey(), cursorCondition, filterCondition, sort.orderBy()), args...)
+	if err != nil {
+		return book.PaginationResult{}, err
 	}
 
 	var result []tableBook
 
-	err := r.preparedStmt.bookPagination.SelectContext(ctx, &result, param.LastID, param.PerPage)
+	err = r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(query), args...)
 	if err != nil {
 		return book.PaginationResult{}, err
 	}
 
-	books, err := r.compileBooksResult(ctx, result)
-
-	lastID := ""
-	if len(books) > 0 {
-		lastID = books[len(books)-1].ID
-	}
+	result, pageInfo := pagination.Paginate(result, cursor, perPage, func(item tableBook) pagination.Cursor {
+		return pagination.Cursor{
+			Sort: param.Sort,
+			Key:  item.SortKey,
+			ID:   item.ID,
+		}
+	})
 
-	if err != nil {
+	// the page after the last book is empty, there is nothing to compile.
+	if len(result) == 0 {
 		return book.PaginationResult{
-			Data:    books,
-			PerPage: param.PerPage,
-			LastID:  lastID,
-		}, err
+			Data:     []book.Book{},
+			PageInfo: pageInfo,
+		}, nil
 	}
 
+	books, err := r.compileBooksResult(ctx, result)
+
 	return book.PaginationResult{
-		Data:    books,
-		PerPage: param.PerPage,
-		LastID:  lastID,
-	}, nil
+		Data:     books,
+		PageInfo: pageInfo,
+	}, err
 }
 
 func (r *Repo) getAuthorsByBookIDs(ctx context.Context, bookIDs []string) ([]tableAuthor, error) {
@@ -283,6 +321,160 @@ func (r *Repo) getGenresByBookIDs(ctx context.Context, bookIDs []string) ([]tabl
 	return genres, nil
 }
 
+// paginateCatalog runs the authors, genres or publishers listing query template, the items are ordered by
+// their name and the id breaks the tie.
+func (r *Repo) paginateCatalog(ctx context.Context, queryTemplate string, param book.ListParam) ([]tableCatalogItem, pagination.PageInfo, error) {
+	perPage := pagination.Limit(param.PerPage)
+
+	cursor, err := pagination.DecodeCursor(param.Cursor)
+	if err != nil {
+		return nil, pagination.PageInfo{PerPage: perPage}, err
+	}
+
+	direction, operator := "asc", ">"
+	if cursor.Backward {
+		direction, operator = "desc", "<"
+	}
+
+	var cursorCondition string
+	var args []any
+	if cursor.ID != "" {
+		cursorCondition = fmt.Sprintf("and (name, id) %s (?, ?)", operator)
+		args = append(args, cursor.Key, cursor.ID)
+	}
+
+	args = append(args, perPage+1)
+
+	var result []tableCatalogItem
+
+	query := fmt.Sprintf(queryTemplate, cursorCondition, direction)
+	err = r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(query), args...)
+	if err != nil {
+		slog.Error("error executing catalog pagination query", slog.String("error", err.Error()))
+		return nil, pagination.PageInfo{PerPage: perPage}, err
+	}
+
+	result, pageInfo := pagination.Paginate(result, cursor, perPage, func(item tableCatalogItem) pagination.Cursor {
+		return pagination.Cursor{
+			Key: item.Name,
+			ID:  item.ID,
+		}
+	})
+
+	return result, pageInfo, nil
+}
+
+func (r *Repo) findCatalogItem(ctx context.Context, stmt preparedQueryGetter, id string) (tableCatalogItem, error) {
+	var result []tableCatalogItem
+
+	err := stmt.SelectContext(ctx, &result, id)
+	if err != nil {
+		return tableCatalogItem{}, err
+	}
+
+	if len(result) == 0 {
+		return tableCatalogItem{}, ErrNotFound
+	}
+
+	return result[0], nil
+}
+
+func (r *Repo) PaginateAuthors(ctx context.Context, param book.ListParam) (book.AuthorsResult, error) {
+	result, pageInfo, err := r.paginateCatalog(ctx, queryPaginateAuthors, param)
+	if err != nil {
+		return book.AuthorsResult{PageInfo: pageInfo}, err
+	}
+
+	authors := make([]book.Author, 0, len(result))
+	for _, item := range result {
+		authors = append(authors, book.Author{
+			ID:   item.ID,
+			Name: item.Name,
+		})
+	}
+
+	return book.AuthorsResult{
+		Data:     authors,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+func (r *Repo) FindAuthorByID(ctx context.Context, id string) (book.Author, error) {
+	item, err := r.findCatalogItem(ctx, r.preparedStmt.getAuthorByID, id)
+	if err != nil {
+		return book.Author{}, err
+	}
+
+	return book.Author{
+		ID:   item.ID,
+		Name: item.Name,
+	}, nil
+}
+
+func (r *Repo) PaginateGenres(ctx context.Context, param book.ListParam) (book.GenresResult, error) {
+	result, pageInfo, err := r.paginateCatalog(ctx, queryPaginateGenres, param)
+	if err != nil {
+		return book.GenresResult{PageInfo: pageInfo}, err
+	}
+
+	genres := make([]book.Genre, 0, len(result))
+	for _, item := range result {
+		genres = append(genres, book.Genre{
+			ID:   item.ID,
+			Name: item.Name,
+		})
+	}
+
+	return book.GenresResult{
+		Data:     genres,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+func (r *Repo) FindGenreByID(ctx context.Context, id string) (book.Genre, error) {
+	item, err := r.findCatalogItem(ctx, r.preparedStmt.getGenreByID, id)
+	if err != nil {
+		return book.Genre{}, err
+	}
+
+	return book.Genre{
+		ID:   item.ID,
+		Name: item.Name,
+	}, nil
+}
+
+func (r *Repo) PaginatePublishers(ctx context.Context, param book.ListParam) (book.PublishersResult, error) {
+	result, pageInfo, err := r.paginateCatalog(ctx, queryPaginatePublishers, param)
+	if err != nil {
+		return book.PublishersResult{PageInfo: pageInfo}, err
+	}
+
+	publishers := make([]book.Publisher, 0, len(result))
+	for _, item := range result {
+		publishers = append(publishers, book.Publisher{
+			ID:   item.ID,
+			Name: item.Name,
+		})
+	}
+
+	return book.PublishersResult{
+		Data:     publishers,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+func (r *Repo) FindPublisherByID(ctx context.Context, id string) (book.Publisher, error) {
+	item, err := r.findCatalogItem(ctx, r.preparedStmt.getPublisherByID, id)
+	if err != nil {
+		return book.Publisher{}, err
+	}
+
+	return book.Publisher{
+		ID:   item.ID,
+		Name: item.Name,
+	}, nil
+}
+
 func (r *Repo) FindByIDs(ctx context.Context, bookIDs []string) ([]book.Book, error) {
 	var rawBooks []tableBook
 
@@ -296,6 +488,10 @@ func (r *Repo) FindByIDs(ctx context.Context, bookIDs []string) ([]book.Book, er
 		return nil, err
 	}
 
+	if len(rawBooks) == 0 {
+		return []book.Book{}, nil
+	}
+
 	var books = make([]book.Book, 0, len(rawBooks))
 	var bookIdxByID = make(map[string]int)
 	var resultBookIDs = make([]string, 0, len(rawBooks))
@@ -316,7 +512,7 @@ func (r *Repo) FindByIDs(ctx context.Context, bookIDs []string) ([]book.Book, er
 			ID:               itemResult.ID,
 			Title:            itemResult.Title,
 			Description:      itemResult.Description,
-			Price:            itemResult.Price,
+			Price:            money.New(itemResult.Price, money.Currency(itemResult.Currency)),
 			ISBN:             itemResult.ISBN,
 			Language:         itemResult.Language,
 			Edition:          itemResult.Edition,
@@ -325,6 +521,8 @@ func (r *Repo) FindByIDs(ctx context.Context, bookIDs []string) ([]book.Book, er
 			FirstPublishedAt: firstPublishedAt,
 			CoverImg:         itemResult.CoverImg,
 			Rating:           itemResult.Rating,
+			Stock:            itemResult.Stock,
+			Weight:           itemResult.Weight,
 			Publisher: book.Publisher{
 				ID:   itemResult.PublisherID,
 				Name: itemResult.PublisherName,
@@ -399,3 +597,182 @@ func (r *Repo) FindByIDs(ctx context.Context, bookIDs []string) ([]book.Book, er
 
 	return books, nil
 }
+
+// dateTimeValue formats the date the same way the seeder does, nil date is stored as null.
+func dateTimeValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return t.UTC().Format(time.DateTime)
+}
+
+// checkReferences makes sure the publisher, authors and genres of the book exist, the ids are expected to be unique.
+func checkReferences(ctx context.Context, tx *sqlx.Tx, param book.WriteParam) error {
+	references := []struct {
+		query string
+		ids   []string
+	}{
+		{query: queryCountPublishersByIDs, ids: []string{param.PublisherID}},
+		{query: queryCountAuthorsByIDs, ids: param.AuthorIDs},
+		{query: queryCountGenresByIDs, ids: param.GenreIDs},
+	}
+
+	for _, reference := range references {
+		if len(reference.ids) == 0 {
+			continue
+		}
+
+		query, args, err := sqlx.In(reference.query, reference.ids)
+		if err != nil {
+			return err
+		}
+
+		var count int
+		if err = tx.GetContext(ctx, &count, tx.Rebind(query), args...); err != nil {
+			return err
+		}
+
+		if count != len(reference.ids) {
+			return ErrInvalidReference
+		}
+	}
+
+	return nil
+}
+
+func insertBookLinks(ctx context.Context, tx *sqlx.Tx, bookID string, param book.WriteParam) error {
+	for _, authorID := range param.AuthorIDs {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind(queryInsertBookAuthor), id.String(), bookID, authorID); err != nil {
+			return err
+		}
+	}
+
+	for _, genreID := range param.GenreIDs {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, tx.Rebind(queryInsertBookGenre), id.String(), bookID, genreID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Create inserts the book along with its authors and genres in a single transaction, it returns the new book id.
+func (r *Repo) Create(ctx context.Context, param book.WriteParam) (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := r.dbConn.Beginx()
+	if err != nil {
+		return "", err
+	}
+
+	// rolling back the committed transaction does nothing.
+	defer tx.Rollback()
+
+	if err = checkReferences(ctx, tx, param); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertBook), id.String(), param.Title, param.Description, param.Price.Amount(),
+		param.Price.Currency(), param.ISBN, param.Language, param.Edition, param.Pages, param.PublisherID, dateTimeValue(param.PublishedAt),
+		dateTimeValue(param.FirstPublishedAt), param.CoverImg, param.Rating, param.Weight, now, now)
+	if err != nil {
+		slog.Error("error create book", slog.String("error", err.Error()))
+		return "", err
+	}
+
+	if err = insertBookLinks(ctx, tx, id.String(), param); err != nil {
+		slog.Error("error create book links", slog.String("error", err.Error()), slog.String("book_id", id.String()))
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// Update replaces the book data, its authors and genres in a single transaction. Soft deleted book is not found.
+func (r *Repo) Update(ctx context.Context, id string, param book.WriteParam) error {
+	tx, err := r.dbConn.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err = checkReferences(ctx, tx, param); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, tx.Rebind(queryUpdateBook), param.Title, param.Description, param.Price.Amount(),
+		param.Price.Currency(), param.ISBN, param.Language, param.Edition, param.Pages, param.PublisherID, dateTimeValue(param.PublishedAt),
+		dateTimeValue(param.FirstPublishedAt), param.CoverImg, param.Rating, param.Weight, time.Now(), id)
+	if err != nil {
+		slog.Error("error update book", slog.String("error", err.Error()), slog.String("book_id", id))
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrNotFound
+	}
+
+	for _, query := range []string{queryDeleteBookAuthors, queryDeleteBookGenres} {
+		if _, err = tx.ExecContext(ctx, tx.Rebind(query), id); err != nil {
+			return err
+		}
+	}
+
+	if err = insertBookLinks(ctx, tx, id, param); err != nil {
+		slog.Error("error update book links", slog.String("error", err.Error()), slog.String("book_id", id))
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SoftDelete hides the book from the catalog, the book is kept for the existing orders.
+func (r *Repo) SoftDelete(ctx context.Context, id string) error {
+	now := time.Now()
+
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(querySoftDeleteBook), now, now, id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Restore brings back the soft deleted book into the catalog.
+func (r *Repo) Restore(ctx context.Context, id string) error {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryRestoreBook), time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ package book
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -73,6 +74,41 @@ func (m *MockdbConnection) EXPECT() *MockdbConnectionMockRecorder {
 	return m.recorder
 }
 
+// Beginx mocks base method.
+func (m *MockdbConnection) Beginx() (*sqlx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Beginx")
+	ret0, _ := ret[0].(*sqlx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Beginx indicates an expected call of Beginx.
+func (mr *MockdbConnectionMockRecorder) Beginx() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Beginx", reflect.TypeOf((*MockdbConnection)(nil).Beginx))
+}
+
+// ExecContext mocks base method.
+func (m *MockdbConnection) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecContext", varargs...)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockdbConnectionMockRecorder) ExecContext(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*MockdbConnection)(nil).ExecContext), varargs...)
+}
+
 // Preparex mocks base method.
 func (m *MockdbConnection) Preparex(query string) (*sqlx.Stmt, error) {
 	m.ctrl.T.Helper()
@@ -3,11 +3,16 @@ package book
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/jmoiron/sqlx"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestRepo_FindByIDs(t *testing.T) {
@@ -17,9 +22,8 @@ func TestRepo_FindByIDs(t *testing.T) {
 	dbConnMock := NewMockdbConnection(ctrl)
 
 	type fields struct {
-		cfg          Config
-		dbConn       dbConnection
-		preparedStmt preparedStmt
+		cfg    Config
+		dbConn dbConnection
 	}
 	type args struct {
 		ctx context.Context
@@ -36,9 +40,8 @@ func TestRepo_FindByIDs(t *testing.T) {
 		{
 			name: "can get book detail by id",
 			fields: fields{
-				cfg:          Config{},
-				dbConn:       dbConnMock,
-				preparedStmt: preparedStmt{},
+				cfg:    Config{},
+				dbConn: dbConnMock,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -55,7 +58,8 @@ func TestRepo_FindByIDs(t *testing.T) {
 							ID:            "1",
 							Title:         "Book 1",
 							Description:   "Desc",
-							Price:         5.6,
+							Price:         560,
+							Currency:      "USD",
 							ISBN:          "123124123",
 							PublisherID:   "2",
 							PublisherName: "Publisher Book 1",
@@ -103,7 +107,7 @@ func TestRepo_FindByIDs(t *testing.T) {
 					ID:          "1",
 					Title:       "Book 1",
 					Description: "Desc",
-					Price:       5.6,
+					Price:       money.New(560, money.USD),
 					ISBN:        "123124123",
 					Publisher: book.Publisher{
 						ID:   "2",
@@ -133,12 +137,31 @@ func TestRepo_FindByIDs(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "can handle unknown book ids",
+			fields: fields{
+				cfg:    Config{},
+				dbConn: dbConnMock,
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  []string{"9"},
+			},
+			beforeTest: func() {
+				query, queryArgs, _ := sqlx.In(queryGetBookByIDs, []string{"9"})
+				dbConnMock.EXPECT().Rebind(query).Return(query)
+
+				var bookResult []tableBook
+				dbConnMock.EXPECT().SelectContext(context.Background(), &bookResult, query, queryArgs).Return(nil)
+			},
+			want:    []book.Book{},
+			wantErr: false,
+		},
 		{
 			name: "can handle fail get book by id",
 			fields: fields{
-				cfg:          Config{},
-				dbConn:       dbConnMock,
-				preparedStmt: preparedStmt{},
+				cfg:    Config{},
+				dbConn: dbConnMock,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -155,7 +178,8 @@ func TestRepo_FindByIDs(t *testing.T) {
 							ID:            "1",
 							Title:         "Book 1",
 							Description:   "Desc",
-							Price:         5.6,
+							Price:         560,
+							Currency:      "USD",
 							ISBN:          "123124123",
 							PublisherID:   "2",
 							PublisherName: "Publisher Book 1",
@@ -191,7 +215,7 @@ func TestRepo_FindByIDs(t *testing.T) {
 					ID:          "1",
 					Title:       "Book 1",
 					Description: "Desc",
-					Price:       5.6,
+					Price:       money.New(560, money.USD),
 					ISBN:        "123124123",
 					Publisher: book.Publisher{
 						ID:   "2",
@@ -216,9 +240,8 @@ func TestRepo_FindByIDs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Repo{
-				cfg:          tt.fields.cfg,
-				dbConn:       tt.fields.dbConn,
-				preparedStmt: tt.fields.preparedStmt,
+				cfg:    tt.fields.cfg,
+				dbConn: tt.fields.dbConn,
 			}
 
 			if tt.beforeTest != nil {
@@ -242,12 +265,10 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 	defer ctrl.Finish()
 
 	dbConnMock := NewMockdbConnection(ctrl)
-	preparedStmtMock := NewMockpreparedQueryGetter(ctrl)
 
 	type fields struct {
-		cfg          Config
-		dbConn       dbConnection
-		preparedStmt preparedStmt
+		cfg    Config
+		dbConn dbConnection
 	}
 	type args struct {
 		ctx   context.Context
@@ -266,26 +287,27 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 			fields: fields{
 				cfg:    Config{},
 				dbConn: dbConnMock,
-				preparedStmt: preparedStmt{
-					bookPagination: preparedStmtMock,
-				},
 			},
 			args: args{
 				ctx: context.Background(),
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
+				query, queryArgs, _ := sqlx.In(fmt.Sprintf(queryPaginateAllBooks, "null", "", "", "b.id asc"), 2)
+				dbConnMock.EXPECT().Rebind(query).Return(query)
+
 				var bookResult []tableBook
-				preparedStmtMock.EXPECT().SelectContext(context.Background(), &bookResult, []interface{}{"", 1}).Return(nil).
+				dbConnMock.EXPECT().SelectContext(context.Background(), &bookResult, query, queryArgs...).Return(nil).
 					SetArg(1, []tableBook{
 						{
 							ID:            "1",
 							Title:         "Book 1",
 							Description:   "Desc",
-							Price:         5.6,
+							Price:         560,
+							Currency:      "USD",
 							ISBN:          "123124123",
 							PublisherID:   "2",
 							PublisherName: "Publisher Book 1",
@@ -293,7 +315,7 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 					})
 
 				var result []tableAuthor
-				query, queryArgs, _ := sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
+				query, queryArgs, _ = sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
 				dbConnMock.EXPECT().Rebind(query).Return(query)
 				dbConnMock.EXPECT().SelectContext(context.Background(), &result, query, queryArgs).
 					Return(nil).
@@ -334,7 +356,7 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5.6,
+						Price:       money.New(560, money.USD),
 						ISBN:        "123124123",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -362,8 +384,7 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1},
 			},
 			wantErr: false,
 		},
@@ -372,26 +393,27 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 			fields: fields{
 				cfg:    Config{},
 				dbConn: dbConnMock,
-				preparedStmt: preparedStmt{
-					bookPagination: preparedStmtMock,
-				},
 			},
 			args: args{
 				ctx: context.Background(),
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
+				query, queryArgs, _ := sqlx.In(fmt.Sprintf(queryPaginateAllBooks, "null", "", "", "b.id asc"), 2)
+				dbConnMock.EXPECT().Rebind(query).Return(query)
+
 				var bookResult []tableBook
-				preparedStmtMock.EXPECT().SelectContext(context.Background(), &bookResult, []interface{}{"", 1}).Return(nil).
+				dbConnMock.EXPECT().SelectContext(context.Background(), &bookResult, query, queryArgs...).Return(nil).
 					SetArg(1, []tableBook{
 						{
 							ID:            "1",
 							Title:         "Book 1",
 							Description:   "Desc",
-							Price:         5.6,
+							Price:         560,
+							Currency:      "USD",
 							ISBN:          "123124123",
 							PublisherID:   "2",
 							PublisherName: "Publisher Book 1",
@@ -399,7 +421,7 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 					})
 
 				var result []tableAuthor
-				query, queryArgs, _ := sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
+				query, queryArgs, _ = sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
 				dbConnMock.EXPECT().Rebind(query).Return(query)
 				dbConnMock.EXPECT().SelectContext(context.Background(), &result, query, queryArgs).
 					Return(nil).
@@ -428,7 +450,7 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5.6,
+						Price:       money.New(560, money.USD),
 						ISBN:        "123124123",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -446,8 +468,7 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1},
 			},
 			wantErr: true,
 		},
@@ -455,9 +476,8 @@ func TestRepo_PaginateAllBooks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Repo{
-				cfg:          tt.fields.cfg,
-				dbConn:       tt.fields.dbConn,
-				preparedStmt: tt.fields.preparedStmt,
+				cfg:    tt.fields.cfg,
+				dbConn: tt.fields.dbConn,
 			}
 			if tt.beforeTest != nil {
 				tt.beforeTest()
@@ -499,24 +519,27 @@ func TestRepo_boot(t *testing.T) {
 				preparedStmt: preparedStmt{},
 			},
 			beforeTest: func(r *Repo) {
-				dbConnMock.EXPECT().Rebind(queryPaginateAllBooks).Return(queryPaginateAllBooks)
-				dbConnMock.EXPECT().Preparex(queryPaginateAllBooks).Return(&sqlx.Stmt{}, nil)
+				dbConnMock.EXPECT().Rebind(queryGetAuthorByID).Return(queryGetAuthorByID)
+				dbConnMock.EXPECT().Preparex(queryGetAuthorByID).Return(&sqlx.Stmt{}, nil)
 
-				dbConnMock.EXPECT().Rebind(queryPaginateBooksSearchResult).Return(queryPaginateBooksSearchResult)
-				dbConnMock.EXPECT().Preparex(queryPaginateBooksSearchResult).Return(&sqlx.Stmt{}, nil)
+				dbConnMock.EXPECT().Rebind(queryGetGenreByID).Return(queryGetGenreByID)
+				dbConnMock.EXPECT().Preparex(queryGetGenreByID).Return(&sqlx.Stmt{}, nil)
+
+				dbConnMock.EXPECT().Rebind(queryGetPublisherByID).Return(queryGetPublisherByID)
+				dbConnMock.EXPECT().Preparex(queryGetPublisherByID).Return(&sqlx.Stmt{}, nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "boot repository",
+			name: "can handle error boot repository",
 			fields: fields{
 				cfg:          Config{},
 				dbConn:       dbConnMock,
 				preparedStmt: preparedStmt{},
 			},
 			beforeTest: func(r *Repo) {
-				dbConnMock.EXPECT().Rebind(queryPaginateAllBooks).Return(queryPaginateAllBooks)
-				dbConnMock.EXPECT().Preparex(queryPaginateAllBooks).Return(&sqlx.Stmt{}, sql.ErrConnDone)
+				dbConnMock.EXPECT().Rebind(queryGetAuthorByID).Return(queryGetAuthorByID)
+				dbConnMock.EXPECT().Preparex(queryGetAuthorByID).Return(&sqlx.Stmt{}, sql.ErrConnDone)
 			},
 			wantErr: true,
 		},
@@ -547,9 +570,8 @@ func TestRepo_getAuthorsByBookIDs(t *testing.T) {
 	dbConnMock := NewMockdbConnection(ctrl)
 
 	type fields struct {
-		cfg          Config
-		dbConn       dbConnection
-		preparedStmt preparedStmt
+		cfg    Config
+		dbConn dbConnection
 	}
 	type args struct {
 		ctx     context.Context
@@ -566,9 +588,8 @@ func TestRepo_getAuthorsByBookIDs(t *testing.T) {
 		{
 			name: "can handle many book ids",
 			fields: fields{
-				cfg:          Config{},
-				dbConn:       dbConnMock,
-				preparedStmt: preparedStmt{},
+				cfg:    Config{},
+				dbConn: dbConnMock,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -620,9 +641,8 @@ func TestRepo_getAuthorsByBookIDs(t *testing.T) {
 		{
 			name: "can handle error get many book ids",
 			fields: fields{
-				cfg:          Config{},
-				dbConn:       dbConnMock,
-				preparedStmt: preparedStmt{},
+				cfg:    Config{},
+				dbConn: dbConnMock,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -642,9 +662,8 @@ func TestRepo_getAuthorsByBookIDs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Repo{
-				cfg:          tt.fields.cfg,
-				dbConn:       tt.fields.dbConn,
-				preparedStmt: tt.fields.preparedStmt,
+				cfg:    tt.fields.cfg,
+				dbConn: tt.fields.dbConn,
 			}
 
 			if tt.beforeTest != nil {
@@ -670,9 +689,8 @@ func TestRepo_getGenresByBookIDs(t *testing.T) {
 	dbConnMock := NewMockdbConnection(ctrl)
 
 	type fields struct {
-		cfg          Config
-		dbConn       dbConnection
-		preparedStmt preparedStmt
+		cfg    Config
+		dbConn dbConnection
 	}
 	type args struct {
 		ctx     context.Context
@@ -689,9 +707,8 @@ func TestRepo_getGenresByBookIDs(t *testing.T) {
 		{
 			name: "can handle many book ids",
 			fields: fields{
-				cfg:          Config{},
-				dbConn:       dbConnMock,
-				preparedStmt: preparedStmt{},
+				cfg:    Config{},
+				dbConn: dbConnMock,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -743,9 +760,8 @@ func TestRepo_getGenresByBookIDs(t *testing.T) {
 		{
 			name: "can handle error get many book ids",
 			fields: fields{
-				cfg:          Config{},
-				dbConn:       dbConnMock,
-				preparedStmt: preparedStmt{},
+				cfg:    Config{},
+				dbConn: dbConnMock,
 			},
 			args: args{
 				ctx:     context.Background(),
@@ -765,9 +781,8 @@ func TestRepo_getGenresByBookIDs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Repo{
-				cfg:          tt.fields.cfg,
-				dbConn:       tt.fields.dbConn,
-				preparedStmt: tt.fields.preparedStmt,
+				cfg:    tt.fields.cfg,
+				dbConn: tt.fields.dbConn,
 			}
 
 			if tt.beforeTest != nil {
@@ -791,12 +806,10 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 	defer ctrl.Finish()
 
 	dbConnMock := NewMockdbConnection(ctrl)
-	preparedStmtMock := NewMockpreparedQueryGetter(ctrl)
 
 	type fields struct {
-		cfg          Config
-		dbConn       dbConnection
-		preparedStmt preparedStmt
+		cfg    Config
+		dbConn dbConnection
 	}
 	type args struct {
 		ctx         context.Context
@@ -816,27 +829,28 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 			fields: fields{
 				cfg:    Config{},
 				dbConn: dbConnMock,
-				preparedStmt: preparedStmt{
-					bookSearchPagination: preparedStmtMock,
-				},
 			},
 			args: args{
 				ctx:         context.Background(),
 				searchQuery: "potter",
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
+				query, queryArgs, _ := sqlx.In(fmt.Sprintf(queryPaginateBooksSearchResult, "m.rank", "", "", "m.rank asc, b.id asc"), "\"potter\"*", 2)
+				dbConnMock.EXPECT().Rebind(query).Return(query)
+
 				var bookResult []tableBook
-				preparedStmtMock.EXPECT().SelectContext(context.Background(), &bookResult, []interface{}{"%potter%", "", "%potter%", 1}...).Return(nil).
+				dbConnMock.EXPECT().SelectContext(context.Background(), &bookResult, query, queryArgs...).Return(nil).
 					SetArg(1, []tableBook{
 						{
 							ID:            "1",
 							Title:         "Book 1",
 							Description:   "Desc",
-							Price:         5.6,
+							Price:         560,
+							Currency:      "USD",
 							ISBN:          "123124123",
 							PublisherID:   "2",
 							PublisherName: "Publisher Book 1",
@@ -844,7 +858,7 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 					})
 
 				var result []tableAuthor
-				query, queryArgs, _ := sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
+				query, queryArgs, _ = sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
 				dbConnMock.EXPECT().Rebind(query).Return(query)
 				dbConnMock.EXPECT().SelectContext(context.Background(), &result, query, queryArgs).
 					Return(nil).
@@ -885,7 +899,7 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5.6,
+						Price:       money.New(560, money.USD),
 						ISBN:        "123124123",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -913,8 +927,7 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1},
 			},
 			wantErr: false,
 		},
@@ -923,27 +936,28 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 			fields: fields{
 				cfg:    Config{},
 				dbConn: dbConnMock,
-				preparedStmt: preparedStmt{
-					bookSearchPagination: preparedStmtMock,
-				},
 			},
 			args: args{
 				ctx:         context.Background(),
 				searchQuery: "potter",
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
+				query, queryArgs, _ := sqlx.In(fmt.Sprintf(queryPaginateBooksSearchResult, "m.rank", "", "", "m.rank asc, b.id asc"), "\"potter\"*", 2)
+				dbConnMock.EXPECT().Rebind(query).Return(query)
+
 				var bookResult []tableBook
-				preparedStmtMock.EXPECT().SelectContext(context.Background(), &bookResult, []interface{}{"%potter%", "", "%potter%", 1}...).Return(nil).
+				dbConnMock.EXPECT().SelectContext(context.Background(), &bookResult, query, queryArgs...).Return(nil).
 					SetArg(1, []tableBook{
 						{
 							ID:            "1",
 							Title:         "Book 1",
 							Description:   "Desc",
-							Price:         5.6,
+							Price:         560,
+							Currency:      "USD",
 							ISBN:          "123124123",
 							PublisherID:   "2",
 							PublisherName: "Publisher Book 1",
@@ -951,7 +965,7 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 					})
 
 				var result []tableAuthor
-				query, queryArgs, _ := sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
+				query, queryArgs, _ = sqlx.In(queryGetAuthorsByBookIDs, []string{"1"})
 				dbConnMock.EXPECT().Rebind(query).Return(query)
 				dbConnMock.EXPECT().SelectContext(context.Background(), &result, query, queryArgs).
 					Return(nil).
@@ -980,7 +994,7 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5.6,
+						Price:       money.New(560, money.USD),
 						ISBN:        "123124123",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -998,18 +1012,36 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1},
 			},
 			wantErr: true,
 		},
+		{
+			name: "can skip searching when the query has no searchable term",
+			fields: fields{
+				cfg:    Config{},
+				dbConn: dbConnMock,
+			},
+			args: args{
+				ctx:         context.Background(),
+				searchQuery: "\"*-",
+				param: book.PaginationParam{
+					PerPage: 1,
+					Cursor:  "",
+				},
+			},
+			want: book.PaginationResult{
+				Data:     []book.Book{},
+				PageInfo: pagination.PageInfo{PerPage: 1},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Repo{
-				cfg:          tt.fields.cfg,
-				dbConn:       tt.fields.dbConn,
-				preparedStmt: tt.fields.preparedStmt,
+				cfg:    tt.fields.cfg,
+				dbConn: tt.fields.dbConn,
 			}
 			if tt.beforeTest != nil {
 				tt.beforeTest()
@@ -1025,3 +1057,100 @@ func TestRepo_PaginateBookSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestRepo_CreateAndUpdate(t *testing.T) {
+	conn := newCatalogTestConn(t)
+	r := &Repo{dbConn: conn}
+
+	publishedAt := time.Date(2010, 8, 24, 0, 0, 0, 0, time.UTC)
+	param := book.WriteParam{
+		Title:       "Mockingjay",
+		Description: "My name is Katniss Everdeen.",
+		Price:       money.New(650, money.USD),
+		ISBN:        "4",
+		Language:    "English",
+		Pages:       390,
+		PublishedAt: &publishedAt,
+		Rating:      4.1,
+		PublisherID: "p1",
+		AuthorIDs:   []string{"a1"},
+		GenreIDs:    []string{"g1", "g2"},
+	}
+
+	id, err := r.Create(context.Background(), param)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := r.FindByIDs(context.Background(), []string{id})
+	if err != nil || len(got) != 1 {
+		t.Fatalf("FindByIDs() got = %v, error = %v", got, err)
+	}
+
+	if got[0].Title != param.Title || len(got[0].Authors) != 1 || len(got[0].Genres) != 2 || !got[0].PublishedAt.Equal(publishedAt) {
+		t.Errorf("Create() got = %+v, want %+v", got[0], param)
+	}
+
+	param.Title = "Mockingjay (Hunger Games, Book Three)"
+	param.AuthorIDs = []string{"a1", "a2"}
+	param.GenreIDs = []string{"g1"}
+	param.PublishedAt = nil
+	if err = r.Update(context.Background(), id, param); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, _ = r.FindByIDs(context.Background(), []string{id})
+	if got[0].Title != param.Title || len(got[0].Authors) != 2 || len(got[0].Genres) != 1 || got[0].PublishedAt != nil {
+		t.Errorf("Update() got = %+v, want %+v", got[0], param)
+	}
+
+	// unknown reference rolls back the whole write.
+	invalidParam := param
+	invalidParam.Title = "Unknown"
+	invalidParam.GenreIDs = []string{"g1", "unknown"}
+	if err = r.Update(context.Background(), id, invalidParam); !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("Update() error = %v, want %v", err, ErrInvalidReference)
+	}
+
+	if _, err = r.Create(context.Background(), invalidParam); !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("Create() error = %v, want %v", err, ErrInvalidReference)
+	}
+
+	var count int
+	_ = conn.Get(&count, `select count(*) from books where title = 'Unknown'`)
+	if count != 0 {
+		t.Errorf("Create() invalid book is written")
+	}
+
+	if err = r.Update(context.Background(), "unknown", param); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestRepo_SoftDeleteAndRestore(t *testing.T) {
+	r := &Repo{dbConn: newCatalogTestConn(t)}
+
+	if err := r.SoftDelete(context.Background(), "b1"); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+
+	if got, _ := r.FindByIDs(context.Background(), []string{"b1"}); len(got) != 0 {
+		t.Errorf("FindByIDs() got = %v, want soft deleted book to be hidden", got)
+	}
+
+	if err := r.SoftDelete(context.Background(), "b1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SoftDelete() error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err := r.Restore(context.Background(), "b1"); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if got, _ := r.FindByIDs(context.Background(), []string{"b1"}); len(got) != 1 {
+		t.Errorf("FindByIDs() got = %v, want restored book", got)
+	}
+
+	if err := r.Restore(context.Background(), "b1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Restore() error = %v, want %v", err, ErrNotFound)
+	}
+}
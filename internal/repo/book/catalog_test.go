@@ -0,0 +1,99 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+)
+
+func TestRepo_PaginateAuthors(t *testing.T) {
+	conn := newCatalogTestConn(t)
+	conn.MustExec(`insert into authors (id, name) values ('a3', 'George Orwell');
+		insert into authors (id, name, deleted_at) values ('a4', 'Anonymous', current_timestamp);`)
+
+	r := &Repo{dbConn: conn}
+
+	firstPage, err := r.PaginateAuthors(context.Background(), book.ListParam{PerPage: 2})
+	if err != nil {
+		t.Fatalf("PaginateAuthors() error = %v", err)
+	}
+
+	want := []book.Author{{ID: "a3", Name: "George Orwell"}, {ID: "a2", Name: "J.K. Rowling"}}
+	if !reflect.DeepEqual(firstPage.Data, want) || !firstPage.PageInfo.HasMore {
+		t.Errorf("PaginateAuthors() first page got = %v, want %v with more", firstPage, want)
+	}
+
+	secondPage, err := r.PaginateAuthors(context.Background(), book.ListParam{PerPage: 2, Cursor: firstPage.PageInfo.Next})
+	if err != nil {
+		t.Fatalf("PaginateAuthors() error = %v", err)
+	}
+
+	want = []book.Author{{ID: "a1", Name: "Suzanne Collins"}}
+	if !reflect.DeepEqual(secondPage.Data, want) || secondPage.PageInfo.HasMore {
+		t.Errorf("PaginateAuthors() second page got = %v, want %v", secondPage, want)
+	}
+
+	prevPage, err := r.PaginateAuthors(context.Background(), book.ListParam{PerPage: 2, Cursor: secondPage.PageInfo.Prev})
+	if err != nil {
+		t.Fatalf("PaginateAuthors() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(prevPage.Data, firstPage.Data) {
+		t.Errorf("PaginateAuthors() previous page got = %v, want %v", prevPage.Data, firstPage.Data)
+	}
+}
+
+func TestRepo_PaginateGenresAndPublishers(t *testing.T) {
+	r := &Repo{dbConn: newCatalogTestConn(t)}
+
+	genres, err := r.PaginateGenres(context.Background(), book.ListParam{})
+	if err != nil {
+		t.Fatalf("PaginateGenres() error = %v", err)
+	}
+
+	wantGenres := []book.Genre{{ID: "g1", Name: "Dystopia"}, {ID: "g2", Name: "Fantasy"}}
+	if !reflect.DeepEqual(genres.Data, wantGenres) {
+		t.Errorf("PaginateGenres() got = %v, want %v", genres.Data, wantGenres)
+	}
+
+	publishers, err := r.PaginatePublishers(context.Background(), book.ListParam{})
+	if err != nil {
+		t.Fatalf("PaginatePublishers() error = %v", err)
+	}
+
+	wantPublishers := []book.Publisher{{ID: "p2", Name: "Bloomsbury"}, {ID: "p1", Name: "Scholastic Press"}}
+	if !reflect.DeepEqual(publishers.Data, wantPublishers) {
+		t.Errorf("PaginatePublishers() got = %v, want %v", publishers.Data, wantPublishers)
+	}
+}
+
+func TestRepo_FindCatalogItemByID(t *testing.T) {
+	conn := newCatalogTestConn(t)
+	conn.MustExec(`update publishers set deleted_at = current_timestamp where id = 'p2'`)
+
+	r := &Repo{dbConn: conn}
+	if err := r.boot(); err != nil {
+		t.Fatalf("boot() error = %v", err)
+	}
+
+	author, err := r.FindAuthorByID(context.Background(), "a1")
+	if err != nil || author != (book.Author{ID: "a1", Name: "Suzanne Collins"}) {
+		t.Errorf("FindAuthorByID() got = %v, error = %v", author, err)
+	}
+
+	genre, err := r.FindGenreByID(context.Background(), "g2")
+	if err != nil || genre != (book.Genre{ID: "g2", Name: "Fantasy"}) {
+		t.Errorf("FindGenreByID() got = %v, error = %v", genre, err)
+	}
+
+	if _, err := r.FindAuthorByID(context.Background(), "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindAuthorByID() error = %v, want %v", err, ErrNotFound)
+	}
+
+	if _, err := r.FindPublisherByID(context.Background(), "p2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindPublisherByID() soft deleted error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -1,11 +1,13 @@
 package book
 
-import "errors"
-
-const (
-	defaultPaginationLength = 20
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
 )
 
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound    = book.ErrNotFound
+	ErrInvalidSort = book.ErrInvalidSort
+
+	ErrInvalidReference  = book.ErrInvalidReference
+	ErrInsufficientStock = book.ErrInsufficientStock
 )
@@ -0,0 +1,68 @@
+package book
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+)
+
+// filterConditions compiles the catalog filter into additional where clause conditions of books query.
+// The id list arguments are slices, thus the query needs to be expanded using sqlx.In.
+func filterConditions(filter book.Filter) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if len(filter.GenreIDs) > 0 {
+		conditions = append(conditions, "exists (select 1 from books_genres bg where bg.book_id = b.id and bg.genre_id in (?))")
+		args = append(args, filter.GenreIDs)
+	}
+
+	if len(filter.AuthorIDs) > 0 {
+		conditions = append(conditions, "exists (select 1 from books_authors ba where ba.book_id = b.id and ba.author_id in (?))")
+		args = append(args, filter.AuthorIDs)
+	}
+
+	if len(filter.PublisherIDs) > 0 {
+		conditions = append(conditions, "b.publisher_id in (?)")
+		args = append(args, filter.PublisherIDs)
+	}
+
+	if filter.Language != "" {
+		conditions = append(conditions, "lower(b.language) = lower(?)")
+		args = append(args, filter.Language)
+	}
+
+	// the price is compared in the minor unit, thus only the books of the same currency are comparable.
+	if filter.MinPrice.IsPositive() {
+		conditions = append(conditions, "b.currency = ? and b.price >= ?")
+		args = append(args, filter.MinPrice.Currency(), filter.MinPrice.Amount())
+	}
+
+	if filter.MaxPrice.IsPositive() {
+		conditions = append(conditions, "b.currency = ? and b.price <= ?")
+		args = append(args, filter.MaxPrice.Currency(), filter.MaxPrice.Amount())
+	}
+
+	if filter.MinRating > 0 {
+		conditions = append(conditions, "b.rating >= ?")
+		args = append(args, filter.MinRating)
+	}
+
+	// published_at is stored as text, compare it using the same layout, books without publish date are excluded.
+	if filter.PublishedFrom != nil {
+		conditions = append(conditions, "nullif(b.published_at, '') >= ?")
+		args = append(args, filter.PublishedFrom.Format(time.DateTime))
+	}
+
+	if filter.PublishedUntil != nil {
+		conditions = append(conditions, "nullif(b.published_at, '') <= ?")
+		args = append(args, filter.PublishedUntil.Format(time.DateTime))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "and " + strings.Join(conditions, " and "), args
+}
@@ -0,0 +1,181 @@
+package book
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// newCatalogTestConn creates in memory database filled with small books catalog.
+func newCatalogTestConn(t *testing.T) *sqlx.DB {
+	conn, err := sqlx.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot open connection: %s", err)
+	}
+
+	// every connection of in memory database has its own database
+	conn.SetMaxOpenConns(1)
+
+	for _, migration := range []interface{ Up() error }{
+		migrations.CreatePublishersTable{Conn: conn},
+		migrations.CreateAuthorsTable{Conn: conn},
+		migrations.CreateGenresTable{Conn: conn},
+		migrations.CreateBooksTable{Conn: conn},
+		migrations.CreateBooksAuthorsTable{Conn: conn},
+		migrations.CreateBooksGenresTable{Conn: conn},
+		migrations.CreateBookStockAdjustmentsTable{Conn: conn},
+	} {
+		if err := migration.Up(); err != nil {
+			t.Fatalf("cannot migrate: %s", err)
+		}
+	}
+
+	conn.MustExec(`insert into publishers (id, name) values ('p1', 'Scholastic Press'), ('p2', 'Bloomsbury');
+		insert into authors (id, name) values ('a1', 'Suzanne Collins'), ('a2', 'J.K. Rowling');
+		insert into genres (id, name) values ('g1', 'Dystopia'), ('g2', 'Fantasy');
+		insert into books (id, title, description, price, isbn, language, edition, pages, publisher_id, published_at, cover_img, rating) values
+			('b1', 'The Hunger Games', 'Winning will make you famous.', 509, '1', 'English', '', 374, 'p1', '2008-09-14 00:00:00', '', 4.33),
+			('b2', 'Catching Fire', 'Sparks are igniting.', 620, '2', 'English', '', 391, 'p1', '2009-09-01 00:00:00', '', 4.3),
+			('b3', 'Harry Potter and the Order of the Phoenix', 'There is a door at the end of a silent corridor.', 738, '3', 'English', '', 870, 'p2', '2004-09-01 00:00:00', '', 4.5);
+		insert into books_authors (id, book_id, author_id) values ('ba1', 'b1', 'a1'), ('ba2', 'b2', 'a1'), ('ba3', 'b3', 'a2');
+		insert into books_genres (id, book_id, genre_id) values ('bg1', 'b1', 'g1'), ('bg2', 'b2', 'g1'), ('bg3', 'b3', 'g2');`)
+
+	return conn
+}
+
+func Test_filterConditions(t *testing.T) {
+	publishedFrom := time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)
+	publishedUntil := time.Date(2010, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   book.Filter
+		want     string
+		wantArgs []any
+	}{
+		{
+			name:     "can skip empty filter",
+			filter:   book.Filter{},
+			want:     "",
+			wantArgs: nil,
+		},
+		{
+			name: "can combine every filter",
+			filter: book.Filter{
+				GenreIDs:       []string{"g1", "g2"},
+				AuthorIDs:      []string{"a1"},
+				PublisherIDs:   []string{"p1"},
+				Language:       "English",
+				MinPrice:       money.New(200, money.USD),
+				MaxPrice:       money.New(1050, money.USD),
+				MinRating:      4,
+				PublishedFrom:  &publishedFrom,
+				PublishedUntil: &publishedUntil,
+			},
+			want: "and exists (select 1 from books_genres bg where bg.book_id = b.id and bg.genre_id in (?))" +
+				" and exists (select 1 from books_authors ba where ba.book_id = b.id and ba.author_id in (?))" +
+				" and b.publisher_id in (?)" +
+				" and lower(b.language) = lower(?)" +
+				" and b.currency = ? and b.price >= ?" +
+				" and b.currency = ? and b.price <= ?" +
+				" and b.rating >= ?" +
+				" and nullif(b.published_at, '') >= ?" +
+				" and nullif(b.published_at, '') <= ?",
+			wantArgs: []any{
+				[]string{"g1", "g2"},
+				[]string{"a1"},
+				[]string{"p1"},
+				"English",
+				money.USD,
+				int64(200),
+				money.USD,
+				int64(1050),
+				4.0,
+				"2008-01-01 00:00:00",
+				"2010-12-31 23:59:59",
+			},
+		},
+		{
+			name: "can filter price range only",
+			filter: book.Filter{
+				MaxPrice: money.New(500, money.USD),
+			},
+			want:     "and b.currency = ? and b.price <= ?",
+			wantArgs: []any{money.USD, int64(500)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotArgs := filterConditions(tt.filter)
+			if got != tt.want {
+				t.Errorf("filterConditions() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("filterConditions() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestRepo_PaginateAllBooks_filter(t *testing.T) {
+	r := &Repo{dbConn: newCatalogTestConn(t)}
+
+	publishedFrom := time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC)
+	publishedUntil := time.Date(2008, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter book.Filter
+		want   []string
+	}{
+		{
+			name:   "can filter by genre",
+			filter: book.Filter{GenreIDs: []string{"g1"}},
+			want:   []string{"b1", "b2"},
+		},
+		{
+			name:   "can filter by multiple authors",
+			filter: book.Filter{AuthorIDs: []string{"a1", "a2"}},
+			want:   []string{"b1", "b2", "b3"},
+		},
+		{
+			name:   "can combine genre, price and rating filters",
+			filter: book.Filter{GenreIDs: []string{"g1"}, MinPrice: money.New(550, money.USD), MinRating: 4},
+			want:   []string{"b2"},
+		},
+		{
+			name:   "can filter by publisher and language",
+			filter: book.Filter{PublisherIDs: []string{"p2"}, Language: "english"},
+			want:   []string{"b3"},
+		},
+		{
+			name:   "can filter by published date range",
+			filter: book.Filter{PublishedFrom: &publishedFrom, PublishedUntil: &publishedUntil},
+			want:   []string{"b1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.PaginateAllBooks(context.Background(), book.PaginationParam{Filter: tt.filter})
+			if err != nil {
+				t.Errorf("PaginateAllBooks() error = %v", err)
+				return
+			}
+
+			gotIDs := make([]string, 0, len(got.Data))
+			for _, item := range got.Data {
+				gotIDs = append(gotIDs, item.ID)
+			}
+
+			if !reflect.DeepEqual(gotIDs, tt.want) {
+				t.Errorf("PaginateAllBooks() got = %v, want %v", gotIDs, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package book
 
 const (
-	queryGetBookByIDs = `select b.id, title, description, price, isbn, language,edition, pages, publisher_id,  p.name as publisher_name,  published_at, first_published_at, cover_img, rating, b.created_at, b.updated_at 
+	queryGetBookByIDs = `select b.id, title, description, price, currency, isbn, language,edition, pages, publisher_id,  p.name as publisher_name,  published_at, first_published_at, cover_img, rating, stock, weight, b.created_at, b.updated_at 
 								from books b
 								inner join publishers p on p.id = b.publisher_id
 								where b.id in (?) and b.deleted_at is null`
@@ -16,28 +16,72 @@ const (
 								inner join genres g on books_genres.genre_id = g.id
     							where books_genres.book_id in (?)`
 
-	queryPaginateAllBooks = `select b.id, title, description, price, isbn, language,edition, pages, publisher_id, p.name as publisher_name, 
-       							 published_at, first_published_at, cover_img, rating, b.created_at, b.updated_at 
+	// queryPaginateAllBooks is formatted using the sort key, cursor condition, filter conditions and ordering.
+	queryPaginateAllBooks = `select b.id, title, description, price, currency, isbn, language,edition, pages, publisher_id, p.name as publisher_name, 
+       							 published_at, first_published_at, cover_img, rating, stock, weight, b.created_at, b.updated_at, %[1]s as sort_key
 									from books b
 									inner join publishers p on p.id = b.publisher_id
-									where b.deleted_at is null and b.id > ?
-									order by b.id limit ?`
-
-	//queryPaginateBooksSearchResult = `select b.id, title, description, price, isbn, language,edition, pages, publisher_id, p.name as publisher_name,
-	//   							 published_at, first_published_at, cover_img, rating, b.created_at, b.updated_at
-	//								from book_search_index bsi
-	//								inner join books b on b.id = bsi.id
-	//								inner join publishers p on p.id = b.publisher_id
-	//								where book_search_index match ?
-	//								  and b.deleted_at is null
-	//								  and b.id > ?
-	//								order by rank(), b.id limit ?`
-
-	queryPaginateBooksSearchResult = `select b.id, title, description, price, isbn, language,edition, pages, publisher_id, p.name as publisher_name, 
-       							 published_at, first_published_at, cover_img, rating, b.created_at, b.updated_at 
-									from books b
+									where b.deleted_at is null %[2]s %[3]s
+									order by %[4]s limit ?`
+
+	// queryPaginateBooksSearchResult is formatted the same way as queryPaginateAllBooks, the fts5 match query is the first argument.
+	queryPaginateBooksSearchResult = `with matches as (
+										select id, rank from book_search_index where book_search_index match ?
+									)
+									select b.id, title, description, price, currency, isbn, language,edition, pages, publisher_id, p.name as publisher_name,
+       							 published_at, first_published_at, cover_img, rating, stock, weight, b.created_at, b.updated_at, %[1]s as sort_key
+									from matches m
+									inner join books b on b.id = m.id
 									inner join publishers p on p.id = b.publisher_id
-									where b.title like ?
-										and b.deleted_at is null and b.id > ?
-									order by b.title like ?, b.id limit ?`
+									where b.deleted_at is null %[2]s %[3]s
+									order by %[4]s limit ?`
+
+	// the catalog listings are formatted using the cursor condition and ordering direction.
+	queryPaginateAuthors = `select id, name from authors where deleted_at is null %[1]s order by name %[2]s, id %[2]s limit ?`
+
+	queryPaginateGenres = `select id, name from genres where true %[1]s order by name %[2]s, id %[2]s limit ?`
+
+	queryPaginatePublishers = `select id, name from publishers where deleted_at is null %[1]s order by name %[2]s, id %[2]s limit ?`
+
+	queryGetAuthorByID = `select id, name from authors where id = ? and deleted_at is null`
+
+	queryGetGenreByID = `select id, name from genres where id = ?`
+
+	queryGetPublisherByID = `select id, name from publishers where id = ? and deleted_at is null`
+
+	queryCountPublishersByIDs = `select count(*) from publishers where id in (?) and deleted_at is null`
+
+	queryCountAuthorsByIDs = `select count(*) from authors where id in (?) and deleted_at is null`
+
+	queryCountGenresByIDs = `select count(*) from genres where id in (?)`
+
+	queryInsertBook = `insert into books (id, title, description, price, currency, isbn, language, edition, pages, publisher_id, published_at, first_published_at, cover_img, rating, weight, created_at, updated_at)
+					values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	queryUpdateBook = `update books set title = ?, description = ?, price = ?, currency = ?, isbn = ?, language = ?, edition = ?, pages = ?, publisher_id = ?,
+					published_at = ?, first_published_at = ?, cover_img = ?, rating = ?, weight = ?, updated_at = ?
+					where id = ? and deleted_at is null`
+
+	querySoftDeleteBook = `update books set deleted_at = ?, updated_at = ? where id = ? and deleted_at is null`
+
+	queryRestoreBook = `update books set deleted_at = null, updated_at = ? where id = ? and deleted_at is not null`
+
+	queryInsertBookAuthor = `insert into books_authors (id, book_id, author_id) values (?, ?, ?)`
+
+	queryInsertBookGenre = `insert into books_genres (id, book_id, genre_id) values (?, ?, ?)`
+
+	queryDeleteBookAuthors = `delete from books_authors where book_id = ?`
+
+	queryDeleteBookGenres = `delete from books_genres where book_id = ?`
+
+	queryAdjustBookStock = `update books set stock = stock + ?, updated_at = ? where id = ? and deleted_at is null and stock + ? >= 0 returning stock`
+
+	queryGetBookStock = `select stock from books where id = ? and deleted_at is null`
+
+	queryInsertStockAdjustment = `insert into book_stock_adjustments (id, book_id, order_id, user_id, quantity, stock, reason, created_at)
+					values (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// queryPaginateStockAdjustments is formatted using the cursor condition and ordering direction, the newest comes first.
+	queryPaginateStockAdjustments = `select id, book_id, order_id, user_id, quantity, stock, reason, created_at from book_stock_adjustments
+					where book_id = ? %[1]s order by id %[2]s limit ?`
 )
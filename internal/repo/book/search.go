@@ -0,0 +1,23 @@
+package book
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ftsMatchQuery converts the free text search query into fts5 match expression.
+// Every term is quoted to escape the fts5 query syntax, and prefix matched,
+// so "collins hung" matches the books having both of the term in any indexed column.
+func ftsMatchQuery(searchQuery string) string {
+	terms := strings.FieldsFunc(searchQuery, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	matchTerms := make([]string, 0, len(terms))
+	for _, term := range terms {
+		matchTerms = append(matchTerms, fmt.Sprintf("\"%s\"*", strings.ToLower(term)))
+	}
+
+	return strings.Join(matchTerms, " ")
+}
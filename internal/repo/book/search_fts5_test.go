@@ -0,0 +1,118 @@
+//go:build fts5
+
+package book
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func newSearchIndexTestRepo(t *testing.T) (*Repo, *sqlx.DB) {
+	conn := newCatalogTestConn(t)
+
+	// the search index is created after the books exist, thus it needs to be filled by the migration.
+	if err := (migrations.CreateBookSearchIndexTable{Conn: conn}).Up(); err != nil {
+		t.Fatalf("cannot migrate: %s", err)
+	}
+
+	return &Repo{dbConn: conn}, conn
+}
+
+func searchResultIDs(t *testing.T, r *Repo, searchQuery string, param book.PaginationParam) []string {
+	ids, _ := searchResultPage(t, r, searchQuery, param)
+	return ids
+}
+
+func searchResultPage(t *testing.T, r *Repo, searchQuery string, param book.PaginationParam) ([]string, string) {
+	result, err := r.PaginateBookSearch(context.Background(), searchQuery, param)
+	if err != nil {
+		t.Fatalf("PaginateBookSearch() error = %v", err)
+	}
+
+	ids := make([]string, 0, len(result.Data))
+	for _, item := range result.Data {
+		ids = append(ids, item.ID)
+	}
+
+	return ids, result.PageInfo.Next
+}
+
+func TestRepo_PaginateBookSearch_searchIndex(t *testing.T) {
+	r, conn := newSearchIndexTestRepo(t)
+
+	if got := searchResultIDs(t, r, "collins hunger", book.PaginationParam{}); len(got) != 1 || got[0] != "b1" {
+		t.Errorf("search across title and authors got = %v, want [b1]", got)
+	}
+
+	if got := searchResultIDs(t, r, "scholastic", book.PaginationParam{}); len(got) != 2 {
+		t.Errorf("search by publisher got = %v, want 2 books", got)
+	}
+
+	if got := searchResultIDs(t, r, "scholastic", book.PaginationParam{Filter: book.Filter{MaxPrice: money.New(600, money.USD)}}); len(got) != 1 || got[0] != "b1" {
+		t.Errorf("search with filter got = %v, want [b1]", got)
+	}
+
+	// title matches are ranked above the description matches
+	conn.MustExec(`update books set description = 'The sequel of the hunger games.' where id = 'b2'`)
+	if got := searchResultIDs(t, r, "hunger", book.PaginationParam{}); len(got) != 2 || got[0] != "b1" || got[1] != "b2" {
+		t.Errorf("ranked search got = %v, want [b1 b2]", got)
+	}
+
+	_, next := searchResultPage(t, r, "hunger", book.PaginationParam{PerPage: 1})
+	secondPage := searchResultIDs(t, r, "hunger", book.PaginationParam{PerPage: 1, Cursor: next})
+	if len(secondPage) != 1 || secondPage[0] != "b2" {
+		t.Errorf("second page got = %v, want [b2]", secondPage)
+	}
+
+	if got := searchResultIDs(t, r, "hunger", book.PaginationParam{Sort: book.SortPriceDesc}); len(got) != 2 || got[0] != "b2" {
+		t.Errorf("search sorted by price got = %v, want b2 first", got)
+	}
+
+	conn.MustExec(`update authors set name = 'Suzanne Marie Collins' where id = 'a1'`)
+	if got := searchResultIDs(t, r, "marie", book.PaginationParam{}); len(got) != 2 {
+		t.Errorf("search renamed author got = %v, want 2 books", got)
+	}
+
+	conn.MustExec(`insert into books_genres (id, book_id, genre_id) values ('bg4', 'b3', 'g1')`)
+	if got := searchResultIDs(t, r, "dystopia", book.PaginationParam{}); len(got) != 3 {
+		t.Errorf("search attached genre got = %v, want 3 books", got)
+	}
+
+	conn.MustExec(`update books set deleted_at = current_timestamp where id = 'b1'`)
+	if got := searchResultIDs(t, r, "collins", book.PaginationParam{}); len(got) != 1 || got[0] != "b2" {
+		t.Errorf("search soft deleted book got = %v, want [b2]", got)
+	}
+}
+
+func TestRepo_PaginateBookSearch_writes(t *testing.T) {
+	r, _ := newSearchIndexTestRepo(t)
+
+	id, err := r.Create(context.Background(), book.WriteParam{
+		Title:       "Mockingjay",
+		Description: "My name is Katniss Everdeen.",
+		ISBN:        "4",
+		PublisherID: "p1",
+		AuthorIDs:   []string{"a1"},
+		GenreIDs:    []string{"g1"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if got := searchResultIDs(t, r, "mockingjay collins", book.PaginationParam{}); len(got) != 1 || got[0] != id {
+		t.Errorf("search created book got = %v, want [%s]", got, id)
+	}
+
+	if err = r.SoftDelete(context.Background(), id); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+
+	if got := searchResultIDs(t, r, "mockingjay", book.PaginationParam{}); len(got) != 0 {
+		t.Errorf("search soft deleted book got = %v, want none", got)
+	}
+}
@@ -0,0 +1,34 @@
+package book
+
+import "testing"
+
+func Test_ftsMatchQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		searchQuery string
+		want        string
+	}{
+		{
+			name:        "can match every term by prefix",
+			searchQuery: "collins hunger",
+			want:        "\"collins\"* \"hunger\"*",
+		},
+		{
+			name:        "can escape fts5 query syntax",
+			searchQuery: "\"harry\" OR potter* -NEAR(",
+			want:        "\"harry\"* \"or\"* \"potter\"* \"near\"*",
+		},
+		{
+			name:        "can handle empty query",
+			searchQuery: "   ",
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ftsMatchQuery(tt.searchQuery); got != tt.want {
+				t.Errorf("ftsMatchQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package book
+
+import (
+	"fmt"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+)
+
+// sortOrder describes how the books query is ordered, the book id is always used as the tiebreaker
+// to keep the keyset pagination stable. Empty column means the books are ordered by their id only.
+type sortOrder struct {
+	column string
+	desc   bool
+}
+
+// the nullable columns are coalesced, so they can be compared in the cursor condition.
+var sortOrders = map[book.Sort]sortOrder{
+	book.SortDefault:         {},
+	book.SortPrice:           {column: "b.price"},
+	book.SortPriceDesc:       {column: "b.price", desc: true},
+	book.SortRating:          {column: "coalesce(b.rating, 0)"},
+	book.SortRatingDesc:      {column: "coalesce(b.rating, 0)", desc: true},
+	book.SortPublishedAt:     {column: "coalesce(b.published_at, '')"},
+	book.SortPublishedAtDesc: {column: "coalesce(b.published_at, '')", desc: true},
+	book.SortTitle:           {column: "b.title"},
+	book.SortTitleDesc:       {column: "b.title", desc: true},
+}
+
+// relevanceSortOrder orders the search result by the bm25 rank of the search index.
+var relevanceSortOrder = sortOrder{column: "m.rank"}
+
+// sortKey is the selected expression which value is recorded in the cursor.
+func (s sortOrder) sortKey() string {
+	if s.column == "" {
+		return "null"
+	}
+
+	return s.column
+}
+
+func (s sortOrder) orderBy() string {
+	direction := "asc"
+	if s.desc {
+		direction = "desc"
+	}
+
+	if s.column == "" {
+		return fmt.Sprintf("b.id %s", direction)
+	}
+
+	return fmt.Sprintf("%s %s, b.id %s", s.column, direction, direction)
+}
+
+// reversed flips the ordering direction, it is used to fetch the items before a backward cursor.
+func (s sortOrder) reversed() sortOrder {
+	return sortOrder{column: s.column, desc: !s.desc}
+}
+
+// cursorCondition compiles the where clause condition to fetch the books after the cursor.
+func (s sortOrder) cursorCondition(cursor pagination.Cursor) (string, []any) {
+	if cursor.ID == "" {
+		return "", nil
+	}
+
+	operator := ">"
+	if s.desc {
+		operator = "<"
+	}
+
+	if s.column == "" {
+		return fmt.Sprintf("and b.id %s ?", operator), []any{cursor.ID}
+	}
+
+	return fmt.Sprintf("and (%s, b.id) %s (?, ?)", s.column, operator), []any{cursor.Key, cursor.ID}
+}
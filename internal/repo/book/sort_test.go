@@ -0,0 +1,166 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+)
+
+func Test_sortOrder_cursorCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		sort      sortOrder
+		cursor    pagination.Cursor
+		wantOrder string
+		want      string
+		wantArgs  []any
+	}{
+		{
+			name:      "first page has no condition",
+			sort:      sortOrders[book.SortPrice],
+			cursor:    pagination.Cursor{},
+			wantOrder: "b.price asc, b.id asc",
+			want:      "",
+		},
+		{
+			name:      "default sort compares the id only",
+			sort:      sortOrders[book.SortDefault],
+			cursor:    pagination.Cursor{ID: "1"},
+			wantOrder: "b.id asc",
+			want:      "and b.id > ?",
+			wantArgs:  []any{"1"},
+		},
+		{
+			name:      "ascending sort compares the key and id",
+			sort:      sortOrders[book.SortTitle],
+			cursor:    pagination.Cursor{Sort: book.SortTitle, Key: "Catching Fire", ID: "1"},
+			wantOrder: "b.title asc, b.id asc",
+			want:      "and (b.title, b.id) > (?, ?)",
+			wantArgs:  []any{"Catching Fire", "1"},
+		},
+		{
+			name:      "descending sort compares backwards",
+			sort:      sortOrders[book.SortRatingDesc],
+			cursor:    pagination.Cursor{Sort: book.SortRatingDesc, Key: 4.5, ID: "1"},
+			wantOrder: "coalesce(b.rating, 0) desc, b.id desc",
+			want:      "and (coalesce(b.rating, 0), b.id) < (?, ?)",
+			wantArgs:  []any{4.5, "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sort.orderBy(); got != tt.wantOrder {
+				t.Errorf("orderBy() got = %v, want %v", got, tt.wantOrder)
+			}
+
+			got, gotArgs := tt.sort.cursorCondition(tt.cursor)
+			if got != tt.want {
+				t.Errorf("cursorCondition() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("cursorCondition() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestRepo_PaginateAllBooks_sort(t *testing.T) {
+	r := &Repo{dbConn: newCatalogTestConn(t)}
+
+	tests := []struct {
+		name string
+		sort book.Sort
+		want []string
+	}{
+		{
+			name: "can sort by id",
+			sort: book.SortDefault,
+			want: []string{"b1", "b2", "b3"},
+		},
+		{
+			name: "can sort by price descending",
+			sort: book.SortPriceDesc,
+			want: []string{"b3", "b2", "b1"},
+		},
+		{
+			name: "can sort by rating",
+			sort: book.SortRating,
+			want: []string{"b2", "b1", "b3"},
+		},
+		{
+			name: "can sort by published date descending",
+			sort: book.SortPublishedAtDesc,
+			want: []string{"b2", "b1", "b3"},
+		},
+		{
+			name: "can sort by title",
+			sort: book.SortTitle,
+			want: []string{"b2", "b3", "b1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// walk through the pages one book at a time to exercise the cursor, then walk back using the previous cursor.
+			gotIDs := make([]string, 0, len(tt.want))
+			gotPrevIDs := make([]string, 0, len(tt.want))
+			param := book.PaginationParam{PerPage: 1, Sort: tt.sort}
+			for range len(tt.want) {
+				got, err := r.PaginateAllBooks(context.Background(), param)
+				if err != nil {
+					t.Errorf("PaginateAllBooks() error = %v", err)
+					return
+				}
+
+				for _, item := range got.Data {
+					gotIDs = append(gotIDs, item.ID)
+				}
+
+				if !got.PageInfo.HasMore {
+					param.Cursor = got.PageInfo.Prev
+					break
+				}
+
+				param.Cursor = got.PageInfo.Next
+			}
+
+			for param.Cursor != "" {
+				got, err := r.PaginateAllBooks(context.Background(), param)
+				if err != nil {
+					t.Errorf("PaginateAllBooks() error = %v", err)
+					return
+				}
+
+				for _, item := range got.Data {
+					gotPrevIDs = append([]string{item.ID}, gotPrevIDs...)
+				}
+
+				param.Cursor = got.PageInfo.Prev
+			}
+
+			if !reflect.DeepEqual(gotPrevIDs, tt.want[:len(tt.want)-1]) {
+				t.Errorf("PaginateAllBooks() backward got = %v, want %v", gotPrevIDs, tt.want[:len(tt.want)-1])
+			}
+
+			if !reflect.DeepEqual(gotIDs, tt.want) {
+				t.Errorf("PaginateAllBooks() got = %v, want %v", gotIDs, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepo_PaginateAllBooks_invalidSort(t *testing.T) {
+	r := &Repo{dbConn: newCatalogTestConn(t)}
+
+	if _, err := r.PaginateAllBooks(context.Background(), book.PaginationParam{Sort: "pages"}); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("PaginateAllBooks() error = %v, want %v", err, ErrInvalidSort)
+	}
+
+	cursor := pagination.Cursor{Sort: book.SortPrice, Key: 5.09, ID: "b1"}.Encode()
+	if _, err := r.PaginateAllBooks(context.Background(), book.PaginationParam{Sort: book.SortTitle, Cursor: cursor}); !errors.Is(err, pagination.ErrInvalidCursor) {
+		t.Errorf("PaginateAllBooks() error = %v, want %v", err, pagination.ErrInvalidCursor)
+	}
+}
@@ -0,0 +1,147 @@
+package book
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+)
+
+// nullableString stores the empty string as null.
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+// AdjustStock changes the book stock and records the adjustment in a single transaction,
+// the stock is never taken below zero.
+func (r *Repo) AdjustStock(ctx context.Context, param book.StockAdjustParam) (book.StockAdjustment, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return book.StockAdjustment{}, err
+	}
+
+	tx, err := r.dbConn.Beginx()
+	if err != nil {
+		return book.StockAdjustment{}, err
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	var stock int
+	err = tx.GetContext(ctx, &stock, tx.Rebind(queryAdjustBookStock), param.Quantity, now, param.BookID, param.Quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		var available int
+		if err = tx.GetContext(ctx, &available, tx.Rebind(queryGetBookStock), param.BookID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return book.StockAdjustment{}, ErrNotFound
+			}
+
+			return book.StockAdjustment{}, err
+		}
+
+		return book.StockAdjustment{}, &book.InsufficientStockError{
+			BookID:    param.BookID,
+			Requested: -param.Quantity,
+			Available: available,
+		}
+	}
+
+	if err != nil {
+		slog.Error("error adjust book stock", slog.String("error", err.Error()), slog.String("book_id", param.BookID))
+		return book.StockAdjustment{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertStockAdjustment), id.String(), param.BookID, nil,
+		nullableString(param.UserID), param.Quantity, stock, param.Reason, now)
+	if err != nil {
+		slog.Error("error record book stock adjustment", slog.String("error", err.Error()), slog.String("book_id", param.BookID))
+		return book.StockAdjustment{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return book.StockAdjustment{}, err
+	}
+
+	return book.StockAdjustment{
+		ID:        id.String(),
+		BookID:    param.BookID,
+		UserID:    param.UserID,
+		Quantity:  param.Quantity,
+		Stock:     stock,
+		Reason:    param.Reason,
+		CreatedAt: &now,
+	}, nil
+}
+
+// PaginateStockAdjustments lists the stock audit trail of the book, the newest adjustment comes first.
+func (r *Repo) PaginateStockAdjustments(ctx context.Context, bookID string, param book.ListParam) (book.StockAdjustmentsResult, error) {
+	perPage := pagination.Limit(param.PerPage)
+
+	cursor, err := pagination.DecodeCursor(param.Cursor)
+	if err != nil {
+		return book.StockAdjustmentsResult{PageInfo: pagination.PageInfo{PerPage: perPage}}, err
+	}
+
+	direction, operator := "desc", "<"
+	if cursor.Backward {
+		direction, operator = "asc", ">"
+	}
+
+	var cursorCondition string
+	var args = []any{bookID}
+	if cursor.ID != "" {
+		cursorCondition = fmt.Sprintf("and id %s ?", operator)
+		args = append(args, cursor.ID)
+	}
+
+	args = append(args, perPage+1)
+
+	var result []tableStockAdjustment
+
+	query := fmt.Sprintf(queryPaginateStockAdjustments, cursorCondition, direction)
+	err = r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(query), args...)
+	if err != nil {
+		slog.Error("error executing stock adjustments pagination query", slog.String("error", err.Error()))
+		return book.StockAdjustmentsResult{PageInfo: pagination.PageInfo{PerPage: perPage}}, err
+	}
+
+	result, pageInfo := pagination.Paginate(result, cursor, perPage, func(item tableStockAdjustment) pagination.Cursor {
+		return pagination.Cursor{ID: item.ID}
+	})
+
+	adjustments := make([]book.StockAdjustment, 0, len(result))
+	for _, item := range result {
+		var createdAt *time.Time
+		if item.CreatedAt.Valid {
+			createdAt = &item.CreatedAt.Time
+		}
+
+		adjustments = append(adjustments, book.StockAdjustment{
+			ID:        item.ID,
+			BookID:    item.BookID,
+			OrderID:   item.OrderID.String,
+			UserID:    item.UserID.String,
+			Quantity:  item.Quantity,
+			Stock:     item.Stock,
+			Reason:    item.Reason,
+			CreatedAt: createdAt,
+		})
+	}
+
+	return book.StockAdjustmentsResult{
+		Data:     adjustments,
+		PageInfo: pageInfo,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+)
+
+func TestRepo_AdjustStock(t *testing.T) {
+	conn := newCatalogTestConn(t)
+	r := &Repo{dbConn: conn}
+
+	adjustment, err := r.AdjustStock(context.Background(), book.StockAdjustParam{BookID: "b1", UserID: "u1", Quantity: 10, Reason: "restock"})
+	if err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+
+	if adjustment.Stock != 10 || adjustment.Quantity != 10 || adjustment.UserID != "u1" {
+		t.Errorf("AdjustStock() got = %+v, want stock 10", adjustment)
+	}
+
+	_, err = r.AdjustStock(context.Background(), book.StockAdjustParam{BookID: "b1", UserID: "u1", Quantity: -11, Reason: "damaged"})
+	var stockErr *book.InsufficientStockError
+	if !errors.As(err, &stockErr) || stockErr.Requested != 11 || stockErr.Available != 10 {
+		t.Errorf("AdjustStock() error = %v, want insufficient stock of 10", err)
+	}
+
+	if _, err = r.AdjustStock(context.Background(), book.StockAdjustParam{BookID: "b1", UserID: "u1", Quantity: -4, Reason: "damaged"}); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+
+	if _, err = r.AdjustStock(context.Background(), book.StockAdjustParam{BookID: "unknown", Quantity: 1, Reason: "restock"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AdjustStock() unknown book error = %v, want %v", err, ErrNotFound)
+	}
+
+	books, err := r.FindByIDs(context.Background(), []string{"b1"})
+	if err != nil || len(books) != 1 || books[0].Stock != 6 {
+		t.Errorf("FindByIDs() got = %v, err = %v, want stock 6", books, err)
+	}
+
+	result, err := r.PaginateStockAdjustments(context.Background(), "b1", book.ListParam{PerPage: 1})
+	if err != nil {
+		t.Fatalf("PaginateStockAdjustments() error = %v", err)
+	}
+
+	if len(result.Data) != 1 || result.Data[0].Quantity != -4 || result.Data[0].Stock != 6 || !result.PageInfo.HasMore {
+		t.Errorf("PaginateStockAdjustments() got = %+v, want the newest adjustment first", result)
+	}
+
+	next, err := r.PaginateStockAdjustments(context.Background(), "b1", book.ListParam{PerPage: 1, Cursor: result.PageInfo.Next})
+	if err != nil {
+		t.Fatalf("PaginateStockAdjustments() error = %v", err)
+	}
+
+	if len(next.Data) != 1 || next.Data[0].Quantity != 10 || next.Data[0].Reason != "restock" || next.PageInfo.HasMore {
+		t.Errorf("PaginateStockAdjustments() next page got = %+v, want the restock", next)
+	}
+}
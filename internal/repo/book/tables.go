@@ -8,7 +8,8 @@ type tableBook struct {
 	ID               string       `db:"id"`
 	Title            string       `db:"title"`
 	Description      string       `db:"description"`
-	Price            float64      `db:"price"`
+	Price            int64        `db:"price"`
+	Currency         string       `db:"currency"`
 	ISBN             string       `db:"isbn"`
 	PublisherID      string       `db:"publisher_id"`
 	PublisherName    string       `db:"publisher_name"`
@@ -19,8 +20,11 @@ type tableBook struct {
 	FirstPublishedAt sql.NullTime `db:"first_published_at"`
 	CoverImg         string       `db:"cover_img"`
 	Rating           float64      `db:"rating"`
+	Stock            int          `db:"stock"`
+	Weight           int          `db:"weight"`
 	CreatedAt        sql.NullTime `db:"created_at"`
 	UpdatedAt        sql.NullTime `db:"updated_at"`
+	SortKey          any          `db:"sort_key"`
 }
 
 type tableAuthor struct {
@@ -34,3 +38,20 @@ type tableGenre struct {
 	Name   string `db:"name"`
 	BookID string `db:"book_id"`
 }
+
+// tableCatalogItem is the row of authors, genres and publishers listing.
+type tableCatalogItem struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+}
+
+type tableStockAdjustment struct {
+	ID        string         `db:"id"`
+	BookID    string         `db:"book_id"`
+	OrderID   sql.NullString `db:"order_id"`
+	UserID    sql.NullString `db:"user_id"`
+	Quantity  int            `db:"quantity"`
+	Stock     int            `db:"stock"`
+	Reason    string         `db:"reason"`
+	CreatedAt sql.NullTime   `db:"created_at"`
+}
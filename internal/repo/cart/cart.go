@@ -0,0 +1,218 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/cart"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+	Beginx() (*sqlx.Tx, error)
+
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewCartRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+// nullableString stores the empty string as null.
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+// Create creates the cart of the user, empty user id creates the guest cart.
+func (r *Repo) Create(ctx context.Context, userID string) (cart.Cart, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return cart.Cart{}, err
+	}
+
+	now := time.Now()
+
+	_, err = r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryInsertCart), id.String(), nullableString(userID), now, now)
+	if err != nil {
+		slog.Error("error create cart", slog.String("error", err.Error()), slog.String("user_id", userID))
+		return cart.Cart{}, err
+	}
+
+	return cart.Cart{
+		ID:        id.String(),
+		UserID:    userID,
+		Items:     []cart.Item{},
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}, nil
+}
+
+func (r *Repo) FindByID(ctx context.Context, id string) (cart.Cart, error) {
+	return r.findOne(ctx, queryGetCartByID, id)
+}
+
+func (r *Repo) FindByUserID(ctx context.Context, userID string) (cart.Cart, error) {
+	return r.findOne(ctx, queryGetCartByUserID, userID)
+}
+
+// findOne finds the cart along with its items, the item prices are left for the caller.
+func (r *Repo) findOne(ctx context.Context, query string, args ...any) (cart.Cart, error) {
+	var carts []tableCart
+
+	if err := r.dbConn.SelectContext(ctx, &carts, r.dbConn.Rebind(query), args...); err != nil {
+		return cart.Cart{}, err
+	}
+
+	if len(carts) == 0 {
+		return cart.Cart{}, ErrNotFound
+	}
+
+	var items []tableCartItem
+
+	if err := r.dbConn.SelectContext(ctx, &items, r.dbConn.Rebind(queryGetCartItems), carts[0].ID); err != nil {
+		return cart.Cart{}, err
+	}
+
+	result := cart.Cart{
+		ID:     carts[0].ID,
+		UserID: carts[0].UserID.String,
+		Items:  make([]cart.Item, 0, len(items)),
+	}
+
+	if carts[0].CreatedAt.Valid {
+		result.CreatedAt = &carts[0].CreatedAt.Time
+	}
+
+	if carts[0].UpdatedAt.Valid {
+		result.UpdatedAt = &carts[0].UpdatedAt.Time
+	}
+
+	for _, item := range items {
+		result.Items = append(result.Items, cart.Item{
+			BookID:   item.BookID,
+			Quantity: item.Quantity,
+		})
+	}
+
+	return result, nil
+}
+
+// AddItem puts the book into the cart, the quantity is added to the existing one.
+func (r *Repo) AddItem(ctx context.Context, cartID string, param cart.ItemParam) error {
+	return r.upsertItem(ctx, queryAddCartItem, cartID, param)
+}
+
+// SetItem puts the book into the cart, the quantity replaces the existing one.
+func (r *Repo) SetItem(ctx context.Context, cartID string, param cart.ItemParam) error {
+	return r.upsertItem(ctx, querySetCartItem, cartID, param)
+}
+
+func (r *Repo) upsertItem(ctx context.Context, query string, cartID string, param cart.ItemParam) error {
+	tx, err := r.dbConn.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(query), cartID, param.BookID, param.Quantity, now, now); err != nil {
+		slog.Error("error upsert cart item", slog.String("error", err.Error()), slog.String("cart_id", cartID))
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(queryTouchCart), now, cartID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *Repo) RemoveItem(ctx context.Context, cartID string, bookID string) error {
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryDeleteCartItem), cartID, bookID)
+	if err != nil {
+		slog.Error("error remove cart item", slog.String("error", err.Error()), slog.String("cart_id", cartID))
+		return err
+	}
+
+	return nil
+}
+
+// Clear removes every item in the cart, it is used once the cart is checked out.
+func (r *Repo) Clear(ctx context.Context, cartID string) error {
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryDeleteCartItems), cartID)
+	if err != nil {
+		slog.Error("error clear cart", slog.String("error", err.Error()), slog.String("cart_id", cartID))
+		return err
+	}
+
+	return nil
+}
+
+// Merge moves the items of the guest cart into the user cart and removes the guest cart,
+// the quantity of the book which exists in both carts is summed.
+func (r *Repo) Merge(ctx context.Context, guestCartID string, userCartID string) error {
+	tx, err := r.dbConn.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(queryMergeCartItems), userCartID, now, guestCartID); err != nil {
+		slog.Error("error merge cart items", slog.String("error", err.Error()), slog.String("cart_id", guestCartID))
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(queryDeleteCartItems), guestCartID); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(queryDeleteGuestCart), guestCartID); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(queryTouchCart), now, userCartID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
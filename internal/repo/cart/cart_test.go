@@ -0,0 +1,100 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/cart"
+)
+
+func itemQuantities(c cart.Cart) map[string]int {
+	quantities := make(map[string]int)
+	for _, item := range c.Items {
+		quantities[item.BookID] = item.Quantity
+	}
+
+	return quantities
+}
+
+func TestRepo_Items(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateCartsTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	created, err := r.Create(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	_ = r.AddItem(ctx, created.ID, cart.ItemParam{BookID: "1", Quantity: 1})
+	_ = r.AddItem(ctx, created.ID, cart.ItemParam{BookID: "1", Quantity: 2})
+	_ = r.AddItem(ctx, created.ID, cart.ItemParam{BookID: "2", Quantity: 1})
+	_ = r.SetItem(ctx, created.ID, cart.ItemParam{BookID: "2", Quantity: 5})
+	_ = r.AddItem(ctx, created.ID, cart.ItemParam{BookID: "3", Quantity: 1})
+	_ = r.RemoveItem(ctx, created.ID, "3")
+
+	got, err := r.FindByUserID(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+
+	if want := map[string]int{"1": 3, "2": 5}; got.ID != created.ID || !reflect.DeepEqual(itemQuantities(got), want) {
+		t.Errorf("FindByUserID() got = %+v, want %v", got, want)
+	}
+
+	if err = r.Clear(ctx, created.ID); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+
+	got, _ = r.FindByID(ctx, created.ID)
+	if len(got.Items) != 0 {
+		t.Errorf("Clear() items = %+v, want empty", got.Items)
+	}
+
+	if _, err = r.FindByUserID(ctx, "user-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByUserID() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestRepo_Merge(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateCartsTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	guest, _ := r.Create(ctx, "")
+	_ = r.AddItem(ctx, guest.ID, cart.ItemParam{BookID: "1", Quantity: 1})
+	_ = r.AddItem(ctx, guest.ID, cart.ItemParam{BookID: "2", Quantity: 2})
+
+	userCart, _ := r.Create(ctx, "user-1")
+	_ = r.AddItem(ctx, userCart.ID, cart.ItemParam{BookID: "2", Quantity: 1})
+	_ = r.AddItem(ctx, userCart.ID, cart.ItemParam{BookID: "3", Quantity: 4})
+
+	if err := r.Merge(ctx, guest.ID, userCart.ID); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	got, _ := r.FindByID(ctx, userCart.ID)
+	if want := map[string]int{"1": 1, "2": 3, "3": 4}; !reflect.DeepEqual(itemQuantities(got), want) {
+		t.Errorf("Merge() items = %v, want %v", itemQuantities(got), want)
+	}
+
+	if _, err := r.FindByID(ctx, guest.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByID() of the merged guest cart error = %v, want %v", err, ErrNotFound)
+	}
+
+	var guestItems int
+	_ = conn.Get(&guestItems, `select count(*) from cart_items where cart_id = ?`, guest.ID)
+	if guestItems != 0 {
+		t.Errorf("Merge() guest items = %d, want 0", guestItems)
+	}
+}
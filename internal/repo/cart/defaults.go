@@ -0,0 +1,9 @@
+package cart
+
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/cart"
+)
+
+var (
+	ErrNotFound = cart.ErrNotFound
+)
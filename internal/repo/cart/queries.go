@@ -0,0 +1,30 @@
+package cart
+
+const (
+	queryInsertCart = `insert into carts (id, user_id, created_at, updated_at) values (?, ?, ?, ?)`
+
+	queryGetCartByID = `select id, user_id, created_at, updated_at from carts where id = ?`
+
+	queryGetCartByUserID = `select id, user_id, created_at, updated_at from carts where user_id = ?`
+
+	queryGetCartItems = `select cart_id, book_id, quantity, created_at, updated_at from cart_items where cart_id = ? order by created_at, book_id`
+
+	queryAddCartItem = `insert into cart_items (cart_id, book_id, quantity, created_at, updated_at) values (?, ?, ?, ?, ?)
+					on conflict (cart_id, book_id) do update set quantity = cart_items.quantity + excluded.quantity, updated_at = excluded.updated_at`
+
+	querySetCartItem = `insert into cart_items (cart_id, book_id, quantity, created_at, updated_at) values (?, ?, ?, ?, ?)
+					on conflict (cart_id, book_id) do update set quantity = excluded.quantity, updated_at = excluded.updated_at`
+
+	queryDeleteCartItem = `delete from cart_items where cart_id = ? and book_id = ?`
+
+	queryDeleteCartItems = `delete from cart_items where cart_id = ?`
+
+	queryTouchCart = `update carts set updated_at = ? where id = ?`
+
+	// the where clause is required by sqlite to parse the upsert of the select statement.
+	queryMergeCartItems = `insert into cart_items (cart_id, book_id, quantity, created_at, updated_at)
+					select ?, book_id, quantity, created_at, ? from cart_items where cart_id = ? and true
+					on conflict (cart_id, book_id) do update set quantity = cart_items.quantity + excluded.quantity, updated_at = excluded.updated_at`
+
+	queryDeleteGuestCart = `delete from carts where id = ? and user_id is null`
+)
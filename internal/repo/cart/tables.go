@@ -0,0 +1,18 @@
+package cart
+
+import "database/sql"
+
+type tableCart struct {
+	ID        string         `db:"id"`
+	UserID    sql.NullString `db:"user_id"`
+	CreatedAt sql.NullTime   `db:"created_at"`
+	UpdatedAt sql.NullTime   `db:"updated_at"`
+}
+
+type tableCartItem struct {
+	CartID    string       `db:"cart_id"`
+	BookID    string       `db:"book_id"`
+	Quantity  int          `db:"quantity"`
+	CreatedAt sql.NullTime `db:"created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+}
@@ -0,0 +1,9 @@
+package exchangerate
+
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+)
+
+var (
+	ErrNotFound = exchangerate.ErrNotFound
+)
@@ -0,0 +1,135 @@
+package exchangerate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewExchangeRateRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+func exchangeRateFromTable(item tableExchangeRate) (exchangerate.ExchangeRate, error) {
+	rate, err := money.ParseRate(item.Rate)
+	if err != nil {
+		return exchangerate.ExchangeRate{}, err
+	}
+
+	result := exchangerate.ExchangeRate{
+		BaseCurrency: money.Currency(item.BaseCurrency),
+		Currency:     money.Currency(item.Currency),
+		Rate:         rate,
+	}
+
+	if item.CreatedAt.Valid {
+		result.CreatedAt = &item.CreatedAt.Time
+	}
+
+	if item.UpdatedAt.Valid {
+		result.UpdatedAt = &item.UpdatedAt.Time
+	}
+
+	return result, nil
+}
+
+// Upsert sets the rate of the currency, the previous rate of the currency is replaced.
+func (r *Repo) Upsert(ctx context.Context, param exchangerate.ExchangeRate) (exchangerate.ExchangeRate, error) {
+	now := time.Now()
+
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryUpsertExchangeRate), param.BaseCurrency, param.Currency,
+		param.Rate.String(), now, now)
+	if err != nil {
+		slog.Error("error upsert exchange rate", slog.String("error", err.Error()), slog.String("currency", string(param.Currency)))
+		return exchangerate.ExchangeRate{}, err
+	}
+
+	return r.FindByCurrency(ctx, param.BaseCurrency, param.Currency)
+}
+
+func (r *Repo) Delete(ctx context.Context, baseCurrency, currency money.Currency) error {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryDeleteExchangeRate), baseCurrency, currency)
+	if err != nil {
+		slog.Error("error delete exchange rate", slog.String("error", err.Error()), slog.String("currency", string(currency)))
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) FindByCurrency(ctx context.Context, baseCurrency, currency money.Currency) (exchangerate.ExchangeRate, error) {
+	var result tableExchangeRate
+	err := r.dbConn.GetContext(ctx, &result, r.dbConn.Rebind(queryGetExchangeRate), baseCurrency, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return exchangerate.ExchangeRate{}, ErrNotFound
+	}
+
+	if err != nil {
+		return exchangerate.ExchangeRate{}, err
+	}
+
+	return exchangeRateFromTable(result)
+}
+
+func (r *Repo) FindByBaseCurrency(ctx context.Context, baseCurrency money.Currency) ([]exchangerate.ExchangeRate, error) {
+	var result []tableExchangeRate
+	if err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(queryGetExchangeRatesByBaseCurrency), baseCurrency); err != nil {
+		return nil, err
+	}
+
+	rates := make([]exchangerate.ExchangeRate, 0, len(result))
+	for _, item := range result {
+		rate, err := exchangeRateFromTable(item)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
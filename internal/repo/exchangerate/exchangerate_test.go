@@ -0,0 +1,59 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestRepo_ExchangeRates(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateExchangeRatesTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	rate, _ := money.ParseRate("15750.50")
+	created, err := r.Upsert(ctx, exchangerate.ExchangeRate{BaseCurrency: money.USD, Currency: money.IDR, Rate: rate})
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	if created.Currency != money.IDR || created.Rate.String() != "15750.5" || created.UpdatedAt == nil {
+		t.Errorf("Upsert() got = %+v", created)
+	}
+
+	rate, _ = money.ParseRate("16000")
+	updated, err := r.Upsert(ctx, exchangerate.ExchangeRate{BaseCurrency: money.USD, Currency: money.IDR, Rate: rate})
+	if err != nil || updated.Rate.String() != "16000" {
+		t.Errorf("Upsert() of the existing rate got = %+v, %v", updated, err)
+	}
+
+	rate, _ = money.ParseRate("0.92")
+	if _, err = r.Upsert(ctx, exchangerate.ExchangeRate{BaseCurrency: money.USD, Currency: money.EUR, Rate: rate}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	rates, err := r.FindByBaseCurrency(ctx, money.USD)
+	if err != nil || len(rates) != 2 || rates[0].Currency != money.EUR || rates[1].Currency != money.IDR {
+		t.Errorf("FindByBaseCurrency() got = %+v, %v", rates, err)
+	}
+
+	if _, err = r.FindByCurrency(ctx, money.EUR, money.IDR); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByCurrency() of the other base currency error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err = r.Delete(ctx, money.USD, money.IDR); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if err = r.Delete(ctx, money.USD, money.IDR); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() twice error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,14 @@
+package exchangerate
+
+const (
+	queryUpsertExchangeRate = `insert into exchange_rates (base_currency, currency, rate, created_at, updated_at) values (?, ?, ?, ?, ?)
+					on conflict (base_currency, currency) do update set rate = excluded.rate, updated_at = excluded.updated_at`
+
+	queryDeleteExchangeRate = `delete from exchange_rates where base_currency = ? and currency = ?`
+
+	queryGetExchangeRate = `select base_currency, currency, rate, created_at, updated_at from exchange_rates
+					where base_currency = ? and currency = ?`
+
+	queryGetExchangeRatesByBaseCurrency = `select base_currency, currency, rate, created_at, updated_at from exchange_rates
+					where base_currency = ? order by currency`
+)
@@ -0,0 +1,11 @@
+package exchangerate
+
+import "database/sql"
+
+type tableExchangeRate struct {
+	BaseCurrency string       `db:"base_currency"`
+	Currency     string       `db:"currency"`
+	Rate         string       `db:"rate"`
+	CreatedAt    sql.NullTime `db:"created_at"`
+	UpdatedAt    sql.NullTime `db:"updated_at"`
+}
@@ -0,0 +1,7 @@
+package invoice
+
+import "github.com/rendyananta/example-online-book-store/internal/entity/invoice"
+
+var (
+	ErrNotFound = invoice.ErrNotFound
+)
@@ -0,0 +1,95 @@
+package invoice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/invoice"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewInvoiceRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+func invoiceFromTable(item tableInvoice) invoice.Invoice {
+	result := invoice.Invoice{
+		ID:      item.ID,
+		Number:  item.Number,
+		OrderID: item.OrderID,
+	}
+
+	if item.IssuedAt.Valid {
+		result.IssuedAt = &item.IssuedAt.Time
+	}
+
+	return result
+}
+
+func (r *Repo) FindByOrderID(ctx context.Context, orderID string) (invoice.Invoice, error) {
+	var result tableInvoice
+	err := r.dbConn.GetContext(ctx, &result, r.dbConn.Rebind(queryGetInvoiceByOrderID), orderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return invoice.Invoice{}, ErrNotFound
+	}
+
+	if err != nil {
+		return invoice.Invoice{}, err
+	}
+
+	return invoiceFromTable(result), nil
+}
+
+// Create issues the invoice of the order with the next number, the existing invoice of the order is returned
+// when the order is already invoiced.
+func (r *Repo) Create(ctx context.Context, orderID string) (invoice.Invoice, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return invoice.Invoice{}, err
+	}
+
+	_, err = r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryCreateInvoice), id.String(), orderID, time.Now())
+	if err != nil {
+		slog.Error("error create invoice", slog.String("error", err.Error()), slog.String("order_id", orderID))
+		return invoice.Invoice{}, err
+	}
+
+	return r.FindByOrderID(ctx, orderID)
+}
@@ -0,0 +1,48 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+)
+
+func TestRepo_Invoices(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateInvoicesTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	if _, err := r.FindByOrderID(ctx, "order-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByOrderID() error = %v, want %v", err, ErrNotFound)
+	}
+
+	first, err := r.Create(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if first.Number != 1 || first.OrderID != "order-1" || first.ID == "" || first.IssuedAt == nil {
+		t.Errorf("Create() got = %+v", first)
+	}
+
+	second, err := r.Create(ctx, "order-2")
+	if err != nil || second.Number != 2 {
+		t.Errorf("Create() of the next order got = %+v, %v, want number 2", second, err)
+	}
+
+	// the number of the invoiced order is stable.
+	again, err := r.Create(ctx, "order-1")
+	if err != nil || again.Number != 1 || again.ID != first.ID {
+		t.Errorf("Create() of the invoiced order got = %+v, %v, want %+v", again, err, first)
+	}
+
+	found, err := r.FindByOrderID(ctx, "order-2")
+	if err != nil || found.ID != second.ID {
+		t.Errorf("FindByOrderID() got = %+v, %v, want %+v", found, err, second)
+	}
+}
@@ -0,0 +1,11 @@
+package invoice
+
+const (
+	// queryCreateInvoice takes the next number in a single statement, the order which already has
+	// the invoice keeps it.
+	queryCreateInvoice = `insert into invoices (id, number, order_id, issued_at)
+					select ?, coalesce(max(number), 0) + 1, ?, ? from invoices where true
+					on conflict (order_id) do nothing`
+
+	queryGetInvoiceByOrderID = `select id, number, order_id, issued_at from invoices where order_id = ?`
+)
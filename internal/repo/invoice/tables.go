@@ -0,0 +1,10 @@
+package invoice
+
+import "database/sql"
+
+type tableInvoice struct {
+	ID       string       `db:"id"`
+	Number   int64        `db:"number"`
+	OrderID  string       `db:"order_id"`
+	IssuedAt sql.NullTime `db:"issued_at"`
+}
@@ -1,11 +1,14 @@
 package order
 
-import "errors"
+import "github.com/rendyananta/example-online-book-store/internal/entity/order"
 
-const (
-	defaultPaginationLength = 20
+var (
+	ErrNotFound                = order.ErrNotFound
+	ErrInvalidStatusTransition = order.ErrInvalidStatusTransition
 )
 
-var (
-	ErrNotFound = errors.New("not found")
+// the reasons recorded on the stock audit trail of the books taken and given back by the order.
+const (
+	stockReservationReason = "order placed"
+	stockReleaseReason     = "order cancelled"
 )
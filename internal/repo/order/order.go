@@ -2,10 +2,20 @@ package order
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
 	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/internal/repo/outbox"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"log/slog"
 	"sync"
 	"time"
@@ -32,10 +42,12 @@ type Config struct {
 }
 
 type preparedStmt struct {
-	getUserOrdersPagination preparedQueryGetter
-	getOrderDetail          preparedQueryGetter
-	getOrderDetailByUser    preparedQueryGetter
-	getOrderLines           preparedQueryGetter
+	getUserOrdersPagination         preparedQueryGetter
+	getUserOrdersPaginationBackward preparedQueryGetter
+	getOrderDetail                  preparedQueryGetter
+	getOrderDetailByUser            preparedQueryGetter
+	getOrderLines                   preparedQueryGetter
+	getOrderHistories               preparedQueryGetter
 }
 
 type Repo struct {
@@ -73,6 +85,11 @@ func (r *Repo) boot() error {
 		return err
 	}
 
+	r.preparedStmt.getUserOrdersPaginationBackward, err = r.dbConn.Preparex(r.dbConn.Rebind(queryGetUserOrdersPaginationBackward))
+	if err != nil {
+		return err
+	}
+
 	r.preparedStmt.getOrderDetail, err = r.dbConn.Preparex(r.dbConn.Rebind(queryGetOrderDetail))
 	if err != nil {
 		return err
@@ -83,21 +100,38 @@ func (r *Repo) boot() error {
 		return err
 	}
 
+	r.preparedStmt.getOrderHistories, err = r.dbConn.Preparex(r.dbConn.Rebind(queryGetOrderHistories))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (r *Repo) PaginateOrdersByUserID(ctx context.Context, userID string, param order.PaginationParam) (order.PaginationResult, error) {
-	if param.PerPage == 0 {
-		param.PerPage = defaultPaginationLength
+	perPage := pagination.Limit(param.PerPage)
+
+	cursor, err := pagination.DecodeCursor(param.Cursor)
+	if err != nil {
+		return order.PaginationResult{PageInfo: pagination.PageInfo{PerPage: perPage}}, err
+	}
+
+	stmt := r.preparedStmt.getUserOrdersPagination
+	if cursor.Backward {
+		stmt = r.preparedStmt.getUserOrdersPaginationBackward
 	}
 
 	var result []tableOrder
 
-	err := r.preparedStmt.getUserOrdersPagination.SelectContext(ctx, &result, userID, param.LastID, param.PerPage)
+	err = stmt.SelectContext(ctx, &result, userID, cursor.ID, perPage+1)
 	if err != nil {
-		return order.PaginationResult{PerPage: param.PerPage}, err
+		return order.PaginationResult{PageInfo: pagination.PageInfo{PerPage: perPage}}, err
 	}
 
+	result, pageInfo := pagination.Paginate(result, cursor, perPage, func(item tableOrder) pagination.Cursor {
+		return pagination.Cursor{ID: item.ID}
+	})
+
 	var orders = make([]order.Main, 0, len(result))
 
 	for _, item := range result {
@@ -115,40 +149,46 @@ func (r *Repo) PaginateOrdersByUserID(ctx context.Context, userID string, param
 		orders = append(orders, order.Main{
 			ID:         item.ID,
 			UserID:     item.UserID,
-			GrandTotal: item.GrandTotal,
+			GrandTotal: money.New(item.GrandTotal, money.Currency(item.Currency)),
+			Currency:   money.Currency(item.Currency),
 			Status:     item.Status,
 			CreatedAt:  createdAt,
 			UpdatedAt:  UpdatedAt,
 		})
 	}
 
-	if len(orders) == 0 {
-		return order.PaginationResult{
-			Data:    orders,
-			PerPage: param.PerPage,
-		}, nil
-	}
-
 	return order.PaginationResult{
-		Data:    orders,
-		PerPage: param.PerPage,
-		LastID:  orders[len(orders)-1].ID,
+		Data:     orders,
+		PageInfo: pageInfo,
 	}, nil
 }
 
 func (r *Repo) GetDetailByID(ctx context.Context, orderID string) (order.Main, error) {
 	var resMainOrder tableOrder
 	var resOrderLines []tableOrderLine
+	var resOrderHistories []tableOrderHistory
 	var errs []error
+	var errsMu sync.Mutex
+
+	addErr := func(err error) {
+		errsMu.Lock()
+		defer errsMu.Unlock()
+		errs = append(errs, err)
+	}
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		err := r.preparedStmt.getOrderDetail.GetContext(ctx, &resMainOrder, orderID)
+		if errors.Is(err, sql.ErrNoRows) {
+			addErr(ErrNotFound)
+			return
+		}
+
 		if err != nil {
 			slog.Error("error get order detail query", slog.String("error", err.Error()), slog.String("order_id", orderID))
-			errs = append(errs, err)
+			addErr(err)
 		}
 	}()
 
@@ -158,7 +198,17 @@ func (r *Repo) GetDetailByID(ctx context.Context, orderID string) (order.Main, e
 		err := r.preparedStmt.getOrderLines.SelectContext(ctx, &resOrderLines, orderID)
 		if err != nil {
 			slog.Error("error get order lines query", slog.String("error", err.Error()), slog.String("order_id", orderID))
-			errs = append(errs, err)
+			addErr(err)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := r.preparedStmt.getOrderHistories.SelectContext(ctx, &resOrderHistories, orderID)
+		if err != nil {
+			slog.Error("error get order histories query", slog.String("error", err.Error()), slog.String("order_id", orderID))
+			addErr(err)
 		}
 	}()
 
@@ -168,20 +218,33 @@ func (r *Repo) GetDetailByID(ctx context.Context, orderID string) (order.Main, e
 		return order.Main{}, errs[0]
 	}
 
+	exchangeRate, err := money.ParseRate(resMainOrder.ExchangeRate)
+	if err != nil {
+		slog.Error("error decode order exchange rate", slog.String("error", err.Error()), slog.String("order_id", orderID))
+		return order.Main{}, err
+	}
+
 	orderLines := make([]order.Line, 0, len(resOrderLines))
 
+	// the lines are charged in the order currency.
+	currency := money.Currency(resMainOrder.Currency)
 	for _, line := range resOrderLines {
 		orderLines = append(orderLines, order.Line{
 			ID:                line.ID,
 			OrderID:           line.OrderID,
 			LineReferenceType: line.LineReferenceType,
 			LineReferenceID:   line.LineReferenceID,
-			Amount:            line.Amount,
+			Amount:            money.New(line.Amount, currency),
 			Quantity:          line.Quantity,
-			Subtotal:          line.Subtotal,
+			Subtotal:          money.New(line.Subtotal, currency),
 		})
 	}
 
+	var histories []order.History
+	for _, history := range resOrderHistories {
+		histories = append(histories, historyFromTable(history))
+	}
+
 	var createdAt *time.Time
 	var UpdatedAt *time.Time
 
@@ -194,18 +257,187 @@ func (r *Repo) GetDetailByID(ctx context.Context, orderID string) (order.Main, e
 	}
 
 	mainOrder := order.Main{
-		ID:         resMainOrder.ID,
-		UserID:     resMainOrder.UserID,
-		GrandTotal: resMainOrder.GrandTotal,
-		Status:     resMainOrder.Status,
-		Lines:      orderLines,
-		CreatedAt:  createdAt,
-		UpdatedAt:  UpdatedAt,
+		ID:           resMainOrder.ID,
+		UserID:       resMainOrder.UserID,
+		GrandTotal:   money.New(resMainOrder.GrandTotal, currency),
+		Currency:     currency,
+		BaseCurrency: money.Currency(resMainOrder.BaseCurrency),
+		ExchangeRate: exchangeRate,
+		Status:       resMainOrder.Status,
+		Lines:        orderLines,
+		Histories:    histories,
+		CreatedAt:    createdAt,
+		UpdatedAt:    UpdatedAt,
+	}
+
+	if resMainOrder.ShippingAddress.Valid {
+		var shippingAddress address.Address
+		if err := json.Unmarshal([]byte(resMainOrder.ShippingAddress.String), &shippingAddress); err != nil {
+			slog.Error("error decode order shipping address", slog.String("error", err.Error()), slog.String("order_id", orderID))
+		} else {
+			mainOrder.ShippingAddress = &shippingAddress
+		}
 	}
 
 	return mainOrder, nil
 }
 
+func historyFromTable(history tableOrderHistory) order.History {
+	var createdAt *time.Time
+	if history.CreatedAt.Valid {
+		createdAt = &history.CreatedAt.Time
+	}
+
+	return order.History{
+		ID:         history.ID,
+		FromStatus: history.FromStatus.String,
+		Status:     history.Status,
+		Reason:     history.Reason.String,
+		CreatedAt:  createdAt,
+	}
+}
+
+// nullableString stores the empty string as null.
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+// insertHistory records the status transition of the order, the initial status has empty from status.
+func insertHistory(ctx context.Context, tx *sqlx.Tx, orderID string, from order.Status, to order.Status, reason string, now time.Time) (order.History, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return order.History{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertOrderHistory), id.String(), orderID, nullableString(from), to, nullableString(reason), now)
+	if err != nil {
+		slog.Error("error create order history", slog.String("error", err.Error()), slog.String("order_id", orderID))
+		return order.History{}, err
+	}
+
+	return order.History{
+		ID:         id.String(),
+		FromStatus: from,
+		Status:     to,
+		Reason:     reason,
+		CreatedAt:  &now,
+	}, nil
+}
+
+// reserveStock takes the ordered quantity out of the book stock and records it on the stock audit trail,
+// the order is rejected when the stock is not enough.
+func reserveStock(ctx context.Context, tx *sqlx.Tx, orderID string, userID string, line order.Line) error {
+	now := time.Now()
+
+	var stock int
+	err := tx.GetContext(ctx, &stock, tx.Rebind(queryReserveBookStock), line.Quantity, now, line.LineReferenceID, line.Quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		var available int
+		if err = tx.GetContext(ctx, &available, tx.Rebind(queryGetBookStock), line.LineReferenceID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		return &book.InsufficientStockError{
+			BookID:    line.LineReferenceID,
+			Requested: line.Quantity,
+			Available: available,
+		}
+	}
+
+	if err != nil {
+		slog.Error("error reserve book stock", slog.String("error", err.Error()), slog.String("order_id", orderID))
+		return err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertStockAdjustment), id.String(), line.LineReferenceID, orderID, userID,
+		-line.Quantity, stock, stockReservationReason, now)
+
+	return err
+}
+
+// redeemVoucher counts the usage of the voucher applied by the discount line, the voucher which usage limit
+// has been reached meanwhile is rejected.
+func redeemVoucher(ctx context.Context, tx *sqlx.Tx, orderID string, line order.Line) error {
+	result, err := tx.ExecContext(ctx, tx.Rebind(queryRedeemVoucher), time.Now(), line.LineReferenceID)
+	if err != nil {
+		slog.Error("error redeem voucher", slog.String("error", err.Error()), slog.String("order_id", orderID))
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return voucher.ErrUsageLimitReached
+	}
+
+	return nil
+}
+
+// releaseVouchers gives the usage of the vouchers applied by the order back.
+func releaseVouchers(ctx context.Context, tx *sqlx.Tx, orderID string) error {
+	var lines []tableOrderLine
+	if err := tx.SelectContext(ctx, &lines, tx.Rebind(queryGetOrderLinesByType), orderID, order.LineReferenceTypeDiscount); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, tx.Rebind(queryReleaseVoucher), now, line.LineReferenceID); err != nil {
+			slog.Error("error release voucher", slog.String("error", err.Error()), slog.String("order_id", orderID))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseStock gives the books reserved by the order back into the stock, the soft deleted books included.
+func releaseStock(ctx context.Context, tx *sqlx.Tx, orderID string) error {
+	var lines []tableOrderLine
+	if err := tx.SelectContext(ctx, &lines, tx.Rebind(queryGetOrderLinesByType), orderID, order.LineReferenceTypeBook); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, line := range lines {
+		var stock int
+		err := tx.GetContext(ctx, &stock, tx.Rebind(queryReleaseBookStock), line.Quantity, now, line.LineReferenceID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// the book is no longer exist, there is nothing to give back.
+			continue
+		}
+
+		if err != nil {
+			slog.Error("error release book stock", slog.String("error", err.Error()), slog.String("order_id", orderID))
+			return err
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertStockAdjustment), id.String(), line.LineReferenceID, orderID, nil,
+			line.Quantity, stock, stockReleaseReason, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Create inserts the order and its lines, the stock of the ordered books is reserved, the applied vouchers
+// are redeemed and the order.placed event is written in the same transaction.
 func (r *Repo) Create(ctx context.Context, param order.Main) (order.Main, error) {
 	id, err := uuid.NewV7()
 	if err != nil {
@@ -230,7 +462,28 @@ func (r *Repo) Create(ctx context.Context, param order.Main) (order.Main, error)
 		return order.Main{}, err
 	}
 
-	_, err = tx.ExecContext(ctx, queryInsertOrder, id.String(), param.UserID, param.GrandTotal, param.Status, createdAt, updatedAt)
+	// rolling back the committed transaction does nothing.
+	defer tx.Rollback()
+
+	// the order which is not converted is based on its own currency.
+	baseCurrency := param.BaseCurrency
+	if baseCurrency == "" {
+		baseCurrency = param.GrandTotal.Currency()
+	}
+
+	var shippingAddressID, shippingAddress any
+	if param.ShippingAddress != nil {
+		snapshot, err := json.Marshal(param.ShippingAddress)
+		if err != nil {
+			return order.Main{}, err
+		}
+
+		shippingAddressID = param.ShippingAddress.ID
+		shippingAddress = string(snapshot)
+	}
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertOrder), id.String(), param.UserID, param.GrandTotal.Amount(), param.GrandTotal.Currency(),
+		baseCurrency, param.ExchangeRate.String(), param.Status, shippingAddressID, shippingAddress, createdAt, updatedAt)
 	if err != nil {
 		return order.Main{}, err
 	}
@@ -238,32 +491,113 @@ func (r *Repo) Create(ctx context.Context, param order.Main) (order.Main, error)
 	for _, line := range param.Lines {
 		lineID, err := uuid.NewV7()
 		if err != nil {
-			continue
+			return order.Main{}, err
 		}
 
-		_, err = tx.ExecContext(ctx, queryInsertOrderLine, lineID.String(), id.String(), line.LineReferenceType, line.LineReferenceID, line.Amount, line.Quantity, line.Subtotal)
+		_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertOrderLine), lineID.String(), id.String(), line.LineReferenceType, line.LineReferenceID, line.Amount.Amount(), line.Quantity, line.Subtotal.Amount())
 		if err != nil {
 			slog.Error("error create order line", slog.String("error", err.Error()), slog.String("order_id", id.String()))
-			continue
+			return order.Main{}, err
 		}
-	}
 
-	err = tx.Commit()
-	if err != nil {
-		if err = tx.Rollback(); err != nil {
+		switch line.LineReferenceType {
+		case order.LineReferenceTypeBook:
+			err = reserveStock(ctx, tx, id.String(), param.UserID, line)
+		case order.LineReferenceTypeDiscount:
+			err = redeemVoucher(ctx, tx, id.String(), line)
+		}
+
+		if err != nil {
 			return order.Main{}, err
 		}
+	}
 
+	history, err := insertHistory(ctx, tx, id.String(), "", param.Status, "", createdAt)
+	if err != nil {
 		return order.Main{}, err
 	}
 
-	return order.Main{
-		ID:         id.String(),
-		UserID:     param.UserID,
-		GrandTotal: param.GrandTotal,
+	created := order.Main{
+		ID:              id.String(),
+		UserID:          param.UserID,
+		GrandTotal:      param.GrandTotal,
+		Currency:        param.GrandTotal.Currency(),
+		BaseCurrency:    baseCurrency,
+		ExchangeRate:    param.ExchangeRate,
+		Status:          param.Status,
+		Lines:           param.Lines,
+		Histories:       []order.History{history},
+		ShippingAddress: param.ShippingAddress,
+		CreatedAt:       &createdAt,
+		UpdatedAt:       &updatedAt,
+	}
+
+	if err = outbox.Write(ctx, tx, event.TypeOrderPlaced, created.ID, created); err != nil {
+		slog.Error("error write order placed event", slog.String("error", err.Error()), slog.String("order_id", created.ID))
+		return order.Main{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return order.Main{}, err
+	}
+
+	return created, nil
+}
+
+// UpdateStatus moves the order from the given status and records the transition, the order which status
+// has been changed meanwhile is rejected. The stock reserved by the cancelled order is released, and
+// the order.status_changed event is written in the same transaction.
+func (r *Repo) UpdateStatus(ctx context.Context, from order.Status, param order.TransitionParam) (order.History, error) {
+	tx, err := r.dbConn.Beginx()
+	if err != nil {
+		return order.History{}, err
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	result, err := tx.ExecContext(ctx, tx.Rebind(queryUpdateOrderStatus), param.Status, now, param.OrderID, from)
+	if err != nil {
+		slog.Error("error update order status", slog.String("error", err.Error()), slog.String("order_id", param.OrderID))
+		return order.History{}, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return order.History{}, ErrInvalidStatusTransition
+	}
+
+	// the order refunded after it is shipped keeps its stock, the books are no longer in the store.
+	if order.ReleasesReservation(from, param.Status) {
+		if err = releaseStock(ctx, tx, param.OrderID); err != nil {
+			return order.History{}, err
+		}
+
+		if err = releaseVouchers(ctx, tx, param.OrderID); err != nil {
+			return order.History{}, err
+		}
+	}
+
+	history, err := insertHistory(ctx, tx, param.OrderID, from, param.Status, param.Reason, now)
+	if err != nil {
+		return order.History{}, err
+	}
+
+	err = outbox.Write(ctx, tx, event.TypeOrderStatusChanged, param.OrderID, event.OrderStatusChanged{
+		OrderID:    param.OrderID,
+		FromStatus: from,
 		Status:     param.Status,
-		Lines:      param.Lines,
-		CreatedAt:  &createdAt,
-		UpdatedAt:  &updatedAt,
-	}, nil
+		Reason:     param.Reason,
+		ChangedAt:  &now,
+	})
+	if err != nil {
+		slog.Error("error write order status changed event", slog.String("error", err.Error()), slog.String("order_id", param.OrderID))
+		return order.History{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return order.History{}, err
+	}
+
+	return history, nil
 }
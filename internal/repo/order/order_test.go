@@ -3,15 +3,25 @@ package order
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/jmoiron/sqlx"
 	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
 	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"reflect"
 	"testing"
 )
 
 func TestRepo_GetDetailByID(t *testing.T) {
+	identityRate, _ := money.ParseRate("1")
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -44,6 +54,7 @@ func TestRepo_GetDetailByID(t *testing.T) {
 					getUserOrdersPagination: preparedStmtMock,
 					getOrderDetail:          preparedStmtMock,
 					getOrderLines:           preparedStmtMock,
+					getOrderHistories:       preparedStmtMock,
 				},
 			},
 			args: args{
@@ -54,12 +65,15 @@ func TestRepo_GetDetailByID(t *testing.T) {
 				var result tableOrder
 				preparedStmtMock.EXPECT().GetContext(context.Background(), &result, "1").Return(nil).
 					SetArg(1, tableOrder{
-						ID:         "1",
-						UserID:     "1",
-						GrandTotal: 2.4,
-						Status:     order.StatusDone,
-						CreatedAt:  sql.NullTime{},
-						UpdatedAt:  sql.NullTime{},
+						ID:           "1",
+						UserID:       "1",
+						GrandTotal:   240,
+						Currency:     "USD",
+						BaseCurrency: "USD",
+						ExchangeRate: "1",
+						Status:       order.StatusPendingPayment,
+						CreatedAt:    sql.NullTime{},
+						UpdatedAt:    sql.NullTime{},
 					})
 
 				var orderLines []tableOrderLine
@@ -70,26 +84,44 @@ func TestRepo_GetDetailByID(t *testing.T) {
 							OrderID:           "1",
 							LineReferenceType: "book",
 							LineReferenceID:   "1",
-							Amount:            4.5,
+							Amount:            450,
 							Quantity:          2,
-							Subtotal:          9,
+							Subtotal:          900,
+						},
+					})
+
+				var histories []tableOrderHistory
+				preparedStmtMock.EXPECT().SelectContext(context.Background(), &histories, "1").Return(nil).
+					SetArg(1, []tableOrderHistory{
+						{
+							ID:     "1",
+							Status: order.StatusPendingPayment,
 						},
 					})
 			},
 			want: order.Main{
-				ID:         "1",
-				UserID:     "1",
-				GrandTotal: 2.4,
-				Status:     order.StatusDone,
+				ID:           "1",
+				UserID:       "1",
+				GrandTotal:   money.New(240, money.USD),
+				Currency:     money.USD,
+				BaseCurrency: money.USD,
+				ExchangeRate: identityRate,
+				Status:       order.StatusPendingPayment,
 				Lines: []order.Line{
 					{
 						ID:                "1",
 						OrderID:           "1",
 						LineReferenceType: "book",
 						LineReferenceID:   "1",
-						Amount:            4.5,
+						Amount:            money.New(450, money.USD),
 						Quantity:          2,
-						Subtotal:          9,
+						Subtotal:          money.New(900, money.USD),
+					},
+				},
+				Histories: []order.History{
+					{
+						ID:     "1",
+						Status: order.StatusPendingPayment,
 					},
 				},
 			},
@@ -104,6 +136,7 @@ func TestRepo_GetDetailByID(t *testing.T) {
 					getUserOrdersPagination: preparedStmtMock,
 					getOrderDetail:          preparedStmtMock,
 					getOrderLines:           preparedStmtMock,
+					getOrderHistories:       preparedStmtMock,
 				},
 			},
 			args: args{
@@ -114,16 +147,22 @@ func TestRepo_GetDetailByID(t *testing.T) {
 				var result tableOrder
 				preparedStmtMock.EXPECT().GetContext(context.Background(), &result, "1").Return(nil).
 					SetArg(1, tableOrder{
-						ID:         "1",
-						UserID:     "1",
-						GrandTotal: 2.4,
-						Status:     order.StatusDone,
-						CreatedAt:  sql.NullTime{},
-						UpdatedAt:  sql.NullTime{},
+						ID:           "1",
+						UserID:       "1",
+						GrandTotal:   240,
+						Currency:     "USD",
+						BaseCurrency: "USD",
+						ExchangeRate: "1",
+						Status:       order.StatusPendingPayment,
+						CreatedAt:    sql.NullTime{},
+						UpdatedAt:    sql.NullTime{},
 					})
 
 				var orderLines []tableOrderLine
 				preparedStmtMock.EXPECT().SelectContext(context.Background(), &orderLines, "1").Return(sql.ErrConnDone)
+
+				var histories []tableOrderHistory
+				preparedStmtMock.EXPECT().SelectContext(context.Background(), &histories, "1").Return(nil)
 			},
 			want:    order.Main{},
 			wantErr: true,
@@ -194,21 +233,23 @@ func TestRepo_PaginateOrdersByUserID(t *testing.T) {
 			},
 			beforeTest: func() {
 				var result []tableOrder
-				preparedStmtMock.EXPECT().SelectContext(context.Background(), &result, "1", "", 2).Return(nil).
+				preparedStmtMock.EXPECT().SelectContext(context.Background(), &result, "1", "", 3).Return(nil).
 					SetArg(1, []tableOrder{
 						{
 							ID:         "1",
 							UserID:     "1",
-							GrandTotal: 2.4,
-							Status:     order.StatusDone,
+							GrandTotal: 240,
+							Currency:   "USD",
+							Status:     order.StatusPendingPayment,
 							CreatedAt:  sql.NullTime{},
 							UpdatedAt:  sql.NullTime{},
 						},
 						{
 							ID:         "2",
 							UserID:     "1",
-							GrandTotal: 3.4,
-							Status:     order.StatusDone,
+							GrandTotal: 340,
+							Currency:   "USD",
+							Status:     order.StatusPendingPayment,
 							CreatedAt:  sql.NullTime{},
 							UpdatedAt:  sql.NullTime{},
 						},
@@ -219,18 +260,81 @@ func TestRepo_PaginateOrdersByUserID(t *testing.T) {
 					{
 						ID:         "1",
 						UserID:     "1",
-						GrandTotal: 2.4,
-						Status:     order.StatusDone,
+						GrandTotal: money.New(240, money.USD),
+						Currency:   money.USD,
+						Status:     order.StatusPendingPayment,
 					},
 					{
 						ID:         "2",
 						UserID:     "1",
-						GrandTotal: 3.4,
-						Status:     order.StatusDone,
+						GrandTotal: money.New(340, money.USD),
+						Currency:   money.USD,
+						Status:     order.StatusPendingPayment,
 					},
 				},
-				PerPage: 2,
-				LastID:  "2",
+				PageInfo: pagination.PageInfo{PerPage: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "can handle get previous orders page",
+			fields: fields{
+				cfg:    Config{},
+				dbConn: dbConnMock,
+				preparedStmt: preparedStmt{
+					getUserOrdersPaginationBackward: preparedStmtMock,
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "1",
+				param: order.PaginationParam{
+					PerPage: 2,
+					Cursor:  pagination.Cursor{ID: "3", Backward: true}.Encode(),
+				},
+			},
+			beforeTest: func() {
+				var result []tableOrder
+				preparedStmtMock.EXPECT().SelectContext(context.Background(), &result, "1", "3", 3).Return(nil).
+					SetArg(1, []tableOrder{
+						{
+							ID:         "2",
+							UserID:     "1",
+							GrandTotal: 340,
+							Currency:   "USD",
+							Status:     order.StatusPendingPayment,
+						},
+						{
+							ID:         "1",
+							UserID:     "1",
+							GrandTotal: 240,
+							Currency:   "USD",
+							Status:     order.StatusPendingPayment,
+						},
+					})
+			},
+			want: order.PaginationResult{
+				Data: []order.Main{
+					{
+						ID:         "1",
+						UserID:     "1",
+						GrandTotal: money.New(240, money.USD),
+						Currency:   money.USD,
+						Status:     order.StatusPendingPayment,
+					},
+					{
+						ID:         "2",
+						UserID:     "1",
+						GrandTotal: money.New(340, money.USD),
+						Currency:   money.USD,
+						Status:     order.StatusPendingPayment,
+					},
+				},
+				PageInfo: pagination.PageInfo{
+					PerPage: 2,
+					Next:    pagination.Cursor{ID: "2"}.Encode(),
+					HasMore: true,
+				},
 			},
 			wantErr: false,
 		},
@@ -252,12 +356,11 @@ func TestRepo_PaginateOrdersByUserID(t *testing.T) {
 			},
 			beforeTest: func() {
 				var result []tableOrder
-				preparedStmtMock.EXPECT().SelectContext(context.Background(), &result, "1", "", 2).Return(sql.ErrConnDone)
+				preparedStmtMock.EXPECT().SelectContext(context.Background(), &result, "1", "", 3).Return(sql.ErrConnDone)
 			},
 			want: order.PaginationResult{
-				Data:    nil,
-				PerPage: 2,
-				LastID:  "",
+				Data:     nil,
+				PageInfo: pagination.PageInfo{PerPage: 2},
 			},
 			wantErr: true,
 		},
@@ -312,11 +415,17 @@ func TestRepo_boot(t *testing.T) {
 				dbConnMock.EXPECT().Rebind(queryGetUserOrdersPagination).Return(queryGetUserOrdersPagination)
 				dbConnMock.EXPECT().Preparex(queryGetUserOrdersPagination).Return(&sqlx.Stmt{}, nil)
 
+				dbConnMock.EXPECT().Rebind(queryGetUserOrdersPaginationBackward).Return(queryGetUserOrdersPaginationBackward)
+				dbConnMock.EXPECT().Preparex(queryGetUserOrdersPaginationBackward).Return(&sqlx.Stmt{}, nil)
+
 				dbConnMock.EXPECT().Rebind(queryGetOrderDetail).Return(queryGetOrderDetail)
 				dbConnMock.EXPECT().Preparex(queryGetOrderDetail).Return(&sqlx.Stmt{}, nil)
 
 				dbConnMock.EXPECT().Rebind(queryGetOrderLines).Return(queryGetOrderLines)
 				dbConnMock.EXPECT().Preparex(queryGetOrderLines).Return(&sqlx.Stmt{}, nil)
+
+				dbConnMock.EXPECT().Rebind(queryGetOrderHistories).Return(queryGetOrderHistories)
+				dbConnMock.EXPECT().Preparex(queryGetOrderHistories).Return(&sqlx.Stmt{}, nil)
 			},
 			wantErr: false,
 		},
@@ -331,6 +440,9 @@ func TestRepo_boot(t *testing.T) {
 				dbConnMock.EXPECT().Rebind(queryGetUserOrdersPagination).Return(queryGetUserOrdersPagination)
 				dbConnMock.EXPECT().Preparex(queryGetUserOrdersPagination).Return(&sqlx.Stmt{}, nil)
 
+				dbConnMock.EXPECT().Rebind(queryGetUserOrdersPaginationBackward).Return(queryGetUserOrdersPaginationBackward)
+				dbConnMock.EXPECT().Preparex(queryGetUserOrdersPaginationBackward).Return(&sqlx.Stmt{}, nil)
+
 				dbConnMock.EXPECT().Rebind(queryGetOrderDetail).Return(queryGetOrderDetail)
 				dbConnMock.EXPECT().Preparex(queryGetOrderDetail).Return(&sqlx.Stmt{}, nil)
 
@@ -364,8 +476,15 @@ func TestRepo_CreateOrder(t *testing.T) {
 	defer ctrl.Finish()
 
 	dbConnMock, _ := sqlx.Open("sqlite3", ":memory:")
+	// every connection opens its own in memory database.
+	dbConnMock.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: dbConnMock}.Up()
 	_ = migrations.CreateOrdersTable{Conn: dbConnMock}.Up()
 	_ = migrations.CreateOrderLinesTable{Conn: dbConnMock}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: dbConnMock}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: dbConnMock}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: dbConnMock}.Up()
+	dbConnMock.MustExec(`insert into books (id, title, description, price, isbn, publisher_id, stock) values ('2', 'Book', 'desc', 120, '1', 'p1', 3)`)
 
 	type fields struct {
 		cfg          Config
@@ -383,6 +502,7 @@ func TestRepo_CreateOrder(t *testing.T) {
 		beforeTest func(repo *Repo)
 		want       order.Main
 		wantErr    bool
+		wantStock  int
 	}{
 		{
 			name: "can create order",
@@ -395,15 +515,15 @@ func TestRepo_CreateOrder(t *testing.T) {
 				ctx: context.Background(),
 				param: order.Main{
 					UserID:     "1",
-					GrandTotal: 2.4,
-					Status:     order.StatusDone,
+					GrandTotal: money.New(240, money.USD),
+					Status:     order.StatusPendingPayment,
 					Lines: []order.Line{
 						{
 							LineReferenceType: order.LineReferenceTypeBook,
 							LineReferenceID:   "2",
-							Amount:            1.2,
+							Amount:            money.New(120, money.USD),
 							Quantity:          2,
-							Subtotal:          2.4,
+							Subtotal:          money.New(240, money.USD),
 						},
 					},
 				},
@@ -411,20 +531,51 @@ func TestRepo_CreateOrder(t *testing.T) {
 			beforeTest: func(repo *Repo) {
 			},
 			want: order.Main{
-				ID:         "",
-				UserID:     "1",
-				GrandTotal: 2.4,
-				Status:     order.StatusDone,
+				ID:           "",
+				UserID:       "1",
+				GrandTotal:   money.New(240, money.USD),
+				Currency:     money.USD,
+				BaseCurrency: money.USD,
+				Status:       order.StatusPendingPayment,
 				Lines: []order.Line{
 					{
 						LineReferenceType: order.LineReferenceTypeBook,
 						LineReferenceID:   "2",
-						Amount:            1.2,
+						Amount:            money.New(120, money.USD),
 						Quantity:          2,
-						Subtotal:          2.4,
+						Subtotal:          money.New(240, money.USD),
+					},
+				},
+			},
+			wantStock: 1,
+		},
+		{
+			name: "can reject order exceeding the stock",
+			fields: fields{
+				cfg:          Config{},
+				dbConn:       dbConnMock,
+				preparedStmt: preparedStmt{},
+			},
+			args: args{
+				ctx: context.Background(),
+				param: order.Main{
+					UserID:     "1",
+					GrandTotal: money.New(240, money.USD),
+					Status:     order.StatusPendingPayment,
+					Lines: []order.Line{
+						{
+							LineReferenceType: order.LineReferenceTypeBook,
+							LineReferenceID:   "2",
+							Amount:            money.New(120, money.USD),
+							Quantity:          2,
+							Subtotal:          money.New(240, money.USD),
+						},
 					},
 				},
 			},
+			want:      order.Main{},
+			wantErr:   true,
+			wantStock: 1,
 		},
 	}
 	for _, tt := range tests {
@@ -452,9 +603,374 @@ func TestRepo_CreateOrder(t *testing.T) {
 				got.Lines[i].ID = ""
 			}
 
+			if len(got.Histories) > 0 {
+				if got.Histories[0].Status != tt.args.param.Status || got.Histories[0].FromStatus != "" {
+					t.Errorf("Create() histories = %v, want the initial status", got.Histories)
+				}
+
+				got.Histories = nil
+			}
+
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Create() got = %v, want %v", got, tt.want)
 			}
+
+			var stock int
+			_ = dbConnMock.Get(&stock, `select stock from books where id = '2'`)
+			if stock != tt.wantStock {
+				t.Errorf("Create() stock = %v, want %v", stock, tt.wantStock)
+			}
 		})
 	}
 }
+
+func TestRepo_CreateOrder_insufficientStock(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: conn}.Up()
+	_ = migrations.CreateOrdersTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderLinesTable{Conn: conn}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: conn}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+	conn.MustExec(`insert into books (id, title, description, price, isbn, publisher_id, stock) values
+		('1', 'Book 1', 'desc', 120, '1', 'p1', 5), ('2', 'Book 2', 'desc', 120, '2', 'p1', 1)`)
+
+	r := &Repo{dbConn: conn}
+
+	_, err := r.Create(context.Background(), order.Main{
+		UserID: "1",
+		Status: order.StatusPendingPayment,
+		Lines: []order.Line{
+			{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "1", Quantity: 2},
+			{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "2", Quantity: 3},
+		},
+	})
+
+	var stockErr *book.InsufficientStockError
+	if !errors.As(err, &stockErr) || !errors.Is(err, book.ErrInsufficientStock) {
+		t.Fatalf("Create() error = %v, want InsufficientStockError", err)
+	}
+
+	want := book.InsufficientStockError{BookID: "2", Requested: 3, Available: 1}
+	if *stockErr != want {
+		t.Errorf("Create() error = %+v, want %+v", *stockErr, want)
+	}
+
+	// the stock taken by the first line is released along with the rejected order.
+	var stock, orders, adjustments, events int
+	_ = conn.Get(&stock, `select stock from books where id = '1'`)
+	_ = conn.Get(&orders, `select count(*) from orders`)
+	_ = conn.Get(&adjustments, `select count(*) from book_stock_adjustments`)
+	_ = conn.Get(&events, `select count(*) from outbox_events`)
+	if stock != 5 || orders != 0 || adjustments != 0 || events != 0 {
+		t.Errorf("Create() stock = %d, orders = %d, adjustments = %d, events = %d, want 5, 0, 0, 0", stock, orders, adjustments, events)
+	}
+}
+
+func TestRepo_UpdateStatus(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: conn}.Up()
+	_ = migrations.CreateOrdersTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderLinesTable{Conn: conn}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: conn}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+
+	created, err := r.Create(context.Background(), order.Main{UserID: "1", Status: order.StatusPendingPayment})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	history, err := r.UpdateStatus(context.Background(), order.StatusPendingPayment, order.TransitionParam{
+		OrderID: created.ID,
+		Status:  order.StatusPaid,
+		Reason:  "paid by transfer",
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+
+	if history.FromStatus != order.StatusPendingPayment || history.Status != order.StatusPaid || history.Reason != "paid by transfer" {
+		t.Errorf("UpdateStatus() got = %+v, want pending_payment -> paid", history)
+	}
+
+	// the order is no longer pending the payment.
+	_, err = r.UpdateStatus(context.Background(), order.StatusPendingPayment, order.TransitionParam{
+		OrderID: created.ID,
+		Status:  order.StatusCancelled,
+	})
+	if !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("UpdateStatus() error = %v, want %v", err, ErrInvalidStatusTransition)
+	}
+
+	var status string
+	var histories int
+	_ = conn.Get(&status, `select status from orders where id = ?`, created.ID)
+	_ = conn.Get(&histories, `select count(*) from order_status_histories where order_id = ?`, created.ID)
+	if status != order.StatusPaid || histories != 2 {
+		t.Errorf("UpdateStatus() status = %s, histories = %d, want paid with 2 histories", status, histories)
+	}
+
+	// the placed order and the accepted transition are written into the outbox, the rejected one is not.
+	var events []struct {
+		Type    string `db:"type"`
+		Payload string `db:"payload"`
+	}
+	_ = conn.Select(&events, `select type, payload from outbox_events where aggregate_id = ? order by id`, created.ID)
+	if len(events) != 2 || events[0].Type != event.TypeOrderPlaced || events[1].Type != event.TypeOrderStatusChanged {
+		t.Fatalf("UpdateStatus() events = %+v, want order.placed and order.status_changed", events)
+	}
+
+	var changed event.OrderStatusChanged
+	_ = json.Unmarshal([]byte(events[1].Payload), &changed)
+	if changed.OrderID != created.ID || changed.FromStatus != order.StatusPendingPayment || changed.Status != order.StatusPaid || changed.Reason != "paid by transfer" {
+		t.Errorf("UpdateStatus() event payload = %+v", changed)
+	}
+}
+
+func TestRepo_UpdateStatus_cancel(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: conn}.Up()
+	_ = migrations.CreateOrdersTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderLinesTable{Conn: conn}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: conn}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+	conn.MustExec(`insert into books (id, title, description, price, isbn, publisher_id, stock) values ('1', 'Book 1', 'desc', 120, '1', 'p1', 5)`)
+
+	r := &Repo{dbConn: conn}
+
+	created, err := r.Create(context.Background(), order.Main{
+		UserID: "1",
+		Status: order.StatusPendingPayment,
+		Lines: []order.Line{
+			{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "1", Quantity: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	history, err := r.UpdateStatus(context.Background(), order.StatusPendingPayment, order.TransitionParam{
+		OrderID: created.ID,
+		Status:  order.StatusCancelled,
+		Reason:  "changed my mind",
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+
+	if history.Status != order.StatusCancelled || history.Reason != "changed my mind" {
+		t.Errorf("UpdateStatus() got = %+v, want cancelled with the reason", history)
+	}
+
+	var stock int
+	var released int
+	_ = conn.Get(&stock, `select stock from books where id = '1'`)
+	_ = conn.Get(&released, `select quantity from book_stock_adjustments where order_id = ? and reason = ?`, created.ID, stockReleaseReason)
+	if stock != 5 || released != 2 {
+		t.Errorf("UpdateStatus() stock = %d, released = %d, want 5 and 2", stock, released)
+	}
+}
+
+func TestRepo_UpdateStatus_refund(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: conn}.Up()
+	_ = migrations.CreateOrdersTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderLinesTable{Conn: conn}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: conn}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+	_ = migrations.CreateVouchersTable{Conn: conn}.Up()
+	conn.MustExec(`insert into books (id, title, description, price, isbn, publisher_id, stock) values ('1', 'Book 1', 'desc', 1000, '1', 'p1', 5)`)
+	conn.MustExec(`insert into vouchers (id, code, discount_type, amount, usage_limit) values ('v1', 'ONCE', 'fixed', 200, 1)`)
+
+	r := &Repo{dbConn: conn}
+
+	created, err := r.Create(context.Background(), order.Main{
+		UserID:     "1",
+		Status:     order.StatusPendingPayment,
+		GrandTotal: money.New(1800, money.USD),
+		Lines: []order.Line{
+			{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "1", Amount: money.New(1000, money.USD), Quantity: 2, Subtotal: money.New(2000, money.USD)},
+			{LineReferenceType: order.LineReferenceTypeDiscount, LineReferenceID: "v1", Amount: money.New(-200, money.USD), Quantity: 1, Subtotal: money.New(-200, money.USD)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	for _, transition := range []order.TransitionParam{
+		{OrderID: created.ID, Status: order.StatusPaid},
+		{OrderID: created.ID, Status: order.StatusRefunded, Reason: "out of print"},
+	} {
+		from := order.StatusPendingPayment
+		if transition.Status == order.StatusRefunded {
+			from = order.StatusPaid
+		}
+
+		if _, err = r.UpdateStatus(context.Background(), from, transition); err != nil {
+			t.Fatalf("UpdateStatus() to %s error = %v", transition.Status, err)
+		}
+	}
+
+	var stock, released, usedCount int
+	_ = conn.Get(&stock, `select stock from books where id = '1'`)
+	_ = conn.Get(&released, `select quantity from book_stock_adjustments where order_id = ? and reason = ?`, created.ID, stockReleaseReason)
+	_ = conn.Get(&usedCount, `select used_count from vouchers where id = 'v1'`)
+	if stock != 5 || released != 2 || usedCount != 0 {
+		t.Errorf("UpdateStatus() stock = %d, released = %d, used count = %d, want 5, 2 and 0", stock, released, usedCount)
+	}
+}
+
+func TestRepo_CreateOrder_voucher(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: conn}.Up()
+	_ = migrations.CreateOrdersTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderLinesTable{Conn: conn}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: conn}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+	_ = migrations.CreateVouchersTable{Conn: conn}.Up()
+	conn.MustExec(`insert into books (id, title, description, price, isbn, publisher_id, stock) values ('1', 'Book 1', 'desc', 1000, '1', 'p1', 5)`)
+	conn.MustExec(`insert into vouchers (id, code, discount_type, amount, usage_limit) values ('v1', 'ONCE', 'fixed', 200, 1)`)
+
+	r := &Repo{dbConn: conn}
+
+	param := order.Main{
+		UserID:     "1",
+		Status:     order.StatusPendingPayment,
+		GrandTotal: money.New(800, money.USD),
+		Lines: []order.Line{
+			{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "1", Amount: money.New(1000, money.USD), Quantity: 1, Subtotal: money.New(1000, money.USD)},
+			{LineReferenceType: order.LineReferenceTypeDiscount, LineReferenceID: "v1", Amount: money.New(-200, money.USD), Quantity: 1, Subtotal: money.New(-200, money.USD)},
+		},
+	}
+
+	created, err := r.Create(context.Background(), param)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// the usage limit is reached, the whole order is rejected including its stock reservation.
+	if _, err = r.Create(context.Background(), param); !errors.Is(err, voucher.ErrUsageLimitReached) {
+		t.Errorf("Create() error = %v, want %v", err, voucher.ErrUsageLimitReached)
+	}
+
+	var stock, usedCount int
+	_ = conn.Get(&stock, `select stock from books where id = '1'`)
+	_ = conn.Get(&usedCount, `select used_count from vouchers where id = 'v1'`)
+	if stock != 4 || usedCount != 1 {
+		t.Errorf("Create() stock = %d, used count = %d, want 4 and 1", stock, usedCount)
+	}
+
+	_, err = r.UpdateStatus(context.Background(), order.StatusPendingPayment, order.TransitionParam{
+		OrderID: created.ID,
+		Status:  order.StatusCancelled,
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+
+	_ = conn.Get(&usedCount, `select used_count from vouchers where id = 'v1'`)
+	if usedCount != 0 {
+		t.Errorf("UpdateStatus() used count = %d, want the voucher usage given back", usedCount)
+	}
+}
+
+func TestRepo_CreateOrder_shippingAddress(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: conn}.Up()
+	_ = migrations.CreateOrdersTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderLinesTable{Conn: conn}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: conn}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+	conn.MustExec(`insert into books (id, title, description, price, isbn, publisher_id, stock) values ('1', 'Book 1', 'desc', 1000, '1', 'p1', 5)`)
+
+	r := &Repo{dbConn: conn}
+	if err := r.boot(); err != nil {
+		t.Fatalf("boot() error = %v", err)
+	}
+
+	shippingAddress := address.Address{ID: "a1", RecipientName: "Rendy", Line1: "Jl. Merdeka 1", City: "Jakarta", Region: "DKI Jakarta", Country: "ID"}
+
+	created, err := r.Create(context.Background(), order.Main{
+		UserID:          "1",
+		Status:          order.StatusPendingPayment,
+		GrandTotal:      money.New(1200, money.USD),
+		ShippingAddress: &shippingAddress,
+		Lines: []order.Line{
+			{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "1", Amount: money.New(1000, money.USD), Quantity: 1, Subtotal: money.New(1000, money.USD)},
+			{LineReferenceType: order.LineReferenceTypeShippingFee, LineReferenceID: "a1", Amount: money.New(200, money.USD), Quantity: 1, Subtotal: money.New(200, money.USD)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := r.GetDetailByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("GetDetailByID() error = %v", err)
+	}
+
+	if got.ShippingAddress == nil || !reflect.DeepEqual(*got.ShippingAddress, shippingAddress) || len(got.Lines) != 2 {
+		t.Errorf("GetDetailByID() got = %+v, want the shipping address %+v", got, shippingAddress)
+	}
+
+	var shippingAddressID string
+	_ = conn.Get(&shippingAddressID, `select shipping_address_id from orders where id = ?`, created.ID)
+	if shippingAddressID != "a1" {
+		t.Errorf("Create() shipping address id = %q, want a1", shippingAddressID)
+	}
+}
+
+func TestRepo_CreateOrder_exchangeRate(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateBooksTable{Conn: conn}.Up()
+	_ = migrations.CreateOrdersTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderLinesTable{Conn: conn}.Up()
+	_ = migrations.CreateBookStockAdjustmentsTable{Conn: conn}.Up()
+	_ = migrations.CreateOrderStatusHistoriesTable{Conn: conn}.Up()
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+	conn.MustExec(`insert into books (id, title, description, price, isbn, publisher_id, stock) values ('1', 'Book 1', 'desc', 1000, '1', 'p1', 5)`)
+
+	r := &Repo{dbConn: conn}
+	if err := r.boot(); err != nil {
+		t.Fatalf("boot() error = %v", err)
+	}
+
+	rate, _ := money.ParseRate("15750.5")
+	created, err := r.Create(context.Background(), order.Main{
+		UserID:       "1",
+		Status:       order.StatusPendingPayment,
+		GrandTotal:   money.New(15750500, money.IDR),
+		BaseCurrency: money.USD,
+		ExchangeRate: rate,
+		Lines: []order.Line{
+			{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "1", Amount: money.New(15750500, money.IDR), Quantity: 1, Subtotal: money.New(15750500, money.IDR)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := r.GetDetailByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("GetDetailByID() error = %v", err)
+	}
+
+	if got.Currency != money.IDR || got.BaseCurrency != money.USD || got.ExchangeRate.String() != "15750.5" ||
+		!got.Lines[0].Subtotal.Equal(money.New(15750500, money.IDR)) {
+		t.Errorf("GetDetailByID() got = %+v, want the order in IDR converted from USD", got)
+	}
+}
@@ -1,17 +1,42 @@
 package order
 
 const (
-	queryGetUserOrdersPagination = `select id, grand_total, status, created_at, updated_at from orders 
+	queryGetUserOrdersPagination = `select id, grand_total, currency, status, created_at, updated_at from orders 
 					  where user_id = ? and deleted_at is null and id > ? order by id limit ?`
 
-	queryGetOrderDetail = `select id, user_id, grand_total, status, created_at, updated_at from orders 
+	queryGetUserOrdersPaginationBackward = `select id, grand_total, currency, status, created_at, updated_at from orders 
+					  where user_id = ? and deleted_at is null and id < ? order by id desc limit ?`
+
+	queryGetOrderDetail = `select id, user_id, grand_total, currency, base_currency, exchange_rate, status, shipping_address, created_at, updated_at from orders 
 					  where id = ? and deleted_at is null`
 
 	queryGetOrderLines = `select id, line_reference_type, line_reference_id, amount, quantity, subtotal from order_lines where order_id = ?`
 
-	queryInsertOrder = `insert into orders (id, user_id, grand_total, status, created_at, updated_at) 
-					values (?, ?, ?, ?, ?, ?)`
+	queryGetOrderHistories = `select id, from_status, status, reason, created_at from order_status_histories where order_id = ? order by id`
+
+	queryInsertOrderHistory = `insert into order_status_histories (id, order_id, from_status, status, reason, created_at) values (?, ?, ?, ?, ?, ?)`
+
+	queryUpdateOrderStatus = `update orders set status = ?, updated_at = ? where id = ? and status = ? and deleted_at is null`
+
+	queryInsertOrder = `insert into orders (id, user_id, grand_total, currency, base_currency, exchange_rate, status, shipping_address_id,
+					shipping_address, created_at, updated_at) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	queryInsertOrderLine = `insert into order_lines (id, order_id, line_reference_type, line_reference_id, amount, quantity, subtotal) 
 					values (?, ?, ?, ?, ?, ?, ?)`
+
+	queryReserveBookStock = `update books set stock = stock - ?, updated_at = ? where id = ? and deleted_at is null and stock >= ? returning stock`
+
+	queryGetBookStock = `select stock from books where id = ? and deleted_at is null`
+
+	queryGetOrderLinesByType = `select id, line_reference_type, line_reference_id, amount, quantity, subtotal from order_lines
+					where order_id = ? and line_reference_type = ?`
+
+	queryReleaseBookStock = `update books set stock = stock + ?, updated_at = ? where id = ? returning stock`
+
+	queryRedeemVoucher = `update vouchers set used_count = used_count + 1, updated_at = ? where id = ? and (usage_limit = 0 or used_count < usage_limit)`
+
+	queryReleaseVoucher = `update vouchers set used_count = used_count - 1, updated_at = ? where id = ? and used_count > 0`
+
+	queryInsertStockAdjustment = `insert into book_stock_adjustments (id, book_id, order_id, user_id, quantity, stock, reason, created_at)
+					values (?, ?, ?, ?, ?, ?, ?, ?)`
 )
@@ -5,20 +5,32 @@ import (
 )
 
 type tableOrder struct {
-	ID         string       `db:"id"`
-	UserID     string       `db:"user_id"`
-	GrandTotal float64      `db:"grand_total"`
-	Status     string       `db:"status"`
-	CreatedAt  sql.NullTime `db:"created_at"`
-	UpdatedAt  sql.NullTime `db:"updated_at"`
+	ID              string         `db:"id"`
+	UserID          string         `db:"user_id"`
+	GrandTotal      int64          `db:"grand_total"`
+	Currency        string         `db:"currency"`
+	BaseCurrency    string         `db:"base_currency"`
+	ExchangeRate    string         `db:"exchange_rate"`
+	Status          string         `db:"status"`
+	ShippingAddress sql.NullString `db:"shipping_address"`
+	CreatedAt       sql.NullTime   `db:"created_at"`
+	UpdatedAt       sql.NullTime   `db:"updated_at"`
 }
 
 type tableOrderLine struct {
-	ID                string  `db:"id"`
-	OrderID           string  `db:"order_id"`
-	LineReferenceType string  `db:"line_reference_type"`
-	LineReferenceID   string  `db:"line_reference_id"`
-	Amount            float64 `db:"amount"`
-	Quantity          int     `db:"quantity"`
-	Subtotal          float64 `db:"subtotal"`
+	ID                string `db:"id"`
+	OrderID           string `db:"order_id"`
+	LineReferenceType string `db:"line_reference_type"`
+	LineReferenceID   string `db:"line_reference_id"`
+	Amount            int64  `db:"amount"`
+	Quantity          int    `db:"quantity"`
+	Subtotal          int64  `db:"subtotal"`
+}
+
+type tableOrderHistory struct {
+	ID         string         `db:"id"`
+	FromStatus sql.NullString `db:"from_status"`
+	Status     string         `db:"status"`
+	Reason     sql.NullString `db:"reason"`
+	CreatedAt  sql.NullTime   `db:"created_at"`
 }
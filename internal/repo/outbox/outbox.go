@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// txExecer is the transaction of the change the event describes, e.g. *sqlx.Tx.
+type txExecer interface {
+	Rebind(query string) string
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewOutboxRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+// Write records the event within the transaction of the change, thus the event is only kept when
+// the change is committed.
+func Write(ctx context.Context, tx txExecer, eventType event.Type, aggregateID string, payload any) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertEvent), id.String(), eventType, aggregateID, string(contents), now, now)
+	return err
+}
+
+// FindPending finds the events which are due to be dispatched, in the order they were written.
+func (r *Repo) FindPending(ctx context.Context, now time.Time, limit int) ([]event.Event, error) {
+	var result []tableOutboxEvent
+	if err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(queryGetPendingEvents), now, limit); err != nil {
+		return nil, err
+	}
+
+	events := make([]event.Event, 0, len(result))
+	for _, item := range result {
+		evt := event.Event{
+			ID:          item.ID,
+			Type:        item.Type,
+			AggregateID: item.AggregateID,
+			Payload:     json.RawMessage(item.Payload),
+			Attempts:    item.Attempts,
+		}
+
+		if item.CreatedAt.Valid {
+			evt.CreatedAt = &item.CreatedAt.Time
+		}
+
+		events = append(events, evt)
+	}
+
+	return events, nil
+}
+
+// Claim takes the event for the delivery attempt until the given time, it reports false when the event is
+// taken by another dispatcher. The claim of the dispatcher which dies expires, thus the event is delivered again.
+func (r *Repo) Claim(ctx context.Context, evt event.Event, until time.Time) (bool, error) {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryClaimEvent), until, evt.ID, evt.Attempts)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *Repo) MarkDispatched(ctx context.Context, id string) error {
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryMarkEventDispatched), time.Now(), id)
+	return err
+}
+
+// Retry schedules the next delivery attempt of the event.
+func (r *Repo) Retry(ctx context.Context, id string, at time.Time, lastError string) error {
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryRetryEvent), at, lastError, id)
+	return err
+}
+
+// Fail gives up the delivery of the event, it is kept with the last error.
+func (r *Repo) Fail(ctx context.Context, id string, lastError string) error {
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryFailEvent), time.Now(), lastError, id)
+	return err
+}
@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+)
+
+func TestRepo_Outbox(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	// the event of the rolled back change is not kept.
+	tx := conn.MustBegin()
+	if err := Write(ctx, tx, event.TypeUserRegistered, "user-1", map[string]string{"id": "user-1"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	_ = tx.Rollback()
+
+	tx = conn.MustBegin()
+	_ = Write(ctx, tx, event.TypeOrderPlaced, "order-1", map[string]string{"id": "order-1"})
+	_ = Write(ctx, tx, event.TypeOrderPlaced, "order-2", map[string]string{"id": "order-2"})
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	now := time.Now().Add(time.Second)
+	pending, err := r.FindPending(ctx, now, 10)
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("FindPending() got = %+v, %v, want 2 events", pending, err)
+	}
+
+	first := pending[0]
+	if first.Type != event.TypeOrderPlaced || first.AggregateID != "order-1" || string(first.Payload) != `{"id":"order-1"}` || first.CreatedAt == nil {
+		t.Errorf("FindPending() first event = %+v", first)
+	}
+
+	claimed, err := r.Claim(ctx, first, now.Add(time.Minute))
+	if err != nil || !claimed {
+		t.Fatalf("Claim() got = %v, %v, want claimed", claimed, err)
+	}
+
+	// the stale event is already claimed by another dispatcher.
+	if claimed, _ = r.Claim(ctx, first, now.Add(time.Minute)); claimed {
+		t.Errorf("Claim() of the claimed event got = %v, want false", claimed)
+	}
+
+	if pending, _ = r.FindPending(ctx, now, 10); len(pending) != 1 || pending[0].AggregateID != "order-2" {
+		t.Errorf("FindPending() while claimed got = %+v", pending)
+	}
+
+	// the expired claim is delivered again.
+	pending, _ = r.FindPending(ctx, now.Add(2*time.Minute), 10)
+	if len(pending) != 2 || pending[0].Attempts != 1 {
+		t.Fatalf("FindPending() after the claim expired got = %+v", pending)
+	}
+
+	if err = r.Retry(ctx, first.ID, now.Add(time.Hour), "connection refused"); err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+
+	if pending, _ = r.FindPending(ctx, now.Add(2*time.Minute), 10); len(pending) != 1 {
+		t.Errorf("FindPending() before the retry got = %+v", pending)
+	}
+
+	if err = r.MarkDispatched(ctx, pending[0].ID); err != nil {
+		t.Fatalf("MarkDispatched() error = %v", err)
+	}
+
+	if err = r.Fail(ctx, first.ID, "gone"); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+
+	if pending, _ = r.FindPending(ctx, now.Add(2*time.Hour), 10); len(pending) != 0 {
+		t.Errorf("FindPending() of the dispatched and failed events got = %+v", pending)
+	}
+}
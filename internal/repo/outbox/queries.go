@@ -0,0 +1,20 @@
+package outbox
+
+const (
+	queryInsertEvent = `insert into outbox_events (id, type, aggregate_id, payload, available_at, created_at) values (?, ?, ?, ?, ?, ?)`
+
+	queryGetPendingEvents = `select id, type, aggregate_id, payload, attempts, created_at from outbox_events
+					where dispatched_at is null and failed_at is null and available_at <= ?
+					order by id limit ?`
+
+	// queryClaimEvent hides the event from the other dispatchers until the claim expires, the attempts
+	// guard against the event claimed meanwhile.
+	queryClaimEvent = `update outbox_events set attempts = attempts + 1, available_at = ?
+					where id = ? and attempts = ? and dispatched_at is null and failed_at is null`
+
+	queryMarkEventDispatched = `update outbox_events set dispatched_at = ?, last_error = null where id = ?`
+
+	queryRetryEvent = `update outbox_events set available_at = ?, last_error = ? where id = ?`
+
+	queryFailEvent = `update outbox_events set failed_at = ?, last_error = ? where id = ?`
+)
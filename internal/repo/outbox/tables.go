@@ -0,0 +1,12 @@
+package outbox
+
+import "database/sql"
+
+type tableOutboxEvent struct {
+	ID          string       `db:"id"`
+	Type        string       `db:"type"`
+	AggregateID string       `db:"aggregate_id"`
+	Payload     string       `db:"payload"`
+	Attempts    int          `db:"attempts"`
+	CreatedAt   sql.NullTime `db:"created_at"`
+}
@@ -0,0 +1,10 @@
+package payment
+
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/payment"
+)
+
+var (
+	ErrNotFound       = payment.ErrNotFound
+	ErrAlreadySettled = payment.ErrAlreadySettled
+)
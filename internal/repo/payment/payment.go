@@ -0,0 +1,152 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewPaymentRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+func transactionFromTable(item tableTransaction) payment.Transaction {
+	var createdAt *time.Time
+	var updatedAt *time.Time
+
+	if item.CreatedAt.Valid {
+		createdAt = &item.CreatedAt.Time
+	}
+
+	if item.UpdatedAt.Valid {
+		updatedAt = &item.UpdatedAt.Time
+	}
+
+	return payment.Transaction{
+		ID:         item.ID,
+		OrderID:    item.OrderID,
+		Gateway:    item.Gateway,
+		Reference:  item.Reference,
+		Amount:     money.New(item.Amount, money.Currency(item.Currency)),
+		Status:     item.Status,
+		PaymentURL: item.PaymentURL.String,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}
+}
+
+func (r *Repo) findOne(ctx context.Context, query string, args ...any) (payment.Transaction, error) {
+	var result []tableTransaction
+
+	if err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(query), args...); err != nil {
+		return payment.Transaction{}, err
+	}
+
+	if len(result) == 0 {
+		return payment.Transaction{}, ErrNotFound
+	}
+
+	return transactionFromTable(result[0]), nil
+}
+
+// Create records the payment intent created on the gateway.
+func (r *Repo) Create(ctx context.Context, param payment.Transaction) (payment.Transaction, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+
+	now := time.Now()
+
+	_, err = r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryInsertTransaction), id.String(), param.OrderID, param.Gateway,
+		param.Reference, param.Amount.Amount(), param.Amount.Currency(), param.Status, param.PaymentURL, now, now)
+	if err != nil {
+		slog.Error("error create order transaction", slog.String("error", err.Error()), slog.String("order_id", param.OrderID))
+		return payment.Transaction{}, err
+	}
+
+	param.ID = id.String()
+	param.CreatedAt = &now
+	param.UpdatedAt = &now
+
+	return param, nil
+}
+
+// FindPendingByOrderID finds the latest unsettled payment of the order on the gateway.
+func (r *Repo) FindPendingByOrderID(ctx context.Context, orderID string, gateway string) (payment.Transaction, error) {
+	return r.findOne(ctx, queryGetPendingTransactionByOrderID, orderID, gateway)
+}
+
+func (r *Repo) FindByReference(ctx context.Context, gateway string, reference string) (payment.Transaction, error) {
+	return r.findOne(ctx, queryGetTransactionByReference, gateway, reference)
+}
+
+func (r *Repo) FindByOrderID(ctx context.Context, orderID string) ([]payment.Transaction, error) {
+	var result []tableTransaction
+
+	if err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(queryGetTransactionsByOrderID), orderID); err != nil {
+		return nil, err
+	}
+
+	transactions := make([]payment.Transaction, 0, len(result))
+	for _, item := range result {
+		transactions = append(transactions, transactionFromTable(item))
+	}
+
+	return transactions, nil
+}
+
+// Settle stores the result of the pending payment along with the gateway notification payload,
+// the payment which has been settled before is rejected.
+func (r *Repo) Settle(ctx context.Context, id string, status payment.Status, payload []byte) error {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(querySettleTransaction), status, string(payload), time.Now(), id)
+	if err != nil {
+		slog.Error("error settle order transaction", slog.String("error", err.Error()), slog.String("id", id))
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrAlreadySettled
+	}
+
+	return nil
+}
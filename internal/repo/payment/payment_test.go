@@ -0,0 +1,60 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestRepo_Settle(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateOrderTransactionsTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+
+	created, err := r.Create(context.Background(), payment.Transaction{
+		OrderID:    "1",
+		Gateway:    "fake",
+		Reference:  "ref-1",
+		Amount:     money.New(1250, money.USD),
+		Status:     payment.StatusPending,
+		PaymentURL: "http://localhost/pay/ref-1",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	pending, err := r.FindPendingByOrderID(context.Background(), "1", "fake")
+	if err != nil || pending.ID != created.ID {
+		t.Fatalf("FindPendingByOrderID() got = %+v, error = %v, want %s", pending, err, created.ID)
+	}
+
+	if err = r.Settle(context.Background(), created.ID, payment.StatusSucceeded, []byte(`{}`)); err != nil {
+		t.Fatalf("Settle() error = %v", err)
+	}
+
+	// the gateway may deliver the same notification more than once.
+	if err = r.Settle(context.Background(), created.ID, payment.StatusFailed, []byte(`{}`)); !errors.Is(err, ErrAlreadySettled) {
+		t.Errorf("Settle() error = %v, want %v", err, ErrAlreadySettled)
+	}
+
+	settled, err := r.FindByReference(context.Background(), "fake", "ref-1")
+	if err != nil || settled.Status != payment.StatusSucceeded || !settled.Amount.Equal(money.New(1250, money.USD)) {
+		t.Errorf("FindByReference() got = %+v, error = %v, want succeeded", settled, err)
+	}
+
+	if _, err = r.FindPendingByOrderID(context.Background(), "1", "fake"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindPendingByOrderID() error = %v, want %v", err, ErrNotFound)
+	}
+
+	transactions, err := r.FindByOrderID(context.Background(), "1")
+	if err != nil || len(transactions) != 1 {
+		t.Errorf("FindByOrderID() got = %+v, error = %v, want 1 transaction", transactions, err)
+	}
+}
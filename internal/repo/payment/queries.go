@@ -0,0 +1,17 @@
+package payment
+
+const (
+	queryInsertTransaction = `insert into order_transactions (id, order_id, gateway, reference, amount, currency, status, payment_url, created_at, updated_at)
+					values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	queryGetPendingTransactionByOrderID = `select id, order_id, gateway, reference, amount, currency, status, payment_url, created_at, updated_at from order_transactions
+					where order_id = ? and gateway = ? and status = 'pending' order by id desc limit 1`
+
+	queryGetTransactionByReference = `select id, order_id, gateway, reference, amount, currency, status, payment_url, created_at, updated_at from order_transactions
+					where gateway = ? and reference = ?`
+
+	queryGetTransactionsByOrderID = `select id, order_id, gateway, reference, amount, currency, status, payment_url, created_at, updated_at from order_transactions
+					where order_id = ? order by id`
+
+	querySettleTransaction = `update order_transactions set status = ?, payload = ?, updated_at = ? where id = ? and status = 'pending'`
+)
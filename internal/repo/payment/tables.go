@@ -0,0 +1,16 @@
+package payment
+
+import "database/sql"
+
+type tableTransaction struct {
+	ID         string         `db:"id"`
+	OrderID    string         `db:"order_id"`
+	Gateway    string         `db:"gateway"`
+	Reference  string         `db:"reference"`
+	Amount     int64          `db:"amount"`
+	Currency   string         `db:"currency"`
+	Status     string         `db:"status"`
+	PaymentURL sql.NullString `db:"payment_url"`
+	CreatedAt  sql.NullTime   `db:"created_at"`
+	UpdatedAt  sql.NullTime   `db:"updated_at"`
+}
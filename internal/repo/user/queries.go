@@ -1,7 +1,8 @@
 package user
 
 const (
-	queryGetUserByEmail = `select id, email, password from users where email = ?`
-	queryGetUserByID    = `select id, email, password from users where id = ?`
-	queryInsertUser     = `insert into users (id, name, email, password, created_at, updated_at) values (?, ?, ?, ?, ?, ?) returning id`
+	queryGetUserByEmail = `select id, name, email, password, role from users where email = ?`
+	queryGetUserByID    = `select id, name, email, password, role from users where id = ?`
+	queryInsertUser     = `insert into users (id, name, email, password, role, created_at, updated_at) values (?, ?, ?, ?, ?, ?, ?) returning id`
+	queryUpdateUserRole = `update users set role = ?, updated_at = ? where id = ?`
 )
@@ -5,4 +5,5 @@ type tableUser struct {
 	Name     string `db:"name"`
 	Email    string `db:"email"`
 	Password string `db:"password"`
+	Role     string `db:"role"`
 }
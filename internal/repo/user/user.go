@@ -8,7 +8,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
 	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	"github.com/rendyananta/example-online-book-store/internal/repo/outbox"
 	"github.com/rendyananta/example-online-book-store/pkg/db"
 )
 
@@ -21,6 +23,7 @@ type dbConnection interface {
 	Preparex(query string) (*sqlx.Stmt, error)
 	Rebind(query string) string
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	Beginx() (*sqlx.Tx, error)
 }
 
 type queryGetter interface {
@@ -100,12 +103,13 @@ func (r *Repo) FindByEmail(ctx context.Context, email string) (user.User, error)
 		Name:     userResult.Name,
 		Email:    userResult.Email,
 		Password: userResult.Password,
+		Role:     userResult.Role,
 	}, nil
 }
 
 func (r *Repo) FindByID(ctx context.Context, id string) (user.User, error) {
 	var userResult tableUser
-	err := r.preparedStmt.findByEmailStmt.GetContext(ctx, &userResult, id)
+	err := r.preparedStmt.findByIDStmt.GetContext(ctx, &userResult, id)
 
 	if err != nil && errors.Is(err, sql.ErrNoRows) {
 		return user.User{}, ErrNotFound
@@ -120,26 +124,72 @@ func (r *Repo) FindByID(ctx context.Context, id string) (user.User, error) {
 		Name:     userResult.Name,
 		Email:    userResult.Email,
 		Password: userResult.Password,
+		Role:     userResult.Role,
 	}, nil
 }
 
+// Create inserts the user, the user.registered event is written in the same transaction.
 func (r *Repo) Create(ctx context.Context, param user.User) (user.User, error) {
 	id, err := uuid.NewV7()
 	if err != nil {
 		return user.User{}, err
 	}
 
+	role := param.Role
+	if role == "" {
+		role = user.RoleCustomer
+	}
+
 	now := time.Now()
 
-	_, err = r.dbConn.ExecContext(ctx, queryInsertUser, id.String(), param.Name, param.Email, param.Password, now, now)
+	tx, err := r.dbConn.Beginx()
 	if err != nil {
 		return user.User{}, err
 	}
 
-	return user.User{
+	// rolling back the committed transaction does nothing.
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, tx.Rebind(queryInsertUser), id.String(), param.Name, param.Email, param.Password, role, now, now)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	created := user.User{
 		ID:       id.String(),
 		Name:     param.Name,
 		Email:    param.Email,
 		Password: param.Password,
-	}, nil
+		Role:     role,
+	}
+
+	// the password is left out of the payload by its json tag.
+	if err = outbox.Write(ctx, tx, event.TypeUserRegistered, created.ID, created); err != nil {
+		return user.User{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return user.User{}, err
+	}
+
+	return created, nil
+}
+
+// UpdateRole changes the role of the user, the new role is applied on the next issued token.
+func (r *Repo) UpdateRole(ctx context.Context, id string, role user.Role) error {
+	res, err := r.dbConn.ExecContext(ctx, queryUpdateUserRole, role, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
 }
@@ -74,6 +74,21 @@ func (m *MockdbConnection) EXPECT() *MockdbConnectionMockRecorder {
 	return m.recorder
 }
 
+// Beginx mocks base method.
+func (m *MockdbConnection) Beginx() (*sqlx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Beginx")
+	ret0, _ := ret[0].(*sqlx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Beginx indicates an expected call of Beginx.
+func (mr *MockdbConnectionMockRecorder) Beginx() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Beginx", reflect.TypeOf((*MockdbConnection)(nil).Beginx))
+}
+
 // ExecContext mocks base method.
 func (m *MockdbConnection) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	m.ctrl.T.Helper()
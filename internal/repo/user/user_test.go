@@ -3,49 +3,34 @@ package user
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/jmoiron/sqlx"
-	"github.com/mattn/go-sqlite3"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
 	"github.com/rendyananta/example-online-book-store/internal/entity/user"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestRepo_Create(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	dbConnMock := NewMockdbConnection(ctrl)
-	preparedStmtMock := NewMockqueryGetter(ctrl)
-
-	type fields struct {
-		cfg          Config
-		dbConn       dbConnection
-		preparedStmt preparedStmt
-	}
 	type args struct {
 		ctx   context.Context
 		param user.User
 	}
 	tests := []struct {
 		name       string
-		fields     fields
 		args       args
-		beforeTest func()
+		beforeTest func(conn *sqlx.DB)
 		want       user.User
 		wantErr    bool
+		wantEvents int
 	}{
 		{
 			name: "can create user",
-			fields: fields{
-				cfg:    Config{},
-				dbConn: dbConnMock,
-				preparedStmt: preparedStmt{
-					findByEmailStmt: preparedStmtMock,
-					findByIDStmt:    preparedStmtMock,
-				},
-			},
 			args: args{
 				ctx: context.Background(),
 				param: user.User{
@@ -54,29 +39,17 @@ func TestRepo_Create(t *testing.T) {
 					Password: "hashed-password",
 				},
 			},
-			beforeTest: func() {
-				dbConnMock.EXPECT().
-					ExecContext(context.Background(), queryInsertUser, gomock.AssignableToTypeOf(""), "example", "example@email.com", "hashed-password", gomock.AssignableToTypeOf(time.Time{}), gomock.AssignableToTypeOf(time.Time{})).
-					Return(&sqlite3.SQLiteResult{}, nil)
-			},
 			want: user.User{
-				ID:       "123",
 				Name:     "example",
 				Email:    "example@email.com",
 				Password: "hashed-password",
+				Role:     user.RoleCustomer,
 			},
-			wantErr: false,
+			wantErr:    false,
+			wantEvents: 1,
 		},
 		{
 			name: "can handle error when creating user",
-			fields: fields{
-				cfg:    Config{},
-				dbConn: dbConnMock,
-				preparedStmt: preparedStmt{
-					findByEmailStmt: preparedStmtMock,
-					findByIDStmt:    preparedStmtMock,
-				},
-			},
 			args: args{
 				ctx: context.Background(),
 				param: user.User{
@@ -85,25 +58,32 @@ func TestRepo_Create(t *testing.T) {
 					Password: "hashed-password",
 				},
 			},
-			beforeTest: func() {
-				dbConnMock.EXPECT().
-					ExecContext(context.Background(), queryInsertUser, gomock.AssignableToTypeOf(""), "example", "example@email.com", "hashed-password", gomock.AssignableToTypeOf(time.Time{}), gomock.AssignableToTypeOf(time.Time{})).
-					Return(&sqlite3.SQLiteResult{}, sql.ErrConnDone)
+			beforeTest: func(conn *sqlx.DB) {
+				_, _ = conn.Exec(`insert into users (id, name, email, password) values ('1', 'existing', 'example@email.com', 'hashed-password')`)
 			},
 			want:    user.User{},
 			wantErr: true,
+			// the failed insert writes no event.
+			wantEvents: 0,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := Repo{
-				cfg:          tt.fields.cfg,
-				dbConn:       tt.fields.dbConn,
-				preparedStmt: tt.fields.preparedStmt,
-			}
+			conn, _ := sqlx.Open("sqlite3", ":memory:")
+			defer conn.Close()
+
+			// every connection opens its own in memory database.
+			conn.SetMaxOpenConns(1)
+			_ = migrations.CreateUsersTable{Conn: conn}.Up()
+			_ = migrations.CreateOutboxEventsTable{Conn: conn}.Up()
 
 			if tt.beforeTest != nil {
-				tt.beforeTest()
+				tt.beforeTest(conn)
+			}
+
+			r := Repo{
+				cfg:    Config{},
+				dbConn: conn,
 			}
 
 			got, err := r.Create(tt.args.ctx, tt.args.param)
@@ -112,6 +92,28 @@ func TestRepo_Create(t *testing.T) {
 				return
 			}
 
+			var events []struct {
+				Type        string `db:"type"`
+				AggregateID string `db:"aggregate_id"`
+				Payload     string `db:"payload"`
+			}
+			_ = conn.Select(&events, `select type, aggregate_id, payload from outbox_events`)
+			if len(events) != tt.wantEvents {
+				t.Fatalf("Create() events = %+v, want %d", events, tt.wantEvents)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.ID == "" || events[0].Type != event.TypeUserRegistered || events[0].AggregateID != got.ID {
+				t.Errorf("Create() got = %v, event = %+v", got, events[0])
+			}
+
+			if strings.Contains(events[0].Payload, tt.want.Password) {
+				t.Errorf("Create() event payload = %s, want without the password", events[0].Payload)
+			}
+
 			if got.Name != tt.want.Name {
 				t.Errorf("Create() got = %v, want %v", got, tt.want)
 			}
@@ -123,6 +125,10 @@ func TestRepo_Create(t *testing.T) {
 			if got.Password != tt.want.Password {
 				t.Errorf("Create() got = %v, want %v", got, tt.want)
 			}
+
+			if got.Role != tt.want.Role {
+				t.Errorf("Create() got = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -400,3 +406,79 @@ func TestRepo_FindByID(t *testing.T) {
 		})
 	}
 }
+
+func TestRepo_UpdateRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConnMock := NewMockdbConnection(ctrl)
+
+	type args struct {
+		ctx  context.Context
+		id   string
+		role user.Role
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func()
+		wantErr    error
+	}{
+		{
+			name: "can update role",
+			args: args{
+				ctx:  context.Background(),
+				id:   "1234",
+				role: user.RoleStaff,
+			},
+			beforeTest: func() {
+				dbConnMock.EXPECT().
+					ExecContext(context.Background(), queryUpdateUserRole, user.RoleStaff, gomock.AssignableToTypeOf(time.Time{}), "1234").
+					Return(driver.RowsAffected(1), nil)
+			},
+		},
+		{
+			name: "can handle unknown user",
+			args: args{
+				ctx:  context.Background(),
+				id:   "1234",
+				role: user.RoleStaff,
+			},
+			beforeTest: func() {
+				dbConnMock.EXPECT().
+					ExecContext(context.Background(), queryUpdateUserRole, user.RoleStaff, gomock.AssignableToTypeOf(time.Time{}), "1234").
+					Return(driver.RowsAffected(0), nil)
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "can handle error when updating role",
+			args: args{
+				ctx:  context.Background(),
+				id:   "1234",
+				role: user.RoleStaff,
+			},
+			beforeTest: func() {
+				dbConnMock.EXPECT().
+					ExecContext(context.Background(), queryUpdateUserRole, user.RoleStaff, gomock.AssignableToTypeOf(time.Time{}), "1234").
+					Return(nil, sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Repo{
+				dbConn: dbConnMock,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			if err := r.UpdateRole(tt.args.ctx, tt.args.id, tt.args.role); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package voucher
+
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+)
+
+var (
+	ErrNotFound = voucher.ErrNotFound
+)
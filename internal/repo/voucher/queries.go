@@ -0,0 +1,10 @@
+package voucher
+
+const (
+	queryInsertVoucher = `insert into vouchers (id, code, description, discount_type, percentage, amount, max_discount, min_spend, currency, scope_type, scope_id,
+					usage_limit, used_count, starts_at, ends_at, created_at, updated_at)
+					values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?, ?, ?)`
+
+	queryGetVouchersByCodes = `select id, code, description, discount_type, percentage, amount, max_discount, min_spend, currency, scope_type, scope_id,
+					usage_limit, used_count, starts_at, ends_at, created_at, updated_at from vouchers where code in (?)`
+)
@@ -0,0 +1,23 @@
+package voucher
+
+import "database/sql"
+
+type tableVoucher struct {
+	ID           string         `db:"id"`
+	Code         string         `db:"code"`
+	Description  sql.NullString `db:"description"`
+	DiscountType string         `db:"discount_type"`
+	Percentage   float64        `db:"percentage"`
+	Amount       int64          `db:"amount"`
+	MaxDiscount  int64          `db:"max_discount"`
+	MinSpend     int64          `db:"min_spend"`
+	Currency     string         `db:"currency"`
+	ScopeType    sql.NullString `db:"scope_type"`
+	ScopeID      sql.NullString `db:"scope_id"`
+	UsageLimit   int            `db:"usage_limit"`
+	UsedCount    int            `db:"used_count"`
+	StartsAt     sql.NullTime   `db:"starts_at"`
+	EndsAt       sql.NullTime   `db:"ends_at"`
+	CreatedAt    sql.NullTime   `db:"created_at"`
+	UpdatedAt    sql.NullTime   `db:"updated_at"`
+}
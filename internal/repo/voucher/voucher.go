@@ -0,0 +1,165 @@
+package voucher
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewVoucherRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+// nullableString stores the empty string as null.
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+func nullableTime(value *time.Time) any {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
+
+func voucherFromTable(item tableVoucher) voucher.Voucher {
+	currency := money.Currency(item.Currency)
+	result := voucher.Voucher{
+		ID:           item.ID,
+		Code:         item.Code,
+		Description:  item.Description.String,
+		DiscountType: item.DiscountType,
+		Percentage:   item.Percentage,
+		Amount:       money.New(item.Amount, currency),
+		MaxDiscount:  money.New(item.MaxDiscount, currency),
+		MinSpend:     money.New(item.MinSpend, currency),
+		ScopeType:    item.ScopeType.String,
+		ScopeID:      item.ScopeID.String,
+		UsageLimit:   item.UsageLimit,
+		UsedCount:    item.UsedCount,
+	}
+
+	if item.StartsAt.Valid {
+		result.StartsAt = &item.StartsAt.Time
+	}
+
+	if item.EndsAt.Valid {
+		result.EndsAt = &item.EndsAt.Time
+	}
+
+	if item.CreatedAt.Valid {
+		result.CreatedAt = &item.CreatedAt.Time
+	}
+
+	if item.UpdatedAt.Valid {
+		result.UpdatedAt = &item.UpdatedAt.Time
+	}
+
+	return result
+}
+
+func (r *Repo) Create(ctx context.Context, param voucher.Voucher) (voucher.Voucher, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return voucher.Voucher{}, err
+	}
+
+	now := time.Now()
+
+	_, err = r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryInsertVoucher), id.String(), param.Code, nullableString(param.Description),
+		param.DiscountType, param.Percentage, param.Amount.Amount(), param.MaxDiscount.Amount(), param.MinSpend.Amount(), param.Currency(),
+		nullableString(param.ScopeType), nullableString(param.ScopeID),
+		param.UsageLimit, nullableTime(param.StartsAt), nullableTime(param.EndsAt), now, now)
+	if err != nil {
+		slog.Error("error create voucher", slog.String("error", err.Error()), slog.String("code", param.Code))
+		return voucher.Voucher{}, err
+	}
+
+	param.ID = id.String()
+	param.UsedCount = 0
+	param.CreatedAt = &now
+	param.UpdatedAt = &now
+
+	return param, nil
+}
+
+// FindByCodes finds the vouchers of the codes, the unknown codes are left out.
+func (r *Repo) FindByCodes(ctx context.Context, codes []string) ([]voucher.Voucher, error) {
+	if len(codes) == 0 {
+		return []voucher.Voucher{}, nil
+	}
+
+	query, args, err := sqlx.In(queryGetVouchersByCodes, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []tableVoucher
+	if err = r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	vouchers := make([]voucher.Voucher, 0, len(result))
+	for _, item := range result {
+		vouchers = append(vouchers, voucherFromTable(item))
+	}
+
+	return vouchers, nil
+}
+
+func (r *Repo) FindByCode(ctx context.Context, code string) (voucher.Voucher, error) {
+	vouchers, err := r.FindByCodes(ctx, []string{code})
+	if err != nil {
+		return voucher.Voucher{}, err
+	}
+
+	if len(vouchers) == 0 {
+		return voucher.Voucher{}, ErrNotFound
+	}
+
+	return vouchers[0], nil
+}
@@ -0,0 +1,56 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestRepo_FindByCode(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateVouchersTable{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	endsAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	created, err := r.Create(ctx, voucher.Voucher{
+		Code:         "FICTION10",
+		DiscountType: voucher.DiscountTypePercentage,
+		Percentage:   10,
+		MaxDiscount:  money.New(500, money.USD),
+		ScopeType:    voucher.ScopeTypeGenre,
+		ScopeID:      "g1",
+		UsageLimit:   100,
+		EndsAt:       &endsAt,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := r.FindByCode(ctx, "FICTION10")
+	if err != nil {
+		t.Fatalf("FindByCode() error = %v", err)
+	}
+
+	if got.ID != created.ID || got.ScopeType != voucher.ScopeTypeGenre || got.ScopeID != "g1" || !got.MaxDiscount.Equal(money.New(500, money.USD)) || got.Percentage != 10 ||
+		got.UsageLimit != 100 || got.StartsAt != nil || got.EndsAt == nil || !got.EndsAt.Equal(endsAt) {
+		t.Errorf("FindByCode() got = %+v", got)
+	}
+
+	if _, err = r.Create(ctx, voucher.Voucher{Code: "FICTION10", DiscountType: voucher.DiscountTypeFixed, Amount: money.New(100, money.USD)}); err == nil {
+		t.Errorf("Create() of the taken code error = nil, want unique constraint error")
+	}
+
+	if _, err = r.FindByCode(ctx, "UNKNOWN"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByCode() error = %v, want %v", err, ErrNotFound)
+	}
+}
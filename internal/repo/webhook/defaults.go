@@ -0,0 +1,10 @@
+package webhook
+
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+)
+
+var (
+	ErrNotFound         = webhook.ErrNotFound
+	ErrDeliveryNotFound = webhook.ErrDeliveryNotFound
+)
@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+)
+
+func deliveryFromTable(item tableDelivery) webhook.Delivery {
+	result := webhook.Delivery{
+		ID:             item.ID,
+		SubscriptionID: item.SubscriptionID,
+		EventID:        item.EventID,
+		EventType:      item.EventType,
+		Payload:        json.RawMessage(item.Payload),
+		Status:         item.Status,
+		Attempts:       item.Attempts,
+		ResponseStatus: int(item.ResponseStatus.Int64),
+		ResponseBody:   item.ResponseBody.String,
+		LastError:      item.LastError.String,
+	}
+
+	if item.Status == webhook.DeliveryStatusPending && item.AvailableAt.Valid {
+		result.NextAttemptAt = &item.AvailableAt.Time
+	}
+
+	if item.DeliveredAt.Valid {
+		result.DeliveredAt = &item.DeliveredAt.Time
+	}
+
+	if item.CreatedAt.Valid {
+		result.CreatedAt = &item.CreatedAt.Time
+	}
+
+	if item.UpdatedAt.Valid {
+		result.UpdatedAt = &item.UpdatedAt.Time
+	}
+
+	return result
+}
+
+// CreateDeliveries queues the event for each subscription, the subscription which already has the event
+// queued is skipped.
+func (r *Repo) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error {
+	now := time.Now()
+
+	for _, delivery := range deliveries {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+
+		_, err = r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryInsertDelivery), id.String(), delivery.SubscriptionID,
+			delivery.EventID, delivery.EventType, string(delivery.Payload), webhook.DeliveryStatusPending, now, now, now)
+		if err != nil {
+			slog.Error("error create webhook delivery", slog.String("error", err.Error()),
+				slog.String("subscription_id", delivery.SubscriptionID), slog.String("event_id", delivery.EventID))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindDueDeliveries finds the pending deliveries which are due to be sent, in the order they were queued.
+func (r *Repo) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]webhook.Delivery, error) {
+	var result []tableDelivery
+	err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(queryGetDueDeliveries), webhook.DeliveryStatusPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]webhook.Delivery, 0, len(result))
+	for _, item := range result {
+		deliveries = append(deliveries, deliveryFromTable(item))
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDelivery takes the delivery for the attempt until the given time, it reports false when the delivery is
+// taken by another sender.
+func (r *Repo) ClaimDelivery(ctx context.Context, delivery webhook.Delivery, until time.Time) (bool, error) {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryClaimDelivery), until, time.Now(), delivery.ID,
+		delivery.Attempts, webhook.DeliveryStatusPending)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *Repo) MarkDelivered(ctx context.Context, id string, attempt webhook.AttemptResult) error {
+	now := time.Now()
+
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryMarkDeliveryDelivered), webhook.DeliveryStatusDelivered,
+		nullableInt(attempt.ResponseStatus), nullableString(attempt.ResponseBody), now, now, id)
+	return err
+}
+
+// RetryDelivery schedules the next attempt of the delivery.
+func (r *Repo) RetryDelivery(ctx context.Context, id string, at time.Time, attempt webhook.AttemptResult) error {
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryRetryDelivery), at, nullableInt(attempt.ResponseStatus),
+		nullableString(attempt.ResponseBody), nullableString(attempt.Error), time.Now(), id)
+	return err
+}
+
+// FailDelivery gives up the delivery, it is sent again only when it is replayed.
+func (r *Repo) FailDelivery(ctx context.Context, id string, attempt webhook.AttemptResult) error {
+	_, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryFailDelivery), webhook.DeliveryStatusFailed,
+		nullableInt(attempt.ResponseStatus), nullableString(attempt.ResponseBody), nullableString(attempt.Error), time.Now(), id)
+	return err
+}
+
+// ReplayDelivery queues the delivery of the subscription again with the fresh attempts, regardless of its outcome.
+func (r *Repo) ReplayDelivery(ctx context.Context, subscriptionID, id string) (webhook.Delivery, error) {
+	now := time.Now()
+
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryReplayDelivery), webhook.DeliveryStatusPending, now, now,
+		id, subscriptionID)
+	if err != nil {
+		slog.Error("error replay webhook delivery", slog.String("error", err.Error()), slog.String("id", id))
+		return webhook.Delivery{}, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return webhook.Delivery{}, ErrDeliveryNotFound
+	}
+
+	return r.FindDeliveryByID(ctx, subscriptionID, id)
+}
+
+func (r *Repo) FindDeliveryByID(ctx context.Context, subscriptionID, id string) (webhook.Delivery, error) {
+	var result tableDelivery
+	err := r.dbConn.GetContext(ctx, &result, r.dbConn.Rebind(queryGetDeliveryByID), id, subscriptionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return webhook.Delivery{}, ErrDeliveryNotFound
+	}
+
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return deliveryFromTable(result), nil
+}
+
+// FindDeliveries lists the latest deliveries of the subscription, the empty status lists the deliveries of any status.
+func (r *Repo) FindDeliveries(ctx context.Context, subscriptionID string, status webhook.DeliveryStatus, limit int) ([]webhook.Delivery, error) {
+	var result []tableDelivery
+	err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(queryGetDeliveriesBySubscriptionID), subscriptionID,
+		status, status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]webhook.Delivery, 0, len(result))
+	for _, item := range result {
+		deliveries = append(deliveries, deliveryFromTable(item))
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,61 @@
+package webhook
+
+const (
+	queryInsertSubscription = `insert into webhook_subscriptions (id, url, secret, event_types, active, created_at, updated_at)
+					values (?, ?, ?, ?, ?, ?, ?)`
+
+	// queryUpdateSubscription keeps the secret when the given secret is null.
+	queryUpdateSubscription = `update webhook_subscriptions set url = ?, secret = coalesce(?, secret), event_types = ?, active = ?,
+					updated_at = ? where id = ? and deleted_at is null`
+
+	queryDeleteSubscription = `update webhook_subscriptions set deleted_at = ? where id = ? and deleted_at is null`
+
+	queryGetSubscriptionByID = `select id, url, secret, event_types, active, created_at, updated_at from webhook_subscriptions
+					where id = ? and deleted_at is null`
+
+	queryGetSubscriptions = `select id, url, secret, event_types, active, created_at, updated_at from webhook_subscriptions
+					where deleted_at is null order by created_at, id`
+
+	queryGetActiveSubscriptions = `select id, url, secret, event_types, active, created_at, updated_at from webhook_subscriptions
+					where active and deleted_at is null order by created_at, id`
+
+	// queryInsertDelivery skips the event which is already queued for the subscription, thus the redelivered
+	// event is not sent twice.
+	queryInsertDelivery = `insert into webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, available_at,
+					created_at, updated_at) values (?, ?, ?, ?, ?, ?, ?, ?, ?)
+					on conflict (subscription_id, event_id) do nothing`
+
+	// queryGetDueDeliveries leaves the deliveries of the inactive subscription waiting until it is active again.
+	queryGetDueDeliveries = `select d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.available_at,
+					d.response_status, d.response_body, d.last_error, d.delivered_at, d.created_at, d.updated_at
+					from webhook_deliveries d join webhook_subscriptions s on s.id = d.subscription_id
+					where d.status = ? and d.available_at <= ? and s.active and s.deleted_at is null
+					order by d.id limit ?`
+
+	// queryClaimDelivery hides the delivery from the other senders until the claim expires, the attempts
+	// guard against the delivery claimed meanwhile.
+	queryClaimDelivery = `update webhook_deliveries set attempts = attempts + 1, available_at = ?, updated_at = ?
+					where id = ? and attempts = ? and status = ?`
+
+	queryMarkDeliveryDelivered = `update webhook_deliveries set status = ?, response_status = ?, response_body = ?, last_error = null,
+					delivered_at = ?, updated_at = ? where id = ?`
+
+	queryRetryDelivery = `update webhook_deliveries set available_at = ?, response_status = ?, response_body = ?, last_error = ?,
+					updated_at = ? where id = ?`
+
+	queryFailDelivery = `update webhook_deliveries set status = ?, response_status = ?, response_body = ?, last_error = ?,
+					updated_at = ? where id = ?`
+
+	queryReplayDelivery = `update webhook_deliveries set status = ?, attempts = 0, available_at = ?, delivered_at = null, updated_at = ?
+					where id = ? and subscription_id = ?`
+
+	queryGetDeliveryByID = `select id, subscription_id, event_id, event_type, payload, status, attempts, available_at,
+					response_status, response_body, last_error, delivered_at, created_at, updated_at
+					from webhook_deliveries where id = ? and subscription_id = ?`
+
+	// queryGetDeliveriesBySubscriptionID lists the latest deliveries first, the empty status matches any status.
+	queryGetDeliveriesBySubscriptionID = `select id, subscription_id, event_id, event_type, payload, status, attempts, available_at,
+					response_status, response_body, last_error, delivered_at, created_at, updated_at
+					from webhook_deliveries where subscription_id = ? and (? = '' or status = ?)
+					order by id desc limit ?`
+)
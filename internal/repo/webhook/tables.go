@@ -0,0 +1,30 @@
+package webhook
+
+import "database/sql"
+
+type tableSubscription struct {
+	ID         string       `db:"id"`
+	URL        string       `db:"url"`
+	Secret     string       `db:"secret"`
+	EventTypes string       `db:"event_types"`
+	Active     bool         `db:"active"`
+	CreatedAt  sql.NullTime `db:"created_at"`
+	UpdatedAt  sql.NullTime `db:"updated_at"`
+}
+
+type tableDelivery struct {
+	ID             string         `db:"id"`
+	SubscriptionID string         `db:"subscription_id"`
+	EventID        string         `db:"event_id"`
+	EventType      string         `db:"event_type"`
+	Payload        string         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	AvailableAt    sql.NullTime   `db:"available_at"`
+	ResponseStatus sql.NullInt64  `db:"response_status"`
+	ResponseBody   sql.NullString `db:"response_body"`
+	LastError      sql.NullString `db:"last_error"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      sql.NullTime   `db:"created_at"`
+	UpdatedAt      sql.NullTime   `db:"updated_at"`
+}
@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+	"github.com/rendyananta/example-online-book-store/pkg/db"
+)
+
+type dbConnManager interface {
+	Connection(name string) (*sqlx.DB, error)
+}
+
+type dbConnection interface {
+	Rebind(query string) string
+
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	DBConn string
+}
+
+type Repo struct {
+	cfg    Config
+	dbConn dbConnection
+}
+
+func NewWebhookRepo(cfg Config, dbConnManager dbConnManager) (*Repo, error) {
+	if cfg.DBConn == "" {
+		cfg.DBConn = db.ConnDefault
+	}
+
+	conn, err := dbConnManager.Connection(cfg.DBConn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repo{
+		cfg:    cfg,
+		dbConn: conn,
+	}, nil
+}
+
+func nullableString(value string) any {
+	if value == "" {
+		return nil
+	}
+
+	return value
+}
+
+func nullableInt(value int) any {
+	if value == 0 {
+		return nil
+	}
+
+	return value
+}
+
+// joinEventTypes keeps the event types as the comma separated list.
+func joinEventTypes(eventTypes []event.Type) string {
+	return strings.Join(eventTypes, ",")
+}
+
+func splitEventTypes(value string) []event.Type {
+	eventTypes := make([]event.Type, 0)
+	for _, eventType := range strings.Split(value, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	return eventTypes
+}
+
+func subscriptionFromTable(item tableSubscription) webhook.Subscription {
+	result := webhook.Subscription{
+		ID:         item.ID,
+		URL:        item.URL,
+		Secret:     item.Secret,
+		EventTypes: splitEventTypes(item.EventTypes),
+		Active:     item.Active,
+	}
+
+	if item.CreatedAt.Valid {
+		result.CreatedAt = &item.CreatedAt.Time
+	}
+
+	if item.UpdatedAt.Valid {
+		result.UpdatedAt = &item.UpdatedAt.Time
+	}
+
+	return result
+}
+
+func (r *Repo) Create(ctx context.Context, param webhook.WriteParam) (webhook.Subscription, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	now := time.Now()
+
+	_, err = r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryInsertSubscription), id.String(), param.URL, param.Secret,
+		joinEventTypes(param.EventTypes), param.Active, now, now)
+	if err != nil {
+		slog.Error("error create webhook subscription", slog.String("error", err.Error()))
+		return webhook.Subscription{}, err
+	}
+
+	return r.FindByID(ctx, id.String())
+}
+
+// Update replaces the subscription, the secret is kept when the given secret is empty.
+func (r *Repo) Update(ctx context.Context, id string, param webhook.WriteParam) (webhook.Subscription, error) {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryUpdateSubscription), param.URL, nullableString(param.Secret),
+		joinEventTypes(param.EventTypes), param.Active, time.Now(), id)
+	if err != nil {
+		slog.Error("error update webhook subscription", slog.String("error", err.Error()), slog.String("id", id))
+		return webhook.Subscription{}, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return webhook.Subscription{}, ErrNotFound
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// Delete removes the subscription, its deliveries are kept for the record but no longer sent.
+func (r *Repo) Delete(ctx context.Context, id string) error {
+	result, err := r.dbConn.ExecContext(ctx, r.dbConn.Rebind(queryDeleteSubscription), time.Now(), id)
+	if err != nil {
+		slog.Error("error delete webhook subscription", slog.String("error", err.Error()), slog.String("id", id))
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) FindByID(ctx context.Context, id string) (webhook.Subscription, error) {
+	var result tableSubscription
+	err := r.dbConn.GetContext(ctx, &result, r.dbConn.Rebind(queryGetSubscriptionByID), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return webhook.Subscription{}, ErrNotFound
+	}
+
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	return subscriptionFromTable(result), nil
+}
+
+func (r *Repo) FindAll(ctx context.Context) ([]webhook.Subscription, error) {
+	return r.findSubscriptions(ctx, queryGetSubscriptions)
+}
+
+func (r *Repo) FindActive(ctx context.Context) ([]webhook.Subscription, error) {
+	return r.findSubscriptions(ctx, queryGetActiveSubscriptions)
+}
+
+func (r *Repo) findSubscriptions(ctx context.Context, query string) ([]webhook.Subscription, error) {
+	var result []tableSubscription
+	if err := r.dbConn.SelectContext(ctx, &result, r.dbConn.Rebind(query)); err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]webhook.Subscription, 0, len(result))
+	for _, item := range result {
+		subscriptions = append(subscriptions, subscriptionFromTable(item))
+	}
+
+	return subscriptions, nil
+}
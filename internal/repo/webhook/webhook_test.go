@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rendyananta/example-online-book-store/database/migrations"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+)
+
+func TestRepo_Subscriptions(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateWebhookTables{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	created, err := r.Create(ctx, webhook.WriteParam{
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "first-secret-value",
+		EventTypes: []event.Type{event.TypeOrderPlaced, event.TypeOrderStatusChanged},
+		Active:     true,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if created.ID == "" || created.Secret != "first-secret-value" || !created.Active || created.CreatedAt == nil ||
+		!slices.Equal(created.EventTypes, []event.Type{event.TypeOrderPlaced, event.TypeOrderStatusChanged}) {
+		t.Errorf("Create() got = %+v", created)
+	}
+
+	// the empty secret keeps the current one.
+	updated, err := r.Update(ctx, created.ID, webhook.WriteParam{
+		URL:        "https://partner.example.com/v2/hooks",
+		EventTypes: []event.Type{event.TypeOrderStatusChanged},
+		Active:     false,
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if updated.URL != "https://partner.example.com/v2/hooks" || updated.Secret != "first-secret-value" || updated.Active ||
+		!slices.Equal(updated.EventTypes, []event.Type{event.TypeOrderStatusChanged}) {
+		t.Errorf("Update() got = %+v", updated)
+	}
+
+	if active, _ := r.FindActive(ctx); len(active) != 0 {
+		t.Errorf("FindActive() got = %+v, want none", active)
+	}
+
+	if all, _ := r.FindAll(ctx); len(all) != 1 {
+		t.Errorf("FindAll() got = %+v, want 1 subscription", all)
+	}
+
+	if err = r.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err = r.FindByID(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByID() of the deleted subscription error = %v, wantErr %v", err, ErrNotFound)
+	}
+
+	if err = r.Delete(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete() of the deleted subscription error = %v, wantErr %v", err, ErrNotFound)
+	}
+
+	if _, err = r.Update(ctx, created.ID, webhook.WriteParam{URL: "https://example.com"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() of the deleted subscription error = %v, wantErr %v", err, ErrNotFound)
+	}
+}
+
+func TestRepo_Deliveries(t *testing.T) {
+	conn, _ := sqlx.Open("sqlite3", ":memory:")
+	conn.SetMaxOpenConns(1)
+	_ = migrations.CreateWebhookTables{Conn: conn}.Up()
+
+	r := &Repo{dbConn: conn}
+	ctx := context.Background()
+
+	active, _ := r.Create(ctx, webhook.WriteParam{URL: "https://a.example.com", Secret: "secret-a", EventTypes: []event.Type{event.TypeOrderPlaced}, Active: true})
+	paused, _ := r.Create(ctx, webhook.WriteParam{URL: "https://b.example.com", Secret: "secret-b", EventTypes: []event.Type{event.TypeOrderPlaced}, Active: false})
+
+	deliveries := []webhook.Delivery{
+		{SubscriptionID: active.ID, EventID: "0192a000-0000-7000-8000-000000000001", EventType: event.TypeOrderPlaced, Payload: []byte(`{"id":"1"}`)},
+		{SubscriptionID: paused.ID, EventID: "0192a000-0000-7000-8000-000000000001", EventType: event.TypeOrderPlaced, Payload: []byte(`{"id":"1"}`)},
+	}
+
+	if err := r.CreateDeliveries(ctx, deliveries); err != nil {
+		t.Fatalf("CreateDeliveries() error = %v", err)
+	}
+
+	// the redelivered event is queued once.
+	if err := r.CreateDeliveries(ctx, deliveries); err != nil {
+		t.Fatalf("CreateDeliveries() of the redelivered event error = %v", err)
+	}
+
+	now := time.Now().Add(time.Second)
+
+	// the delivery of the inactive subscription waits.
+	due, err := r.FindDueDeliveries(ctx, now, 10)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("FindDueDeliveries() got = %+v, %v, want 1 delivery", due, err)
+	}
+
+	delivery := due[0]
+	if delivery.SubscriptionID != active.ID || delivery.Status != webhook.DeliveryStatusPending || string(delivery.Payload) != `{"id":"1"}` ||
+		delivery.NextAttemptAt == nil {
+		t.Errorf("FindDueDeliveries() got = %+v", delivery)
+	}
+
+	claimed, err := r.ClaimDelivery(ctx, delivery, now.Add(time.Minute))
+	if err != nil || !claimed {
+		t.Fatalf("ClaimDelivery() got = %v, %v, want claimed", claimed, err)
+	}
+
+	if claimed, _ = r.ClaimDelivery(ctx, delivery, now.Add(time.Minute)); claimed {
+		t.Errorf("ClaimDelivery() of the claimed delivery got = %v, want false", claimed)
+	}
+
+	if due, _ = r.FindDueDeliveries(ctx, now, 10); len(due) != 0 {
+		t.Errorf("FindDueDeliveries() while claimed got = %+v", due)
+	}
+
+	err = r.RetryDelivery(ctx, delivery.ID, now.Add(time.Hour), webhook.AttemptResult{ResponseStatus: 503, ResponseBody: "busy", Error: "unexpected status"})
+	if err != nil {
+		t.Fatalf("RetryDelivery() error = %v", err)
+	}
+
+	retried, _ := r.FindDeliveryByID(ctx, active.ID, delivery.ID)
+	if retried.Attempts != 1 || retried.ResponseStatus != 503 || retried.ResponseBody != "busy" || retried.LastError != "unexpected status" {
+		t.Errorf("FindDeliveryByID() after the retry got = %+v", retried)
+	}
+
+	if err = r.FailDelivery(ctx, delivery.ID, webhook.AttemptResult{Error: "connection refused"}); err != nil {
+		t.Fatalf("FailDelivery() error = %v", err)
+	}
+
+	failed, _ := r.FindDeliveries(ctx, active.ID, webhook.DeliveryStatusFailed, 10)
+	if len(failed) != 1 || failed[0].ResponseStatus != 0 || failed[0].LastError != "connection refused" || failed[0].NextAttemptAt != nil {
+		t.Errorf("FindDeliveries() of the failed deliveries got = %+v", failed)
+	}
+
+	replayed, err := r.ReplayDelivery(ctx, active.ID, delivery.ID)
+	if err != nil || replayed.Status != webhook.DeliveryStatusPending || replayed.Attempts != 0 {
+		t.Fatalf("ReplayDelivery() got = %+v, %v", replayed, err)
+	}
+
+	if _, err = r.ReplayDelivery(ctx, paused.ID, delivery.ID); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("ReplayDelivery() of the other subscription error = %v, wantErr %v", err, ErrDeliveryNotFound)
+	}
+
+	due, _ = r.FindDueDeliveries(ctx, time.Now().Add(time.Second), 10)
+	if len(due) != 1 {
+		t.Fatalf("FindDueDeliveries() after the replay got = %+v", due)
+	}
+
+	_, _ = r.ClaimDelivery(ctx, due[0], now.Add(time.Minute))
+	if err = r.MarkDelivered(ctx, delivery.ID, webhook.AttemptResult{ResponseStatus: 200, ResponseBody: "ok"}); err != nil {
+		t.Fatalf("MarkDelivered() error = %v", err)
+	}
+
+	all, _ := r.FindDeliveries(ctx, active.ID, "", 10)
+	if len(all) != 1 || all[0].Status != webhook.DeliveryStatusDelivered || all[0].ResponseStatus != 200 || all[0].LastError != "" ||
+		all[0].DeliveredAt == nil {
+		t.Errorf("FindDeliveries() got = %+v", all)
+	}
+}
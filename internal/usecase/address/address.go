@@ -0,0 +1,78 @@
+package address
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+)
+
+//go:generate mockgen -source=address.go -destination=address_repo_mock_test.go -package address
+type addressRepo interface {
+	Create(ctx context.Context, userID string, param address.WriteParam) (address.Address, error)
+	Update(ctx context.Context, userID, id string, param address.WriteParam) (address.Address, error)
+	Delete(ctx context.Context, userID, id string) error
+	FindByID(ctx context.Context, userID, id string) (address.Address, error)
+	FindByUserID(ctx context.Context, userID string) ([]address.Address, error)
+}
+
+// maxAddresses is the maximum number of addresses kept in the address book of a user.
+const maxAddresses = 20
+
+var (
+	ErrNotFound     = address.ErrNotFound
+	ErrLimitReached = address.ErrLimitReached
+)
+
+// BookUseCase manages the address book of the user, every address is only accessible by its owner.
+type BookUseCase struct {
+	repo addressRepo
+}
+
+func NewAddressBookUseCase(repo addressRepo) (*BookUseCase, error) {
+	return &BookUseCase{repo: repo}, nil
+}
+
+// normalize trims the fields and stores the country as the upper case ISO 3166-1 alpha-2 code.
+func normalize(param address.WriteParam) address.WriteParam {
+	return address.WriteParam{
+		Label:         strings.TrimSpace(param.Label),
+		RecipientName: strings.TrimSpace(param.RecipientName),
+		Phone:         strings.TrimSpace(param.Phone),
+		Line1:         strings.TrimSpace(param.Line1),
+		Line2:         strings.TrimSpace(param.Line2),
+		City:          strings.TrimSpace(param.City),
+		Region:        strings.TrimSpace(param.Region),
+		PostalCode:    strings.TrimSpace(param.PostalCode),
+		Country:       strings.ToUpper(strings.TrimSpace(param.Country)),
+	}
+}
+
+func (uc BookUseCase) List(ctx context.Context, userID string) ([]address.Address, error) {
+	return uc.repo.FindByUserID(ctx, userID)
+}
+
+func (uc BookUseCase) Get(ctx context.Context, userID, id string) (address.Address, error) {
+	return uc.repo.FindByID(ctx, userID, id)
+}
+
+func (uc BookUseCase) Create(ctx context.Context, userID string, param address.WriteParam) (address.Address, error) {
+	addresses, err := uc.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return address.Address{}, err
+	}
+
+	if len(addresses) >= maxAddresses {
+		return address.Address{}, ErrLimitReached
+	}
+
+	return uc.repo.Create(ctx, userID, normalize(param))
+}
+
+func (uc BookUseCase) Update(ctx context.Context, userID, id string, param address.WriteParam) (address.Address, error) {
+	return uc.repo.Update(ctx, userID, id, normalize(param))
+}
+
+func (uc BookUseCase) Delete(ctx context.Context, userID, id string) error {
+	return uc.repo.Delete(ctx, userID, id)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: address.go
+
+// Package address is a generated GoMock package.
+package address
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	address "github.com/rendyananta/example-online-book-store/internal/entity/address"
+)
+
+// MockaddressRepo is a mock of addressRepo interface.
+type MockaddressRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockaddressRepoMockRecorder
+}
+
+// MockaddressRepoMockRecorder is the mock recorder for MockaddressRepo.
+type MockaddressRepoMockRecorder struct {
+	mock *MockaddressRepo
+}
+
+// NewMockaddressRepo creates a new mock instance.
+func NewMockaddressRepo(ctrl *gomock.Controller) *MockaddressRepo {
+	mock := &MockaddressRepo{ctrl: ctrl}
+	mock.recorder = &MockaddressRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaddressRepo) EXPECT() *MockaddressRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockaddressRepo) Create(ctx context.Context, userID string, param address.WriteParam) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, param)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockaddressRepoMockRecorder) Create(ctx, userID, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockaddressRepo)(nil).Create), ctx, userID, param)
+}
+
+// Delete mocks base method.
+func (m *MockaddressRepo) Delete(ctx context.Context, userID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockaddressRepoMockRecorder) Delete(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockaddressRepo)(nil).Delete), ctx, userID, id)
+}
+
+// FindByID mocks base method.
+func (m *MockaddressRepo) FindByID(ctx context.Context, userID, id string) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID, id)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockaddressRepoMockRecorder) FindByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockaddressRepo)(nil).FindByID), ctx, userID, id)
+}
+
+// FindByUserID mocks base method.
+func (m *MockaddressRepo) FindByUserID(ctx context.Context, userID string) ([]address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockaddressRepoMockRecorder) FindByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockaddressRepo)(nil).FindByUserID), ctx, userID)
+}
+
+// Update mocks base method.
+func (m *MockaddressRepo) Update(ctx context.Context, userID, id string, param address.WriteParam) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userID, id, param)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockaddressRepoMockRecorder) Update(ctx, userID, id, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockaddressRepo)(nil).Update), ctx, userID, id, param)
+}
@@ -0,0 +1,53 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+)
+
+func TestBookUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockaddressRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		param      address.WriteParam
+		beforeTest func()
+		wantErr    error
+	}{
+		{
+			name:  "can create normalized address",
+			param: address.WriteParam{RecipientName: " Rendy ", Line1: "Jl. Merdeka 1", City: "Jakarta", Region: "DKI Jakarta", Country: "id"},
+			beforeTest: func() {
+				repoMock.EXPECT().FindByUserID(context.Background(), "u1").Return([]address.Address{}, nil)
+				repoMock.EXPECT().Create(context.Background(), "u1", address.WriteParam{
+					RecipientName: "Rendy", Line1: "Jl. Merdeka 1", City: "Jakarta", Region: "DKI Jakarta", Country: "ID",
+				}).Return(address.Address{ID: "a1"}, nil)
+			},
+		},
+		{
+			name:  "can reject full address book",
+			param: address.WriteParam{RecipientName: "Rendy"},
+			beforeTest: func() {
+				repoMock.EXPECT().FindByUserID(context.Background(), "u1").Return(make([]address.Address, maxAddresses), nil)
+			},
+			wantErr: ErrLimitReached,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewAddressBookUseCase(repoMock)
+			if _, err := uc.Create(context.Background(), "u1", tt.param); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package book
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+)
+
+//go:generate mockgen -source=catalog.go -destination=catalog_repo_mock_test.go -package book
+type catalogRepo interface {
+	PaginateAuthors(ctx context.Context, param book.ListParam) (book.AuthorsResult, error)
+	FindAuthorByID(ctx context.Context, id string) (book.Author, error)
+	PaginateGenres(ctx context.Context, param book.ListParam) (book.GenresResult, error)
+	FindGenreByID(ctx context.Context, id string) (book.Genre, error)
+	PaginatePublishers(ctx context.Context, param book.ListParam) (book.PublishersResult, error)
+	FindPublisherByID(ctx context.Context, id string) (book.Publisher, error)
+	PaginateAllBooks(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error)
+}
+
+// CatalogQueriesUseCase browses the catalog by its authors, genres and publishers.
+type CatalogQueriesUseCase struct {
+	repo  catalogRepo
+	rates exchangeRates
+}
+
+func NewCatalogQueriesUseCase(repo catalogRepo, rates exchangeRates) (*CatalogQueriesUseCase, error) {
+	return &CatalogQueriesUseCase{repo: repo, rates: rates}, nil
+}
+
+func (q CatalogQueriesUseCase) GetAuthors(ctx context.Context, param book.ListParam) (book.AuthorsResult, error) {
+	return q.repo.PaginateAuthors(ctx, param)
+}
+
+func (q CatalogQueriesUseCase) AuthorDetailByID(ctx context.Context, id string) (book.Author, error) {
+	return q.repo.FindAuthorByID(ctx, id)
+}
+
+// BooksByAuthor lists the books written by the author, the other filters of the param still apply.
+func (q CatalogQueriesUseCase) BooksByAuthor(ctx context.Context, authorID string, param book.PaginationParam) (book.PaginationResult, error) {
+	if _, err := q.repo.FindAuthorByID(ctx, authorID); err != nil {
+		return book.PaginationResult{}, err
+	}
+
+	param.Filter.AuthorIDs = []string{authorID}
+
+	return paginateInCurrency(ctx, q.rates, param, q.repo.PaginateAllBooks)
+}
+
+func (q CatalogQueriesUseCase) GetGenres(ctx context.Context, param book.ListParam) (book.GenresResult, error) {
+	return q.repo.PaginateGenres(ctx, param)
+}
+
+func (q CatalogQueriesUseCase) GenreDetailByID(ctx context.Context, id string) (book.Genre, error) {
+	return q.repo.FindGenreByID(ctx, id)
+}
+
+// BooksByGenre lists the books of the genre, the other filters of the param still apply.
+func (q CatalogQueriesUseCase) BooksByGenre(ctx context.Context, genreID string, param book.PaginationParam) (book.PaginationResult, error) {
+	if _, err := q.repo.FindGenreByID(ctx, genreID); err != nil {
+		return book.PaginationResult{}, err
+	}
+
+	param.Filter.GenreIDs = []string{genreID}
+
+	return paginateInCurrency(ctx, q.rates, param, q.repo.PaginateAllBooks)
+}
+
+func (q CatalogQueriesUseCase) GetPublishers(ctx context.Context, param book.ListParam) (book.PublishersResult, error) {
+	return q.repo.PaginatePublishers(ctx, param)
+}
+
+func (q CatalogQueriesUseCase) PublisherDetailByID(ctx context.Context, id string) (book.Publisher, error) {
+	return q.repo.FindPublisherByID(ctx, id)
+}
+
+// BooksByPublisher lists the books published by the publisher, the other filters of the param still apply.
+func (q CatalogQueriesUseCase) BooksByPublisher(ctx context.Context, publisherID string, param book.PaginationParam) (book.PaginationResult, error) {
+	if _, err := q.repo.FindPublisherByID(ctx, publisherID); err != nil {
+		return book.PaginationResult{}, err
+	}
+
+	param.Filter.PublisherIDs = []string{publisherID}
+
+	return paginateInCurrency(ctx, q.rates, param, q.repo.PaginateAllBooks)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: catalog.go
+
+// Package book is a generated GoMock package.
+package book
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	book "github.com/rendyananta/example-online-book-store/internal/entity/book"
+)
+
+// MockcatalogRepo is a mock of catalogRepo interface.
+type MockcatalogRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogRepoMockRecorder
+}
+
+// MockcatalogRepoMockRecorder is the mock recorder for MockcatalogRepo.
+type MockcatalogRepoMockRecorder struct {
+	mock *MockcatalogRepo
+}
+
+// NewMockcatalogRepo creates a new mock instance.
+func NewMockcatalogRepo(ctrl *gomock.Controller) *MockcatalogRepo {
+	mock := &MockcatalogRepo{ctrl: ctrl}
+	mock.recorder = &MockcatalogRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcatalogRepo) EXPECT() *MockcatalogRepoMockRecorder {
+	return m.recorder
+}
+
+// FindAuthorByID mocks base method.
+func (m *MockcatalogRepo) FindAuthorByID(ctx context.Context, id string) (book.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAuthorByID", ctx, id)
+	ret0, _ := ret[0].(book.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAuthorByID indicates an expected call of FindAuthorByID.
+func (mr *MockcatalogRepoMockRecorder) FindAuthorByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuthorByID", reflect.TypeOf((*MockcatalogRepo)(nil).FindAuthorByID), ctx, id)
+}
+
+// FindGenreByID mocks base method.
+func (m *MockcatalogRepo) FindGenreByID(ctx context.Context, id string) (book.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindGenreByID", ctx, id)
+	ret0, _ := ret[0].(book.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindGenreByID indicates an expected call of FindGenreByID.
+func (mr *MockcatalogRepoMockRecorder) FindGenreByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGenreByID", reflect.TypeOf((*MockcatalogRepo)(nil).FindGenreByID), ctx, id)
+}
+
+// FindPublisherByID mocks base method.
+func (m *MockcatalogRepo) FindPublisherByID(ctx context.Context, id string) (book.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPublisherByID", ctx, id)
+	ret0, _ := ret[0].(book.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPublisherByID indicates an expected call of FindPublisherByID.
+func (mr *MockcatalogRepoMockRecorder) FindPublisherByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPublisherByID", reflect.TypeOf((*MockcatalogRepo)(nil).FindPublisherByID), ctx, id)
+}
+
+// PaginateAllBooks mocks base method.
+func (m *MockcatalogRepo) PaginateAllBooks(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaginateAllBooks", ctx, param)
+	ret0, _ := ret[0].(book.PaginationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaginateAllBooks indicates an expected call of PaginateAllBooks.
+func (mr *MockcatalogRepoMockRecorder) PaginateAllBooks(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaginateAllBooks", reflect.TypeOf((*MockcatalogRepo)(nil).PaginateAllBooks), ctx, param)
+}
+
+// PaginateAuthors mocks base method.
+func (m *MockcatalogRepo) PaginateAuthors(ctx context.Context, param book.ListParam) (book.AuthorsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaginateAuthors", ctx, param)
+	ret0, _ := ret[0].(book.AuthorsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaginateAuthors indicates an expected call of PaginateAuthors.
+func (mr *MockcatalogRepoMockRecorder) PaginateAuthors(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaginateAuthors", reflect.TypeOf((*MockcatalogRepo)(nil).PaginateAuthors), ctx, param)
+}
+
+// PaginateGenres mocks base method.
+func (m *MockcatalogRepo) PaginateGenres(ctx context.Context, param book.ListParam) (book.GenresResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaginateGenres", ctx, param)
+	ret0, _ := ret[0].(book.GenresResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaginateGenres indicates an expected call of PaginateGenres.
+func (mr *MockcatalogRepoMockRecorder) PaginateGenres(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaginateGenres", reflect.TypeOf((*MockcatalogRepo)(nil).PaginateGenres), ctx, param)
+}
+
+// PaginatePublishers mocks base method.
+func (m *MockcatalogRepo) PaginatePublishers(ctx context.Context, param book.ListParam) (book.PublishersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaginatePublishers", ctx, param)
+	ret0, _ := ret[0].(book.PublishersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaginatePublishers indicates an expected call of PaginatePublishers.
+func (mr *MockcatalogRepoMockRecorder) PaginatePublishers(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaginatePublishers", reflect.TypeOf((*MockcatalogRepo)(nil).PaginatePublishers), ctx, param)
+}
@@ -0,0 +1,113 @@
+package book
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	bookrp "github.com/rendyananta/example-online-book-store/internal/repo/book"
+)
+
+func TestCatalogQueriesUseCase_BooksByAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockcatalogRepo(ctrl)
+
+	type args struct {
+		ctx      context.Context
+		authorID string
+		param    book.PaginationParam
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func()
+		want       book.PaginationResult
+		wantErr    bool
+	}{
+		{
+			name: "can list books of the author",
+			args: args{
+				ctx:      context.Background(),
+				authorID: "1",
+				param: book.PaginationParam{
+					PerPage: 1,
+					Sort:    book.SortPrice,
+					Filter:  book.Filter{AuthorIDs: []string{"2"}, MinRating: 4},
+				},
+			},
+			beforeTest: func() {
+				repoMock.EXPECT().FindAuthorByID(context.Background(), "1").Return(book.Author{ID: "1", Name: "Author A"}, nil)
+				repoMock.EXPECT().PaginateAllBooks(context.Background(), book.PaginationParam{
+					PerPage: 1,
+					Sort:    book.SortPrice,
+					Filter:  book.Filter{AuthorIDs: []string{"1"}, MinRating: 4},
+				}).Return(book.PaginationResult{
+					Data:     []book.Book{{ID: "1", Title: "Book 1"}},
+					PageInfo: pagination.PageInfo{PerPage: 1},
+				}, nil)
+			},
+			want: book.PaginationResult{
+				Data:     []book.Book{{ID: "1", Title: "Book 1"}},
+				PageInfo: pagination.PageInfo{PerPage: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "can handle unknown author",
+			args: args{
+				ctx:      context.Background(),
+				authorID: "1",
+				param:    book.PaginationParam{},
+			},
+			beforeTest: func() {
+				repoMock.EXPECT().FindAuthorByID(context.Background(), "1").Return(book.Author{}, bookrp.ErrNotFound)
+			},
+			want:    book.PaginationResult{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := CatalogQueriesUseCase{repo: repoMock}
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+			got, err := q.BooksByAuthor(tt.args.ctx, tt.args.authorID, tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BooksByAuthor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BooksByAuthor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogQueriesUseCase_BooksByGenreAndPublisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockcatalogRepo(ctrl)
+	q := CatalogQueriesUseCase{repo: repoMock}
+
+	repoMock.EXPECT().FindGenreByID(context.Background(), "g1").Return(book.Genre{ID: "g1"}, nil)
+	repoMock.EXPECT().PaginateAllBooks(context.Background(), book.PaginationParam{
+		Filter: book.Filter{GenreIDs: []string{"g1"}},
+	}).Return(book.PaginationResult{}, nil)
+
+	if _, err := q.BooksByGenre(context.Background(), "g1", book.PaginationParam{}); err != nil {
+		t.Errorf("BooksByGenre() error = %v", err)
+	}
+
+	repoMock.EXPECT().FindPublisherByID(context.Background(), "p1").Return(book.Publisher{}, bookrp.ErrNotFound)
+
+	if _, err := q.BooksByPublisher(context.Background(), "p1", book.PaginationParam{}); err != bookrp.ErrNotFound {
+		t.Errorf("BooksByPublisher() error = %v, want %v", err, bookrp.ErrNotFound)
+	}
+}
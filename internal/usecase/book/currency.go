@@ -0,0 +1,95 @@
+package book
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+//go:generate mockgen -source=currency.go -destination=currency_mock_test.go -package book
+type exchangeRates interface {
+	Rate(ctx context.Context, from, to money.Currency) (money.Rate, error)
+	BaseCurrency() money.Currency
+}
+
+// convertBooks converts the book prices into the currency, the prices are rounded half up.
+// Empty currency keeps the prices in the currency of the books.
+func convertBooks(ctx context.Context, rates exchangeRates, books []book.Book, currency money.Currency) error {
+	if currency == "" {
+		return nil
+	}
+
+	rateByCurrency := make(map[money.Currency]money.Rate)
+	for i, bookItem := range books {
+		from := bookItem.Price.Currency()
+		if from == currency {
+			continue
+		}
+
+		rate, found := rateByCurrency[from]
+		if !found {
+			var err error
+			if rate, err = rates.Rate(ctx, from, currency); err != nil {
+				return err
+			}
+
+			rateByCurrency[from] = rate
+		}
+
+		books[i].Price = bookItem.Price.Convert(currency, rate, money.RoundHalfUp)
+	}
+
+	return nil
+}
+
+// convertPriceFilter converts the price range given in the requested currency into the base currency the catalog
+// is priced in, the range is narrowed to the prices within the range.
+func convertPriceFilter(ctx context.Context, rates exchangeRates, filter book.Filter) (book.Filter, error) {
+	var err error
+	if filter.MinPrice, err = convertPriceBound(ctx, rates, filter.MinPrice, money.RoundCeiling); err != nil {
+		return filter, err
+	}
+
+	if filter.MaxPrice, err = convertPriceBound(ctx, rates, filter.MaxPrice, money.RoundFloor); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func convertPriceBound(ctx context.Context, rates exchangeRates, price money.Money, mode money.RoundingMode) (money.Money, error) {
+	if price.IsZero() || price.Currency() == "" || price.Currency() == rates.BaseCurrency() {
+		return price, nil
+	}
+
+	rate, err := rates.Rate(ctx, price.Currency(), rates.BaseCurrency())
+	if err != nil {
+		return price, err
+	}
+
+	return price.Convert(rates.BaseCurrency(), rate, mode), nil
+}
+
+// paginateInCurrency paginates the books using the price range in the base currency, then converts the listed prices
+// into the requested currency of the param.
+func paginateInCurrency(ctx context.Context, rates exchangeRates, param book.PaginationParam,
+	paginate func(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error)) (book.PaginationResult, error) {
+	filter, err := convertPriceFilter(ctx, rates, param.Filter)
+	if err != nil {
+		return book.PaginationResult{}, err
+	}
+
+	param.Filter = filter
+
+	result, err := paginate(ctx, param)
+	if err != nil {
+		return result, err
+	}
+
+	if err = convertBooks(ctx, rates, result.Data, param.Currency); err != nil {
+		return book.PaginationResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: currency.go
+
+// Package book is a generated GoMock package.
+package book
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	money "github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// MockexchangeRates is a mock of exchangeRates interface.
+type MockexchangeRates struct {
+	ctrl     *gomock.Controller
+	recorder *MockexchangeRatesMockRecorder
+}
+
+// MockexchangeRatesMockRecorder is the mock recorder for MockexchangeRates.
+type MockexchangeRatesMockRecorder struct {
+	mock *MockexchangeRates
+}
+
+// NewMockexchangeRates creates a new mock instance.
+func NewMockexchangeRates(ctrl *gomock.Controller) *MockexchangeRates {
+	mock := &MockexchangeRates{ctrl: ctrl}
+	mock.recorder = &MockexchangeRatesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexchangeRates) EXPECT() *MockexchangeRatesMockRecorder {
+	return m.recorder
+}
+
+// BaseCurrency mocks base method.
+func (m *MockexchangeRates) BaseCurrency() money.Currency {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseCurrency")
+	ret0, _ := ret[0].(money.Currency)
+	return ret0
+}
+
+// BaseCurrency indicates an expected call of BaseCurrency.
+func (mr *MockexchangeRatesMockRecorder) BaseCurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseCurrency", reflect.TypeOf((*MockexchangeRates)(nil).BaseCurrency))
+}
+
+// Rate mocks base method.
+func (m *MockexchangeRates) Rate(ctx context.Context, from, to money.Currency) (money.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(money.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockexchangeRatesMockRecorder) Rate(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockexchangeRates)(nil).Rate), ctx, from, to)
+}
@@ -0,0 +1,106 @@
+package book
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	bookrp "github.com/rendyananta/example-online-book-store/internal/repo/book"
+)
+
+//go:generate mockgen -source=manage.go -destination=manage_repo_mock_test.go -package book
+type bookWriterRepo interface {
+	Create(ctx context.Context, param book.WriteParam) (string, error)
+	Update(ctx context.Context, id string, param book.WriteParam) error
+	SoftDelete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	FindByIDs(ctx context.Context, id []string) ([]book.Book, error)
+	AdjustStock(ctx context.Context, param book.StockAdjustParam) (book.StockAdjustment, error)
+	PaginateStockAdjustments(ctx context.Context, bookID string, param book.ListParam) (book.StockAdjustmentsResult, error)
+}
+
+// ManagementUseCase lets the staff manage the books catalog.
+type ManagementUseCase struct {
+	repo bookWriterRepo
+}
+
+func NewManagementUseCase(repo bookWriterRepo) (*ManagementUseCase, error) {
+	return &ManagementUseCase{repo: repo}, nil
+}
+
+// uniqueIDs removes the repeated ids while keeping their order.
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+		result = append(result, id)
+	}
+
+	return result
+}
+
+func (uc ManagementUseCase) detail(ctx context.Context, id string) (book.Book, error) {
+	books, err := uc.repo.FindByIDs(ctx, []string{id})
+	if err != nil {
+		return book.Book{}, err
+	}
+
+	if len(books) == 0 {
+		return book.Book{}, bookrp.ErrNotFound
+	}
+
+	return books[0], nil
+}
+
+func (uc ManagementUseCase) Create(ctx context.Context, param book.WriteParam) (book.Book, error) {
+	param.AuthorIDs = uniqueIDs(param.AuthorIDs)
+	param.GenreIDs = uniqueIDs(param.GenreIDs)
+
+	id, err := uc.repo.Create(ctx, param)
+	if err != nil {
+		return book.Book{}, err
+	}
+
+	return uc.detail(ctx, id)
+}
+
+func (uc ManagementUseCase) Update(ctx context.Context, id string, param book.WriteParam) (book.Book, error) {
+	param.AuthorIDs = uniqueIDs(param.AuthorIDs)
+	param.GenreIDs = uniqueIDs(param.GenreIDs)
+
+	if err := uc.repo.Update(ctx, id, param); err != nil {
+		return book.Book{}, err
+	}
+
+	return uc.detail(ctx, id)
+}
+
+func (uc ManagementUseCase) Delete(ctx context.Context, id string) error {
+	return uc.repo.SoftDelete(ctx, id)
+}
+
+func (uc ManagementUseCase) Restore(ctx context.Context, id string) (book.Book, error) {
+	if err := uc.repo.Restore(ctx, id); err != nil {
+		return book.Book{}, err
+	}
+
+	return uc.detail(ctx, id)
+}
+
+// AdjustStock puts the books into the stock or takes them out, the adjustment is kept as the audit trail.
+func (uc ManagementUseCase) AdjustStock(ctx context.Context, param book.StockAdjustParam) (book.StockAdjustment, error) {
+	return uc.repo.AdjustStock(ctx, param)
+}
+
+func (uc ManagementUseCase) StockAdjustments(ctx context.Context, bookID string, param book.ListParam) (book.StockAdjustmentsResult, error) {
+	if _, err := uc.detail(ctx, bookID); err != nil {
+		return book.StockAdjustmentsResult{}, err
+	}
+
+	return uc.repo.PaginateStockAdjustments(ctx, bookID, param)
+}
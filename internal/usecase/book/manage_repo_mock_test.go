@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: manage.go
+
+// Package book is a generated GoMock package.
+package book
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	book "github.com/rendyananta/example-online-book-store/internal/entity/book"
+)
+
+// MockbookWriterRepo is a mock of bookWriterRepo interface.
+type MockbookWriterRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookWriterRepoMockRecorder
+}
+
+// MockbookWriterRepoMockRecorder is the mock recorder for MockbookWriterRepo.
+type MockbookWriterRepoMockRecorder struct {
+	mock *MockbookWriterRepo
+}
+
+// NewMockbookWriterRepo creates a new mock instance.
+func NewMockbookWriterRepo(ctrl *gomock.Controller) *MockbookWriterRepo {
+	mock := &MockbookWriterRepo{ctrl: ctrl}
+	mock.recorder = &MockbookWriterRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookWriterRepo) EXPECT() *MockbookWriterRepoMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockbookWriterRepo) AdjustStock(ctx context.Context, param book.StockAdjustParam) (book.StockAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, param)
+	ret0, _ := ret[0].(book.StockAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockbookWriterRepoMockRecorder) AdjustStock(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockbookWriterRepo)(nil).AdjustStock), ctx, param)
+}
+
+// Create mocks base method.
+func (m *MockbookWriterRepo) Create(ctx context.Context, param book.WriteParam) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, param)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockbookWriterRepoMockRecorder) Create(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockbookWriterRepo)(nil).Create), ctx, param)
+}
+
+// FindByIDs mocks base method.
+func (m *MockbookWriterRepo) FindByIDs(ctx context.Context, id []string) ([]book.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, id)
+	ret0, _ := ret[0].([]book.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockbookWriterRepoMockRecorder) FindByIDs(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockbookWriterRepo)(nil).FindByIDs), ctx, id)
+}
+
+// PaginateStockAdjustments mocks base method.
+func (m *MockbookWriterRepo) PaginateStockAdjustments(ctx context.Context, bookID string, param book.ListParam) (book.StockAdjustmentsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaginateStockAdjustments", ctx, bookID, param)
+	ret0, _ := ret[0].(book.StockAdjustmentsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaginateStockAdjustments indicates an expected call of PaginateStockAdjustments.
+func (mr *MockbookWriterRepoMockRecorder) PaginateStockAdjustments(ctx, bookID, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaginateStockAdjustments", reflect.TypeOf((*MockbookWriterRepo)(nil).PaginateStockAdjustments), ctx, bookID, param)
+}
+
+// Restore mocks base method.
+func (m *MockbookWriterRepo) Restore(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockbookWriterRepoMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockbookWriterRepo)(nil).Restore), ctx, id)
+}
+
+// SoftDelete mocks base method.
+func (m *MockbookWriterRepo) SoftDelete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockbookWriterRepoMockRecorder) SoftDelete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockbookWriterRepo)(nil).SoftDelete), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockbookWriterRepo) Update(ctx context.Context, id string, param book.WriteParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockbookWriterRepoMockRecorder) Update(ctx, id, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockbookWriterRepo)(nil).Update), ctx, id, param)
+}
@@ -0,0 +1,192 @@
+package book
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	bookrp "github.com/rendyananta/example-online-book-store/internal/repo/book"
+)
+
+func TestManagementUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockbookWriterRepo(ctrl)
+
+	type args struct {
+		ctx   context.Context
+		param book.WriteParam
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func()
+		want       book.Book
+		wantErr    bool
+	}{
+		{
+			name: "can create book with unique authors and genres",
+			args: args{
+				ctx: context.Background(),
+				param: book.WriteParam{
+					Title:       "Book 1",
+					PublisherID: "1",
+					AuthorIDs:   []string{"1", "2", "1"},
+					GenreIDs:    []string{"3", "3"},
+				},
+			},
+			beforeTest: func() {
+				repoMock.EXPECT().Create(context.Background(), book.WriteParam{
+					Title:       "Book 1",
+					PublisherID: "1",
+					AuthorIDs:   []string{"1", "2"},
+					GenreIDs:    []string{"3"},
+				}).Return("10", nil)
+				repoMock.EXPECT().FindByIDs(context.Background(), []string{"10"}).Return([]book.Book{
+					{ID: "10", Title: "Book 1"},
+				}, nil)
+			},
+			want:    book.Book{ID: "10", Title: "Book 1"},
+			wantErr: false,
+		},
+		{
+			name: "can handle invalid reference",
+			args: args{
+				ctx:   context.Background(),
+				param: book.WriteParam{Title: "Book 1", PublisherID: "1"},
+			},
+			beforeTest: func() {
+				repoMock.EXPECT().Create(context.Background(), book.WriteParam{
+					Title:       "Book 1",
+					PublisherID: "1",
+					AuthorIDs:   []string{},
+					GenreIDs:    []string{},
+				}).Return("", bookrp.ErrInvalidReference)
+			},
+			want:    book.Book{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ManagementUseCase{repo: repoMock}
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+			got, err := uc.Create(tt.args.ctx, tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Create() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManagementUseCase_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockbookWriterRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		id         string
+		beforeTest func()
+		want       book.Book
+		wantErr    bool
+	}{
+		{
+			name: "can restore book",
+			id:   "1",
+			beforeTest: func() {
+				repoMock.EXPECT().Restore(context.Background(), "1").Return(nil)
+				repoMock.EXPECT().FindByIDs(context.Background(), []string{"1"}).Return([]book.Book{{ID: "1"}}, nil)
+			},
+			want:    book.Book{ID: "1"},
+			wantErr: false,
+		},
+		{
+			name: "can handle restore error",
+			id:   "1",
+			beforeTest: func() {
+				repoMock.EXPECT().Restore(context.Background(), "1").Return(sql.ErrConnDone)
+			},
+			want:    book.Book{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ManagementUseCase{repo: repoMock}
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+			got, err := uc.Restore(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Restore() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Restore() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManagementUseCase_StockAdjustments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockbookWriterRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		id         string
+		beforeTest func()
+		want       book.StockAdjustmentsResult
+		wantErr    bool
+	}{
+		{
+			name: "can list stock adjustments",
+			id:   "1",
+			beforeTest: func() {
+				repoMock.EXPECT().FindByIDs(context.Background(), []string{"1"}).Return([]book.Book{{ID: "1"}}, nil)
+				repoMock.EXPECT().PaginateStockAdjustments(context.Background(), "1", book.ListParam{PerPage: 10}).
+					Return(book.StockAdjustmentsResult{Data: []book.StockAdjustment{{ID: "2", BookID: "1", Quantity: 5}}}, nil)
+			},
+			want: book.StockAdjustmentsResult{Data: []book.StockAdjustment{{ID: "2", BookID: "1", Quantity: 5}}},
+		},
+		{
+			name: "can handle unknown book",
+			id:   "1",
+			beforeTest: func() {
+				repoMock.EXPECT().FindByIDs(context.Background(), []string{"1"}).Return(nil, nil)
+			},
+			want:    book.StockAdjustmentsResult{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := ManagementUseCase{repo: repoMock}
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+			got, err := uc.StockAdjustments(context.Background(), tt.id, book.ListParam{PerPage: 10})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StockAdjustments() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StockAdjustments() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
 	bookrp "github.com/rendyananta/example-online-book-store/internal/repo/book"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 )
 
 //go:generate mockgen -source=query.go -destination=repo_mock_test.go -package book
@@ -13,23 +14,27 @@ type bookRepo interface {
 	PaginateBookSearch(ctx context.Context, searchQuery string, param book.PaginationParam) (book.PaginationResult, error)
 }
 
-// QueriesUseCase only act as a proxy.
+// QueriesUseCase only act as a proxy, besides converting the prices into the requested currency.
 type QueriesUseCase struct {
-	repo bookRepo
+	repo  bookRepo
+	rates exchangeRates
 }
 
-func NewQueryUseCase(repo bookRepo) (*QueriesUseCase, error) {
-	return &QueriesUseCase{repo: repo}, nil
+func NewQueryUseCase(repo bookRepo, rates exchangeRates) (*QueriesUseCase, error) {
+	return &QueriesUseCase{repo: repo, rates: rates}, nil
 }
 
 func (q QueriesUseCase) GetAll(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error) {
-	return q.repo.PaginateAllBooks(ctx, param)
+	return paginateInCurrency(ctx, q.rates, param, q.repo.PaginateAllBooks)
 }
 func (q QueriesUseCase) Search(ctx context.Context, searchQuery string, param book.PaginationParam) (book.PaginationResult, error) {
-	return q.repo.PaginateBookSearch(ctx, searchQuery, param)
+	return paginateInCurrency(ctx, q.rates, param, func(ctx context.Context, param book.PaginationParam) (book.PaginationResult, error) {
+		return q.repo.PaginateBookSearch(ctx, searchQuery, param)
+	})
 }
 
-func (q QueriesUseCase) DetailByID(ctx context.Context, id string) (book.Book, error) {
+// DetailByID finds the book with its price in the currency, empty currency keeps the price of the book.
+func (q QueriesUseCase) DetailByID(ctx context.Context, id string, currency money.Currency) (book.Book, error) {
 	bookItem, err := q.repo.FindByIDs(ctx, []string{id})
 	if err != nil {
 		return book.Book{}, err
@@ -39,5 +44,9 @@ func (q QueriesUseCase) DetailByID(ctx context.Context, id string) (book.Book, e
 		return book.Book{}, bookrp.ErrNotFound
 	}
 
+	if err = convertBooks(ctx, q.rates, bookItem, currency); err != nil {
+		return book.Book{}, err
+	}
+
 	return bookItem[0], nil
 }
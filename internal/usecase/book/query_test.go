@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"github.com/golang/mock/gomock"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"reflect"
 	"testing"
 )
@@ -14,9 +16,11 @@ func TestNewQueryUseCase(t *testing.T) {
 	defer ctrl.Finish()
 
 	repoMock := NewMockbookRepo(ctrl)
+	ratesMock := NewMockexchangeRates(ctrl)
 
 	type args struct {
-		repo bookRepo
+		repo  bookRepo
+		rates exchangeRates
 	}
 	tests := []struct {
 		name    string
@@ -26,16 +30,17 @@ func TestNewQueryUseCase(t *testing.T) {
 	}{
 		{
 			name: "can init",
-			args: args{repo: repoMock},
+			args: args{repo: repoMock, rates: ratesMock},
 			want: &QueriesUseCase{
-				repo: repoMock,
+				repo:  repoMock,
+				rates: ratesMock,
 			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewQueryUseCase(tt.args.repo)
+			got, err := NewQueryUseCase(tt.args.repo, tt.args.rates)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewQueryUseCase() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -52,13 +57,17 @@ func TestQueriesUseCase_DetailByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	repoMock := NewMockbookRepo(ctrl)
+	ratesMock := NewMockexchangeRates(ctrl)
+	idrRate, _ := money.ParseRate("16000")
 
 	type fields struct {
-		repo bookRepo
+		repo  bookRepo
+		rates exchangeRates
 	}
 	type args struct {
-		ctx context.Context
-		id  string
+		ctx      context.Context
+		id       string
+		currency money.Currency
 	}
 	tests := []struct {
 		name       string
@@ -83,7 +92,7 @@ func TestQueriesUseCase_DetailByID(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5,
+						Price:       money.New(500, money.USD),
 						ISBN:        "12345",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -108,7 +117,7 @@ func TestQueriesUseCase_DetailByID(t *testing.T) {
 				ID:          "1",
 				Title:       "Book 1",
 				Description: "Desc",
-				Price:       5,
+				Price:       money.New(500, money.USD),
 				ISBN:        "12345",
 				Publisher: book.Publisher{
 					ID:   "2",
@@ -129,6 +138,26 @@ func TestQueriesUseCase_DetailByID(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "can get detail in the requested currency",
+			fields: fields{
+				repo:  repoMock,
+				rates: ratesMock,
+			},
+			args: args{
+				ctx:      context.Background(),
+				id:       "1",
+				currency: money.IDR,
+			},
+			beforeTest: func() {
+				repoMock.EXPECT().FindByIDs(context.Background(), []string{"1"}).Return([]book.Book{
+					{ID: "1", Title: "Book 1", Price: money.New(509, money.USD)},
+				}, nil)
+				ratesMock.EXPECT().Rate(context.Background(), money.USD, money.IDR).Return(idrRate, nil)
+			},
+			want:    book.Book{ID: "1", Title: "Book 1", Price: money.New(8144000, money.IDR)},
+			wantErr: false,
+		},
 		{
 			name: "can handle error get detail by id",
 			fields: fields{
@@ -144,7 +173,7 @@ func TestQueriesUseCase_DetailByID(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5,
+						Price:       money.New(500, money.USD),
 						ISBN:        "12345",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -167,12 +196,13 @@ func TestQueriesUseCase_DetailByID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			q := QueriesUseCase{
-				repo: tt.fields.repo,
+				repo:  tt.fields.repo,
+				rates: tt.fields.rates,
 			}
 			if tt.beforeTest != nil {
 				tt.beforeTest()
 			}
-			got, err := q.DetailByID(tt.args.ctx, tt.args.id)
+			got, err := q.DetailByID(tt.args.ctx, tt.args.id, tt.args.currency)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DetailByID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -212,20 +242,20 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 				ctx: context.Background(),
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
 				repoMock.EXPECT().PaginateAllBooks(context.Background(), book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				}).Return(book.PaginationResult{
 					Data: []book.Book{
 						{
 							ID:          "1",
 							Title:       "Book 1",
 							Description: "Desc",
-							Price:       5,
+							Price:       money.New(500, money.USD),
 							ISBN:        "12345",
 							Publisher: book.Publisher{
 								ID:   "2",
@@ -245,8 +275,7 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 							},
 						},
 					},
-					PerPage: 1,
-					LastID:  "1",
+					PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 				}, nil)
 			},
 			want: book.PaginationResult{
@@ -255,7 +284,7 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5,
+						Price:       money.New(500, money.USD),
 						ISBN:        "12345",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -275,8 +304,7 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 			},
 			wantErr: false,
 		},
@@ -287,20 +315,20 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 				ctx: context.Background(),
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
 				repoMock.EXPECT().PaginateAllBooks(context.Background(), book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				}).Return(book.PaginationResult{
 					Data: []book.Book{
 						{
 							ID:          "1",
 							Title:       "Book 1",
 							Description: "Desc",
-							Price:       5,
+							Price:       money.New(500, money.USD),
 							ISBN:        "12345",
 							Publisher: book.Publisher{
 								ID:   "2",
@@ -314,8 +342,7 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 							},
 						},
 					},
-					PerPage: 1,
-					LastID:  "1",
+					PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 				}, sql.ErrConnDone)
 			},
 			want: book.PaginationResult{
@@ -324,7 +351,7 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5,
+						Price:       money.New(500, money.USD),
 						ISBN:        "12345",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -338,8 +365,7 @@ func TestQueriesUseCase_GetAll(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 			},
 			wantErr: true,
 		},
@@ -396,20 +422,20 @@ func TestQueriesUseCase_Search(t *testing.T) {
 				searchQuery: "potter",
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
 				repoMock.EXPECT().PaginateBookSearch(context.Background(), "potter", book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				}).Return(book.PaginationResult{
 					Data: []book.Book{
 						{
 							ID:          "1",
 							Title:       "Book 1",
 							Description: "Desc",
-							Price:       5,
+							Price:       money.New(500, money.USD),
 							ISBN:        "12345",
 							Publisher: book.Publisher{
 								ID:   "2",
@@ -429,8 +455,7 @@ func TestQueriesUseCase_Search(t *testing.T) {
 							},
 						},
 					},
-					PerPage: 1,
-					LastID:  "1",
+					PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 				}, nil)
 			},
 			want: book.PaginationResult{
@@ -439,7 +464,7 @@ func TestQueriesUseCase_Search(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5,
+						Price:       money.New(500, money.USD),
 						ISBN:        "12345",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -459,8 +484,7 @@ func TestQueriesUseCase_Search(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 			},
 			wantErr: false,
 		},
@@ -472,20 +496,20 @@ func TestQueriesUseCase_Search(t *testing.T) {
 				searchQuery: "potter",
 				param: book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				},
 			},
 			beforeTest: func() {
 				repoMock.EXPECT().PaginateBookSearch(context.Background(), "potter", book.PaginationParam{
 					PerPage: 1,
-					LastID:  "",
+					Cursor:  "",
 				}).Return(book.PaginationResult{
 					Data: []book.Book{
 						{
 							ID:          "1",
 							Title:       "Book 1",
 							Description: "Desc",
-							Price:       5,
+							Price:       money.New(500, money.USD),
 							ISBN:        "12345",
 							Publisher: book.Publisher{
 								ID:   "2",
@@ -499,8 +523,7 @@ func TestQueriesUseCase_Search(t *testing.T) {
 							},
 						},
 					},
-					PerPage: 1,
-					LastID:  "1",
+					PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 				}, sql.ErrConnDone)
 			},
 			want: book.PaginationResult{
@@ -509,7 +532,7 @@ func TestQueriesUseCase_Search(t *testing.T) {
 						ID:          "1",
 						Title:       "Book 1",
 						Description: "Desc",
-						Price:       5,
+						Price:       money.New(500, money.USD),
 						ISBN:        "12345",
 						Publisher: book.Publisher{
 							ID:   "2",
@@ -523,8 +546,7 @@ func TestQueriesUseCase_Search(t *testing.T) {
 						},
 					},
 				},
-				PerPage: 1,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 1, Next: "1", HasMore: true},
 			},
 			wantErr: true,
 		},
@@ -548,3 +570,37 @@ func TestQueriesUseCase_Search(t *testing.T) {
 		})
 	}
 }
+
+func TestQueriesUseCase_GetAll_currency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockbookRepo(ctrl)
+	ratesMock := NewMockexchangeRates(ctrl)
+	q := QueriesUseCase{repo: repoMock, rates: ratesMock}
+
+	toUSD, _ := money.ParseRate("0.0000625")
+	toIDR, _ := money.ParseRate("16000")
+
+	ratesMock.EXPECT().BaseCurrency().Return(money.USD).AnyTimes()
+	ratesMock.EXPECT().Rate(context.Background(), money.IDR, money.USD).Return(toUSD, nil).Times(2)
+	ratesMock.EXPECT().Rate(context.Background(), money.USD, money.IDR).Return(toIDR, nil)
+
+	// the price range is narrowed to the whole cents, 50000.50 IDR is 3.12503125 USD.
+	repoMock.EXPECT().PaginateAllBooks(context.Background(), book.PaginationParam{
+		Filter:   book.Filter{MinPrice: money.New(313, money.USD), MaxPrice: money.New(625, money.USD)},
+		Currency: money.IDR,
+	}).Return(book.PaginationResult{Data: []book.Book{{ID: "1", Price: money.New(509, money.USD)}}}, nil)
+
+	got, err := q.GetAll(context.Background(), book.PaginationParam{
+		Filter:   book.Filter{MinPrice: money.New(5000050, money.IDR), MaxPrice: money.New(10000000, money.IDR)},
+		Currency: money.IDR,
+	})
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+
+	if !got.Data[0].Price.Equal(money.New(8144000, money.IDR)) {
+		t.Errorf("GetAll() got = %v, want the price in IDR", got.Data[0].Price)
+	}
+}
@@ -0,0 +1,243 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/cart"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+//go:generate mockgen -source=cart.go -destination=repo_mock_test.go -package cart
+type cartRepo interface {
+	Create(ctx context.Context, userID string) (cart.Cart, error)
+	FindByID(ctx context.Context, id string) (cart.Cart, error)
+	FindByUserID(ctx context.Context, userID string) (cart.Cart, error)
+	AddItem(ctx context.Context, cartID string, param cart.ItemParam) error
+	SetItem(ctx context.Context, cartID string, param cart.ItemParam) error
+	RemoveItem(ctx context.Context, cartID string, bookID string) error
+	Clear(ctx context.Context, cartID string) error
+	Merge(ctx context.Context, guestCartID string, userCartID string) error
+}
+
+type bookRepo interface {
+	FindByIDs(ctx context.Context, bookIDs []string) ([]book.Book, error)
+}
+
+type placeOrderUseCase interface {
+	PlaceOrder(ctx context.Context, param order.Main) (order.Main, error)
+}
+
+var (
+	ErrNotFound    = cart.ErrNotFound
+	ErrEmpty       = cart.ErrEmpty
+	ErrInvalidItem = cart.ErrInvalidItem
+)
+
+type UseCase struct {
+	cartRepo   cartRepo
+	bookRepo   bookRepo
+	placeOrder placeOrderUseCase
+}
+
+func NewCartUseCase(cartRepo cartRepo, bookRepo bookRepo, placeOrder placeOrderUseCase) (*UseCase, error) {
+	return &UseCase{
+		cartRepo:   cartRepo,
+		bookRepo:   bookRepo,
+		placeOrder: placeOrder,
+	}, nil
+}
+
+// CreateGuest creates the cart for the user who is not logged in yet, it is merged into the user cart at login.
+func (uc UseCase) CreateGuest(ctx context.Context) (cart.Cart, error) {
+	return uc.cartRepo.Create(ctx, "")
+}
+
+func (uc UseCase) Get(ctx context.Context, owner cart.Owner) (cart.Cart, error) {
+	c, err := uc.resolve(ctx, owner)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+
+	return uc.price(ctx, c)
+}
+
+// AddItem adds the quantity of the book into the cart.
+func (uc UseCase) AddItem(ctx context.Context, owner cart.Owner, param cart.ItemParam) (cart.Cart, error) {
+	return uc.putItem(ctx, owner, param, uc.cartRepo.AddItem)
+}
+
+// UpdateItem replaces the quantity of the book in the cart.
+func (uc UseCase) UpdateItem(ctx context.Context, owner cart.Owner, param cart.ItemParam) (cart.Cart, error) {
+	return uc.putItem(ctx, owner, param, uc.cartRepo.SetItem)
+}
+
+func (uc UseCase) putItem(ctx context.Context, owner cart.Owner, param cart.ItemParam,
+	put func(ctx context.Context, cartID string, param cart.ItemParam) error) (cart.Cart, error) {
+	c, err := uc.resolve(ctx, owner)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+
+	books, err := uc.bookRepo.FindByIDs(ctx, []string{param.BookID})
+	if err != nil {
+		return cart.Cart{}, err
+	}
+
+	if len(books) == 0 {
+		return cart.Cart{}, ErrInvalidItem
+	}
+
+	if err = put(ctx, c.ID, param); err != nil {
+		return cart.Cart{}, err
+	}
+
+	return uc.Get(ctx, owner)
+}
+
+func (uc UseCase) RemoveItem(ctx context.Context, owner cart.Owner, bookID string) (cart.Cart, error) {
+	c, err := uc.resolve(ctx, owner)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+
+	if err = uc.cartRepo.RemoveItem(ctx, c.ID, bookID); err != nil {
+		return cart.Cart{}, err
+	}
+
+	return uc.Get(ctx, owner)
+}
+
+// Checkout places the order of the user cart items to the shipping address along with the voucher codes,
+// the cart is emptied once the order is placed.
+func (uc UseCase) Checkout(ctx context.Context, userID string, param cart.CheckoutParam) (order.Main, error) {
+	c, err := uc.resolve(ctx, cart.Owner{UserID: userID})
+	if err != nil {
+		return order.Main{}, err
+	}
+
+	if len(c.Items) == 0 {
+		return order.Main{}, ErrEmpty
+	}
+
+	lines := make([]order.Line, 0, len(c.Items))
+	for _, item := range c.Items {
+		lines = append(lines, order.Line{
+			LineReferenceType: order.LineReferenceTypeBook,
+			LineReferenceID:   item.BookID,
+			Quantity:          item.Quantity,
+		})
+	}
+
+	placed, err := uc.placeOrder.PlaceOrder(ctx, order.Main{
+		UserID:            userID,
+		Lines:             lines,
+		VoucherCodes:      param.VoucherCodes,
+		ShippingAddressID: param.ShippingAddressID,
+		Currency:          param.Currency,
+	})
+	if err != nil {
+		return order.Main{}, err
+	}
+
+	if err = uc.cartRepo.Clear(ctx, c.ID); err != nil {
+		slog.Error("cannot clear the checked out cart", slog.String("err", err.Error()), slog.String("cart_id", c.ID))
+	}
+
+	return placed, nil
+}
+
+// Merge moves the guest cart items into the user cart, the guest cart is removed afterward.
+func (uc UseCase) Merge(ctx context.Context, guestCartID string, userID string) error {
+	guest, err := uc.resolve(ctx, cart.Owner{CartID: guestCartID})
+	if err != nil {
+		return err
+	}
+
+	userCart, err := uc.resolve(ctx, cart.Owner{UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	return uc.cartRepo.Merge(ctx, guest.ID, userCart.ID)
+}
+
+// resolve finds the cart of the owner, the user cart is created on the first use.
+// The cart of a user is never resolved as the guest cart.
+func (uc UseCase) resolve(ctx context.Context, owner cart.Owner) (cart.Cart, error) {
+	if owner.UserID == "" {
+		c, err := uc.cartRepo.FindByID(ctx, owner.CartID)
+		if err != nil {
+			return cart.Cart{}, err
+		}
+
+		if c.UserID != "" {
+			return cart.Cart{}, ErrNotFound
+		}
+
+		return c, nil
+	}
+
+	c, err := uc.cartRepo.FindByUserID(ctx, owner.UserID)
+	if !errors.Is(err, ErrNotFound) {
+		return c, err
+	}
+
+	c, err = uc.cartRepo.Create(ctx, owner.UserID)
+	if err != nil {
+		// the cart might be created by the concurrent request of the same user.
+		return uc.cartRepo.FindByUserID(ctx, owner.UserID)
+	}
+
+	return c, nil
+}
+
+// price fills the cart items using the current book prices, same as the order placement rounding.
+func (uc UseCase) price(ctx context.Context, c cart.Cart) (cart.Cart, error) {
+	if len(c.Items) == 0 {
+		return c, nil
+	}
+
+	bookIDs := make([]string, 0, len(c.Items))
+	for _, item := range c.Items {
+		bookIDs = append(bookIDs, item.BookID)
+	}
+
+	books, err := uc.bookRepo.FindByIDs(ctx, bookIDs)
+	if err != nil {
+		return cart.Cart{}, err
+	}
+
+	bookByID := make(map[string]book.Book)
+	for _, bookItem := range books {
+		bookByID[bookItem.ID] = bookItem
+	}
+
+	var total money.Money
+	for i, item := range c.Items {
+		bookItem, bookExist := bookByID[item.BookID]
+		if !bookExist {
+			continue
+		}
+
+		// the cart total is shown in a single currency, the same way as the order is priced.
+		if !total.IsZero() && total.Currency() != bookItem.Price.Currency() {
+			return cart.Cart{}, order.ErrCurrencyMismatch
+		}
+
+		subtotal := bookItem.Price.Mul(int64(item.Quantity))
+
+		c.Items[i].Book = &bookItem
+		c.Items[i].Price = bookItem.Price
+		c.Items[i].Subtotal = subtotal
+		c.Items[i].Available = true
+		total = total.Add(subtotal)
+	}
+
+	c.Total = total
+
+	return c, nil
+}
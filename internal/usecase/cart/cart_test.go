@@ -0,0 +1,260 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/cart"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestUseCase_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cartRepoMock := NewMockcartRepo(ctrl)
+	bookRepoMock := NewMockbookRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		owner      cart.Owner
+		beforeTest func()
+		want       cart.Cart
+		wantErr    error
+	}{
+		{
+			name:  "can price the user cart using the current book prices",
+			owner: cart.Owner{UserID: "1"},
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByUserID(context.Background(), "1").Return(cart.Cart{
+					ID:     "10",
+					UserID: "1",
+					Items:  []cart.Item{{BookID: "1", Quantity: 3}, {BookID: "2", Quantity: 1}},
+				}, nil)
+				bookRepoMock.EXPECT().FindByIDs(context.Background(), []string{"1", "2"}).
+					Return([]book.Book{{ID: "1", Price: money.New(150, money.USD)}}, nil)
+			},
+			want: cart.Cart{
+				ID:     "10",
+				UserID: "1",
+				Items: []cart.Item{
+					{BookID: "1", Book: &book.Book{ID: "1", Price: money.New(150, money.USD)}, Quantity: 3, Price: money.New(150, money.USD), Subtotal: money.New(450, money.USD), Available: true},
+					{BookID: "2", Quantity: 1},
+				},
+				Total: money.New(450, money.USD),
+			},
+		},
+		{
+			name:  "can create the user cart on the first use",
+			owner: cart.Owner{UserID: "1"},
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByUserID(context.Background(), "1").Return(cart.Cart{}, ErrNotFound)
+				cartRepoMock.EXPECT().Create(context.Background(), "1").Return(cart.Cart{ID: "10", UserID: "1", Items: []cart.Item{}}, nil)
+			},
+			want: cart.Cart{ID: "10", UserID: "1", Items: []cart.Item{}},
+		},
+		{
+			name:  "can get the guest cart",
+			owner: cart.Owner{CartID: "20"},
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByID(context.Background(), "20").Return(cart.Cart{ID: "20", Items: []cart.Item{}}, nil)
+			},
+			want: cart.Cart{ID: "20", Items: []cart.Item{}},
+		},
+		{
+			name:  "can hide the user cart from the guest",
+			owner: cart.Owner{CartID: "10"},
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByID(context.Background(), "10").Return(cart.Cart{ID: "10", UserID: "1"}, nil)
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewCartUseCase(cartRepoMock, bookRepoMock, nil)
+			got, err := uc.Get(context.Background(), tt.owner)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCase_AddItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cartRepoMock := NewMockcartRepo(ctrl)
+	bookRepoMock := NewMockbookRepo(ctrl)
+
+	guestCart := cart.Cart{ID: "20", Items: []cart.Item{}}
+
+	tests := []struct {
+		name       string
+		param      cart.ItemParam
+		beforeTest func()
+		wantErr    error
+	}{
+		{
+			name:  "can add the book",
+			param: cart.ItemParam{BookID: "1", Quantity: 2},
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByID(context.Background(), "20").Return(guestCart, nil).Times(2)
+				bookRepoMock.EXPECT().FindByIDs(context.Background(), []string{"1"}).Return([]book.Book{{ID: "1"}}, nil)
+				cartRepoMock.EXPECT().AddItem(context.Background(), "20", cart.ItemParam{BookID: "1", Quantity: 2}).Return(nil)
+			},
+		},
+		{
+			name:  "can reject unknown book",
+			param: cart.ItemParam{BookID: "9", Quantity: 1},
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByID(context.Background(), "20").Return(guestCart, nil)
+				bookRepoMock.EXPECT().FindByIDs(context.Background(), []string{"9"}).Return([]book.Book{}, nil)
+			},
+			wantErr: ErrInvalidItem,
+		},
+		{
+			name:  "can handle repo error",
+			param: cart.ItemParam{BookID: "1", Quantity: 1},
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByID(context.Background(), "20").Return(guestCart, nil)
+				bookRepoMock.EXPECT().FindByIDs(context.Background(), []string{"1"}).Return([]book.Book{{ID: "1"}}, nil)
+				cartRepoMock.EXPECT().AddItem(context.Background(), "20", gomock.Any()).Return(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewCartUseCase(cartRepoMock, bookRepoMock, nil)
+			_, err := uc.AddItem(context.Background(), cart.Owner{CartID: "20"}, tt.param)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseCase_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cartRepoMock := NewMockcartRepo(ctrl)
+	placeOrderMock := NewMockplaceOrderUseCase(ctrl)
+
+	tests := []struct {
+		name       string
+		beforeTest func()
+		want       order.Main
+		wantErr    error
+	}{
+		{
+			name: "can place the order of the cart items",
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByUserID(context.Background(), "1").Return(cart.Cart{
+					ID:     "10",
+					UserID: "1",
+					Items:  []cart.Item{{BookID: "1", Quantity: 3}},
+				}, nil)
+				placeOrderMock.EXPECT().PlaceOrder(context.Background(), order.Main{
+					UserID:            "1",
+					Lines:             []order.Line{{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "1", Quantity: 3}},
+					VoucherCodes:      []string{"WELCOME10"},
+					ShippingAddressID: "a1",
+				}).Return(order.Main{ID: "100", UserID: "1"}, nil)
+				cartRepoMock.EXPECT().Clear(context.Background(), "10").Return(nil)
+			},
+			want: order.Main{ID: "100", UserID: "1"},
+		},
+		{
+			name: "can reject empty cart",
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByUserID(context.Background(), "1").Return(cart.Cart{ID: "10", UserID: "1"}, nil)
+			},
+			wantErr: ErrEmpty,
+		},
+		{
+			name: "can keep the cart when the order is rejected",
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByUserID(context.Background(), "1").Return(cart.Cart{
+					ID:     "10",
+					UserID: "1",
+					Items:  []cart.Item{{BookID: "1", Quantity: 300}},
+				}, nil)
+				placeOrderMock.EXPECT().PlaceOrder(context.Background(), gomock.Any()).Return(order.Main{}, book.ErrInsufficientStock)
+			},
+			wantErr: book.ErrInsufficientStock,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewCartUseCase(cartRepoMock, nil, placeOrderMock)
+			got, err := uc.Checkout(context.Background(), "1", cart.CheckoutParam{ShippingAddressID: "a1", VoucherCodes: []string{"WELCOME10"}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Checkout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Checkout() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCase_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cartRepoMock := NewMockcartRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		beforeTest func()
+		wantErr    error
+	}{
+		{
+			name: "can merge the guest cart into the user cart",
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByID(context.Background(), "20").Return(cart.Cart{ID: "20"}, nil)
+				cartRepoMock.EXPECT().FindByUserID(context.Background(), "1").Return(cart.Cart{ID: "10", UserID: "1"}, nil)
+				cartRepoMock.EXPECT().Merge(context.Background(), "20", "10").Return(nil)
+			},
+		},
+		{
+			name: "can ignore the cart of other user",
+			beforeTest: func() {
+				cartRepoMock.EXPECT().FindByID(context.Background(), "20").Return(cart.Cart{ID: "20", UserID: "2"}, nil)
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewCartUseCase(cartRepoMock, nil, nil)
+			if err := uc.Merge(context.Background(), "20", "1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Merge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
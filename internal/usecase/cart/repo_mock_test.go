@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cart.go
+
+// Package cart is a generated GoMock package.
+package cart
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	book "github.com/rendyananta/example-online-book-store/internal/entity/book"
+	cart "github.com/rendyananta/example-online-book-store/internal/entity/cart"
+	order "github.com/rendyananta/example-online-book-store/internal/entity/order"
+)
+
+// MockcartRepo is a mock of cartRepo interface.
+type MockcartRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockcartRepoMockRecorder
+}
+
+// MockcartRepoMockRecorder is the mock recorder for MockcartRepo.
+type MockcartRepoMockRecorder struct {
+	mock *MockcartRepo
+}
+
+// NewMockcartRepo creates a new mock instance.
+func NewMockcartRepo(ctrl *gomock.Controller) *MockcartRepo {
+	mock := &MockcartRepo{ctrl: ctrl}
+	mock.recorder = &MockcartRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcartRepo) EXPECT() *MockcartRepoMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockcartRepo) AddItem(ctx context.Context, cartID string, param cart.ItemParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, cartID, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockcartRepoMockRecorder) AddItem(ctx, cartID, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockcartRepo)(nil).AddItem), ctx, cartID, param)
+}
+
+// Clear mocks base method.
+func (m *MockcartRepo) Clear(ctx context.Context, cartID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, cartID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockcartRepoMockRecorder) Clear(ctx, cartID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockcartRepo)(nil).Clear), ctx, cartID)
+}
+
+// Create mocks base method.
+func (m *MockcartRepo) Create(ctx context.Context, userID string) (cart.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID)
+	ret0, _ := ret[0].(cart.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockcartRepoMockRecorder) Create(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockcartRepo)(nil).Create), ctx, userID)
+}
+
+// FindByID mocks base method.
+func (m *MockcartRepo) FindByID(ctx context.Context, id string) (cart.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(cart.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockcartRepoMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockcartRepo)(nil).FindByID), ctx, id)
+}
+
+// FindByUserID mocks base method.
+func (m *MockcartRepo) FindByUserID(ctx context.Context, userID string) (cart.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].(cart.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockcartRepoMockRecorder) FindByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockcartRepo)(nil).FindByUserID), ctx, userID)
+}
+
+// Merge mocks base method.
+func (m *MockcartRepo) Merge(ctx context.Context, guestCartID, userCartID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", ctx, guestCartID, userCartID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockcartRepoMockRecorder) Merge(ctx, guestCartID, userCartID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockcartRepo)(nil).Merge), ctx, guestCartID, userCartID)
+}
+
+// RemoveItem mocks base method.
+func (m *MockcartRepo) RemoveItem(ctx context.Context, cartID, bookID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", ctx, cartID, bookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockcartRepoMockRecorder) RemoveItem(ctx, cartID, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockcartRepo)(nil).RemoveItem), ctx, cartID, bookID)
+}
+
+// SetItem mocks base method.
+func (m *MockcartRepo) SetItem(ctx context.Context, cartID string, param cart.ItemParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", ctx, cartID, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockcartRepoMockRecorder) SetItem(ctx, cartID, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockcartRepo)(nil).SetItem), ctx, cartID, param)
+}
+
+// MockbookRepo is a mock of bookRepo interface.
+type MockbookRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockbookRepoMockRecorder
+}
+
+// MockbookRepoMockRecorder is the mock recorder for MockbookRepo.
+type MockbookRepoMockRecorder struct {
+	mock *MockbookRepo
+}
+
+// NewMockbookRepo creates a new mock instance.
+func NewMockbookRepo(ctrl *gomock.Controller) *MockbookRepo {
+	mock := &MockbookRepo{ctrl: ctrl}
+	mock.recorder = &MockbookRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbookRepo) EXPECT() *MockbookRepoMockRecorder {
+	return m.recorder
+}
+
+// FindByIDs mocks base method.
+func (m *MockbookRepo) FindByIDs(ctx context.Context, bookIDs []string) ([]book.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, bookIDs)
+	ret0, _ := ret[0].([]book.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockbookRepoMockRecorder) FindByIDs(ctx, bookIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockbookRepo)(nil).FindByIDs), ctx, bookIDs)
+}
+
+// MockplaceOrderUseCase is a mock of placeOrderUseCase interface.
+type MockplaceOrderUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockplaceOrderUseCaseMockRecorder
+}
+
+// MockplaceOrderUseCaseMockRecorder is the mock recorder for MockplaceOrderUseCase.
+type MockplaceOrderUseCaseMockRecorder struct {
+	mock *MockplaceOrderUseCase
+}
+
+// NewMockplaceOrderUseCase creates a new mock instance.
+func NewMockplaceOrderUseCase(ctrl *gomock.Controller) *MockplaceOrderUseCase {
+	mock := &MockplaceOrderUseCase{ctrl: ctrl}
+	mock.recorder = &MockplaceOrderUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockplaceOrderUseCase) EXPECT() *MockplaceOrderUseCaseMockRecorder {
+	return m.recorder
+}
+
+// PlaceOrder mocks base method.
+func (m *MockplaceOrderUseCase) PlaceOrder(ctx context.Context, param order.Main) (order.Main, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceOrder", ctx, param)
+	ret0, _ := ret[0].(order.Main)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceOrder indicates an expected call of PlaceOrder.
+func (mr *MockplaceOrderUseCaseMockRecorder) PlaceOrder(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockplaceOrderUseCase)(nil).PlaceOrder), ctx, param)
+}
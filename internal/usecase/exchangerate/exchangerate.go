@@ -0,0 +1,107 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+//go:generate mockgen -source=exchangerate.go -destination=exchangerate_repo_mock_test.go -package exchangerate
+type exchangeRateRepo interface {
+	Upsert(ctx context.Context, param exchangerate.ExchangeRate) (exchangerate.ExchangeRate, error)
+	Delete(ctx context.Context, baseCurrency, currency money.Currency) error
+	FindByCurrency(ctx context.Context, baseCurrency, currency money.Currency) (exchangerate.ExchangeRate, error)
+	FindByBaseCurrency(ctx context.Context, baseCurrency money.Currency) ([]exchangerate.ExchangeRate, error)
+}
+
+var (
+	ErrNotFound            = exchangerate.ErrNotFound
+	ErrUnsupportedCurrency = exchangerate.ErrUnsupportedCurrency
+	ErrBaseCurrency        = exchangerate.ErrBaseCurrency
+)
+
+type Config struct {
+	// BaseCurrency is the currency every rate is quoted against, the rates of the other base currency are ignored.
+	BaseCurrency money.Currency
+}
+
+// UseCase manages the locally kept exchange rates and finds the rate between two currencies through the base currency.
+type UseCase struct {
+	config Config
+	repo   exchangeRateRepo
+}
+
+func NewExchangeRateUseCase(config Config, repo exchangeRateRepo) (*UseCase, error) {
+	if config.BaseCurrency == "" {
+		config.BaseCurrency = money.DefaultCurrency
+	}
+
+	return &UseCase{config: config, repo: repo}, nil
+}
+
+func (uc UseCase) List(ctx context.Context) ([]exchangerate.ExchangeRate, error) {
+	return uc.repo.FindByBaseCurrency(ctx, uc.config.BaseCurrency)
+}
+
+// Set sets the amount of the currency for one unit of the base currency, it replaces the current rate.
+// The placed orders keep the rate they were converted with.
+func (uc UseCase) Set(ctx context.Context, currency money.Currency, rate money.Rate) (exchangerate.ExchangeRate, error) {
+	if currency == uc.config.BaseCurrency {
+		return exchangerate.ExchangeRate{}, ErrBaseCurrency
+	}
+
+	return uc.repo.Upsert(ctx, exchangerate.ExchangeRate{
+		BaseCurrency: uc.config.BaseCurrency,
+		Currency:     currency,
+		Rate:         rate,
+	})
+}
+
+func (uc UseCase) Delete(ctx context.Context, currency money.Currency) error {
+	return uc.repo.Delete(ctx, uc.config.BaseCurrency, currency)
+}
+
+// rateOf finds the rate of the currency against the base currency.
+func (uc UseCase) rateOf(ctx context.Context, currency money.Currency) (money.Rate, error) {
+	if currency == uc.config.BaseCurrency {
+		return money.Rate{}, nil
+	}
+
+	item, err := uc.repo.FindByCurrency(ctx, uc.config.BaseCurrency, currency)
+	if errors.Is(err, ErrNotFound) {
+		return money.Rate{}, ErrUnsupportedCurrency
+	}
+
+	if err != nil {
+		return money.Rate{}, err
+	}
+
+	return item.Rate, nil
+}
+
+// Rate finds the amount of the target currency for one unit of the source currency, the currencies
+// other than the base currency are converted through the base currency.
+func (uc UseCase) Rate(ctx context.Context, from, to money.Currency) (money.Rate, error) {
+	if from == to {
+		return money.Rate{}, nil
+	}
+
+	fromRate, err := uc.rateOf(ctx, from)
+	if err != nil {
+		return money.Rate{}, err
+	}
+
+	toRate, err := uc.rateOf(ctx, to)
+	if err != nil {
+		return money.Rate{}, err
+	}
+
+	return fromRate.Inverse().Mul(toRate), nil
+}
+
+// BaseCurrency is the currency the rates are quoted against.
+func (uc UseCase) BaseCurrency() money.Currency {
+	return uc.config.BaseCurrency
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: exchangerate.go
+
+// Package exchangerate is a generated GoMock package.
+package exchangerate
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	exchangerate "github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+	money "github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// MockexchangeRateRepo is a mock of exchangeRateRepo interface.
+type MockexchangeRateRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockexchangeRateRepoMockRecorder
+}
+
+// MockexchangeRateRepoMockRecorder is the mock recorder for MockexchangeRateRepo.
+type MockexchangeRateRepoMockRecorder struct {
+	mock *MockexchangeRateRepo
+}
+
+// NewMockexchangeRateRepo creates a new mock instance.
+func NewMockexchangeRateRepo(ctrl *gomock.Controller) *MockexchangeRateRepo {
+	mock := &MockexchangeRateRepo{ctrl: ctrl}
+	mock.recorder = &MockexchangeRateRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexchangeRateRepo) EXPECT() *MockexchangeRateRepoMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockexchangeRateRepo) Delete(ctx context.Context, baseCurrency, currency money.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, baseCurrency, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockexchangeRateRepoMockRecorder) Delete(ctx, baseCurrency, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockexchangeRateRepo)(nil).Delete), ctx, baseCurrency, currency)
+}
+
+// FindByBaseCurrency mocks base method.
+func (m *MockexchangeRateRepo) FindByBaseCurrency(ctx context.Context, baseCurrency money.Currency) ([]exchangerate.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBaseCurrency", ctx, baseCurrency)
+	ret0, _ := ret[0].([]exchangerate.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBaseCurrency indicates an expected call of FindByBaseCurrency.
+func (mr *MockexchangeRateRepoMockRecorder) FindByBaseCurrency(ctx, baseCurrency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBaseCurrency", reflect.TypeOf((*MockexchangeRateRepo)(nil).FindByBaseCurrency), ctx, baseCurrency)
+}
+
+// FindByCurrency mocks base method.
+func (m *MockexchangeRateRepo) FindByCurrency(ctx context.Context, baseCurrency, currency money.Currency) (exchangerate.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCurrency", ctx, baseCurrency, currency)
+	ret0, _ := ret[0].(exchangerate.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCurrency indicates an expected call of FindByCurrency.
+func (mr *MockexchangeRateRepoMockRecorder) FindByCurrency(ctx, baseCurrency, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCurrency", reflect.TypeOf((*MockexchangeRateRepo)(nil).FindByCurrency), ctx, baseCurrency, currency)
+}
+
+// Upsert mocks base method.
+func (m *MockexchangeRateRepo) Upsert(ctx context.Context, param exchangerate.ExchangeRate) (exchangerate.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, param)
+	ret0, _ := ret[0].(exchangerate.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockexchangeRateRepoMockRecorder) Upsert(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockexchangeRateRepo)(nil).Upsert), ctx, param)
+}
@@ -0,0 +1,115 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/exchangerate"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestUseCase_Rate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockexchangeRateRepo(ctrl)
+
+	idr, _ := money.ParseRate("16000")
+	eur, _ := money.ParseRate("0.8")
+
+	tests := []struct {
+		name       string
+		from       money.Currency
+		to         money.Currency
+		beforeTest func()
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "same currency is identity",
+			from:       money.IDR,
+			to:         money.IDR,
+			beforeTest: func() {},
+			want:       "1",
+		},
+		{
+			name: "from the base currency",
+			from: money.USD,
+			to:   money.IDR,
+			beforeTest: func() {
+				repoMock.EXPECT().FindByCurrency(context.Background(), money.USD, money.IDR).
+					Return(exchangerate.ExchangeRate{Rate: idr}, nil)
+			},
+			want: "16000",
+		},
+		{
+			name: "into the base currency",
+			from: money.EUR,
+			to:   money.USD,
+			beforeTest: func() {
+				repoMock.EXPECT().FindByCurrency(context.Background(), money.USD, money.EUR).
+					Return(exchangerate.ExchangeRate{Rate: eur}, nil)
+			},
+			want: "1.25",
+		},
+		{
+			name: "through the base currency",
+			from: money.EUR,
+			to:   money.IDR,
+			beforeTest: func() {
+				repoMock.EXPECT().FindByCurrency(context.Background(), money.USD, money.EUR).
+					Return(exchangerate.ExchangeRate{Rate: eur}, nil)
+				repoMock.EXPECT().FindByCurrency(context.Background(), money.USD, money.IDR).
+					Return(exchangerate.ExchangeRate{Rate: idr}, nil)
+			},
+			want: "20000",
+		},
+		{
+			name: "can reject currency without rate",
+			from: money.USD,
+			to:   money.JPY,
+			beforeTest: func() {
+				repoMock.EXPECT().FindByCurrency(context.Background(), money.USD, money.JPY).
+					Return(exchangerate.ExchangeRate{}, ErrNotFound)
+			},
+			wantErr: ErrUnsupportedCurrency,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewExchangeRateUseCase(Config{}, repoMock)
+			got, err := uc.Rate(context.Background(), tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Rate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Rate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCase_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockexchangeRateRepo(ctrl)
+	rate, _ := money.ParseRate("16000")
+
+	uc, _ := NewExchangeRateUseCase(Config{BaseCurrency: money.USD}, repoMock)
+	if _, err := uc.Set(context.Background(), money.USD, rate); !errors.Is(err, ErrBaseCurrency) {
+		t.Errorf("Set() of the base currency error = %v, wantErr %v", err, ErrBaseCurrency)
+	}
+
+	repoMock.EXPECT().Upsert(context.Background(), exchangerate.ExchangeRate{BaseCurrency: money.USD, Currency: money.IDR, Rate: rate}).
+		Return(exchangerate.ExchangeRate{BaseCurrency: money.USD, Currency: money.IDR, Rate: rate}, nil)
+	if _, err := uc.Set(context.Background(), money.IDR, rate); err != nil {
+		t.Errorf("Set() error = %v", err)
+	}
+}
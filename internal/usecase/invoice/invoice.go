@@ -0,0 +1,97 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/invoice"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+//go:generate mockgen -source=invoice.go -destination=invoice_repo_mock_test.go -package invoice
+type invoiceRepo interface {
+	FindByOrderID(ctx context.Context, orderID string) (invoice.Invoice, error)
+	Create(ctx context.Context, orderID string) (invoice.Invoice, error)
+}
+
+var (
+	ErrNotFound       = invoice.ErrNotFound
+	ErrNotInvoiceable = invoice.ErrNotInvoiceable
+)
+
+// UseCase issues the invoices of the orders.
+type UseCase struct {
+	repo invoiceRepo
+}
+
+func NewInvoiceUseCase(repo invoiceRepo) (*UseCase, error) {
+	return &UseCase{repo: repo}, nil
+}
+
+// Issue finds the invoice of the order detail, the order which is not invoiced yet takes the next invoice number.
+// The cancelled order is only invoiced when it was invoiced before being cancelled.
+func (uc UseCase) Issue(ctx context.Context, detail order.Main) (invoice.Invoice, error) {
+	issued, err := uc.repo.FindByOrderID(ctx, detail.ID)
+	if errors.Is(err, ErrNotFound) {
+		if detail.Status == order.StatusCancelled {
+			return invoice.Invoice{}, ErrNotInvoiceable
+		}
+
+		issued, err = uc.repo.Create(ctx, detail.ID)
+	}
+
+	if err != nil {
+		return invoice.Invoice{}, err
+	}
+
+	return build(issued, detail), nil
+}
+
+// build fills the invoice from the order, the book lines become the items and the other lines are the adjustments
+// of the items subtotal. The amounts are taken from the order lines, thus later price changes do not alter the invoice.
+func build(issued invoice.Invoice, detail order.Main) invoice.Invoice {
+	issued.OrderStatus = detail.Status
+	issued.OrderedAt = detail.CreatedAt
+	issued.BillTo = detail.ShippingAddress
+	issued.Currency = detail.Currency
+	issued.BaseCurrency = detail.BaseCurrency
+	issued.ExchangeRate = detail.ExchangeRate
+	issued.GrandTotal = detail.GrandTotal
+	issued.Subtotal = money.Zero(detail.GrandTotal.Currency())
+	issued.Items = make([]invoice.Item, 0, len(detail.Lines))
+	issued.Adjustments = make([]invoice.Adjustment, 0)
+
+	for _, line := range detail.Lines {
+		if line.LineReferenceType != order.LineReferenceTypeBook {
+			issued.Adjustments = append(issued.Adjustments, invoice.Adjustment{
+				Type:   line.LineReferenceType,
+				Label:  invoice.AdjustmentLabel(line.LineReferenceType),
+				Amount: line.Subtotal,
+			})
+			continue
+		}
+
+		item := invoice.Item{
+			BookID:    line.LineReferenceID,
+			Title:     line.LineReferenceID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.Amount,
+			Amount:    line.Subtotal,
+		}
+
+		if b, ok := line.LineItem.(book.Book); ok {
+			item.Title = b.Title
+			item.ISBN = b.ISBN
+			for _, author := range b.Authors {
+				item.Authors = append(item.Authors, author.Name)
+			}
+		}
+
+		issued.Items = append(issued.Items, item)
+		issued.Subtotal = issued.Subtotal.Add(line.Subtotal)
+	}
+
+	return issued
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invoice.go
+
+// Package invoice is a generated GoMock package.
+package invoice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	invoice "github.com/rendyananta/example-online-book-store/internal/entity/invoice"
+)
+
+// MockinvoiceRepo is a mock of invoiceRepo interface.
+type MockinvoiceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockinvoiceRepoMockRecorder
+}
+
+// MockinvoiceRepoMockRecorder is the mock recorder for MockinvoiceRepo.
+type MockinvoiceRepoMockRecorder struct {
+	mock *MockinvoiceRepo
+}
+
+// NewMockinvoiceRepo creates a new mock instance.
+func NewMockinvoiceRepo(ctrl *gomock.Controller) *MockinvoiceRepo {
+	mock := &MockinvoiceRepo{ctrl: ctrl}
+	mock.recorder = &MockinvoiceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinvoiceRepo) EXPECT() *MockinvoiceRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockinvoiceRepo) Create(ctx context.Context, orderID string) (invoice.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, orderID)
+	ret0, _ := ret[0].(invoice.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockinvoiceRepoMockRecorder) Create(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockinvoiceRepo)(nil).Create), ctx, orderID)
+}
+
+// FindByOrderID mocks base method.
+func (m *MockinvoiceRepo) FindByOrderID(ctx context.Context, orderID string) (invoice.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrderID", ctx, orderID)
+	ret0, _ := ret[0].(invoice.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrderID indicates an expected call of FindByOrderID.
+func (mr *MockinvoiceRepoMockRecorder) FindByOrderID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderID", reflect.TypeOf((*MockinvoiceRepo)(nil).FindByOrderID), ctx, orderID)
+}
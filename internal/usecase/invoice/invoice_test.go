@@ -0,0 +1,181 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/invoice"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestUseCase_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockinvoiceRepo(ctrl)
+	uc, _ := NewInvoiceUseCase(repoMock)
+
+	now := time.Now()
+	rate, _ := money.ParseRate("0.92")
+	detail := order.Main{
+		ID:           "order-1",
+		Status:       order.StatusPaid,
+		GrandTotal:   money.New(1736, money.EUR),
+		Currency:     money.EUR,
+		BaseCurrency: money.USD,
+		ExchangeRate: rate,
+		CreatedAt:    &now,
+		Lines: []order.Line{
+			{
+				LineReferenceType: order.LineReferenceTypeBook,
+				LineReferenceID:   "book-1",
+				LineItem: book.Book{
+					ID:      "book-1",
+					Title:   "The Hunger Games",
+					ISBN:    "9780439023481",
+					Price:   money.New(999, money.USD),
+					Authors: []book.Author{{Name: "Suzanne Collins"}},
+				},
+				Amount:   money.New(468, money.EUR),
+				Quantity: 3,
+				Subtotal: money.New(1404, money.EUR),
+			},
+			{
+				LineReferenceType: order.LineReferenceTypeBook,
+				LineReferenceID:   "book-2",
+				Amount:            money.New(100, money.EUR),
+				Quantity:          1,
+				Subtotal:          money.New(100, money.EUR),
+			},
+			{
+				LineReferenceType: order.LineReferenceTypeDiscount,
+				LineReferenceID:   "voucher-1",
+				Amount:            money.New(-90, money.EUR),
+				Quantity:          1,
+				Subtotal:          money.New(-90, money.EUR),
+			},
+			{
+				LineReferenceType: order.LineReferenceTypeShippingFee,
+				LineReferenceID:   "address-1",
+				Amount:            money.New(322, money.EUR),
+				Quantity:          1,
+				Subtotal:          money.New(322, money.EUR),
+			},
+		},
+	}
+
+	issued := invoice.Invoice{ID: "invoice-1", Number: 7, OrderID: "order-1", IssuedAt: &now}
+
+	tests := []struct {
+		name       string
+		detail     order.Main
+		beforeTest func()
+		want       int64
+		wantErr    error
+	}{
+		{
+			name:   "issue the next number",
+			detail: detail,
+			beforeTest: func() {
+				repoMock.EXPECT().FindByOrderID(gomock.Any(), "order-1").Return(invoice.Invoice{}, ErrNotFound)
+				repoMock.EXPECT().Create(gomock.Any(), "order-1").Return(issued, nil)
+			},
+			want: 7,
+		},
+		{
+			name:   "keep the issued number",
+			detail: detail,
+			beforeTest: func() {
+				repoMock.EXPECT().FindByOrderID(gomock.Any(), "order-1").Return(issued, nil)
+			},
+			want: 7,
+		},
+		{
+			name: "cancelled order without invoice",
+			detail: func() order.Main {
+				cancelled := detail
+				cancelled.Status = order.StatusCancelled
+				return cancelled
+			}(),
+			beforeTest: func() {
+				repoMock.EXPECT().FindByOrderID(gomock.Any(), "order-1").Return(invoice.Invoice{}, ErrNotFound)
+			},
+			wantErr: ErrNotInvoiceable,
+		},
+		{
+			name: "cancelled order keeps its invoice",
+			detail: func() order.Main {
+				cancelled := detail
+				cancelled.Status = order.StatusCancelled
+				return cancelled
+			}(),
+			beforeTest: func() {
+				repoMock.EXPECT().FindByOrderID(gomock.Any(), "order-1").Return(issued, nil)
+			},
+			want: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			got, err := uc.Issue(context.Background(), tt.detail)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Issue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Number != tt.want || got.Code() != "INV-000007" {
+				t.Errorf("Issue() number = %v (%v), want %v", got.Number, got.Code(), tt.want)
+			}
+
+			wantItems := []invoice.Item{
+				{
+					BookID:    "book-1",
+					Title:     "The Hunger Games",
+					ISBN:      "9780439023481",
+					Authors:   []string{"Suzanne Collins"},
+					Quantity:  3,
+					UnitPrice: money.New(468, money.EUR),
+					Amount:    money.New(1404, money.EUR),
+				},
+				{
+					BookID:    "book-2",
+					Title:     "book-2",
+					Quantity:  1,
+					UnitPrice: money.New(100, money.EUR),
+					Amount:    money.New(100, money.EUR),
+				},
+			}
+			if !reflect.DeepEqual(got.Items, wantItems) {
+				t.Errorf("Issue() items = %+v, want %+v", got.Items, wantItems)
+			}
+
+			wantAdjustments := []invoice.Adjustment{
+				{Type: order.LineReferenceTypeDiscount, Label: "Discount", Amount: money.New(-90, money.EUR)},
+				{Type: order.LineReferenceTypeShippingFee, Label: "Shipping", Amount: money.New(322, money.EUR)},
+			}
+			if !reflect.DeepEqual(got.Adjustments, wantAdjustments) {
+				t.Errorf("Issue() adjustments = %+v, want %+v", got.Adjustments, wantAdjustments)
+			}
+
+			if !got.Subtotal.Equal(money.New(1504, money.EUR)) || !got.GrandTotal.Equal(money.New(1736, money.EUR)) {
+				t.Errorf("Issue() subtotal = %v, grand total = %v", got.Subtotal, got.GrandTotal)
+			}
+
+			if got.ExchangeRate.String() != "0.92" || got.BaseCurrency != money.USD {
+				t.Errorf("Issue() exchange rate = %v %v", got.ExchangeRate, got.BaseCurrency)
+			}
+		})
+	}
+}
@@ -1,7 +1,16 @@
 package order
 
-import "errors"
+import (
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+)
 
 var (
-	ErrOrderLineInvalid = errors.New("order line invalid")
+	ErrOrderLineInvalid        = order.ErrOrderLineInvalid
+	ErrInvalidStatusTransition = order.ErrInvalidStatusTransition
+	ErrNotCancellable          = order.ErrNotCancellable
+	ErrVoucherNotFound         = voucher.ErrNotFound
+	ErrShippingAddressRequired = order.ErrShippingAddressRequired
+	ErrShippingAddressNotFound = address.ErrNotFound
 )
@@ -2,25 +2,41 @@ package order
 
 import (
 	"context"
-	"math"
 
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
 	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/usecase/pricing"
 )
 
 type PlaceOrderUseCase struct {
-	orderRepo orderRepo
-	bookRepo  bookRepo
+	orderRepo   orderRepo
+	bookRepo    bookRepo
+	addressRepo addressRepo
+	pricer      pricer
 }
 
-func NewPlaceOrderUseCase(orderRepo orderRepo, bookRepo bookRepo) (*PlaceOrderUseCase, error) {
+func NewPlaceOrderUseCase(orderRepo orderRepo, bookRepo bookRepo, addressRepo addressRepo, pricer pricer) (*PlaceOrderUseCase, error) {
 	return &PlaceOrderUseCase{
-		orderRepo: orderRepo,
-		bookRepo:  bookRepo,
+		orderRepo:   orderRepo,
+		bookRepo:    bookRepo,
+		addressRepo: addressRepo,
+		pricer:      pricer,
 	}, nil
 }
 
+// PlaceOrder prices the ordered books using the pricing pipeline, the order lines other than
+// the books are only added by the pipeline. The order is charged in the requested currency,
+// its exchange rate is recorded on the order.
 func (uc PlaceOrderUseCase) PlaceOrder(ctx context.Context, param order.Main) (order.Main, error) {
+	if param.ShippingAddressID == "" {
+		return param, ErrShippingAddressRequired
+	}
+
+	shippingAddress, err := uc.addressRepo.FindByID(ctx, param.UserID, param.ShippingAddressID)
+	if err != nil {
+		return param, err
+	}
+
 	bookIDsToCheck := make([]string, 0)
 	for _, line := range param.Lines {
 		if line.LineReferenceType == order.LineReferenceTypeBook {
@@ -38,9 +54,15 @@ func (uc PlaceOrderUseCase) PlaceOrder(ctx context.Context, param order.Main) (o
 		bookByID[bookItem.ID] = bookItem
 	}
 
-	var grandTotal = 0.0
-	for i, line := range param.Lines {
-		// other than book type are ignored since its possibility only referencing to the book
+	quote := pricing.Quote{
+		TargetCurrency:  param.Currency,
+		UserID:          param.UserID,
+		Items:           make([]pricing.Item, 0, len(param.Lines)),
+		VoucherCodes:    param.VoucherCodes,
+		ShippingAddress: &shippingAddress,
+	}
+
+	for _, line := range param.Lines {
 		if line.LineReferenceType != order.LineReferenceTypeBook {
 			continue
 		}
@@ -50,18 +72,20 @@ func (uc PlaceOrderUseCase) PlaceOrder(ctx context.Context, param order.Main) (o
 			return param, ErrOrderLineInvalid
 		}
 
-		param.Lines[i].LineItem = bookItem
-		param.Lines[i].Amount = bookItem.Price
-
-		subtotalRounded := math.Ceil(bookItem.Price * float64(line.Quantity) * 100)
+		quote.Items = append(quote.Items, pricing.Item{Book: bookItem, Quantity: line.Quantity})
+	}
 
-		// round to max two decimal
-		param.Lines[i].Subtotal = subtotalRounded / 100
-		grandTotal = math.Ceil(grandTotal*100+subtotalRounded) / 100
+	if err = uc.pricer.Price(ctx, &quote); err != nil {
+		return param, err
 	}
 
-	param.Status = order.StatusDone
-	param.GrandTotal = grandTotal
+	param.Lines = quote.Lines
+	param.Status = order.StatusPendingPayment
+	param.GrandTotal = quote.GrandTotal
+	param.Currency = quote.Currency
+	param.BaseCurrency = quote.BaseCurrency
+	param.ExchangeRate = quote.ExchangeRate
+	param.ShippingAddress = &shippingAddress
 
 	return uc.orderRepo.Create(ctx, param)
 }
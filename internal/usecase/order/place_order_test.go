@@ -3,8 +3,11 @@ package order
 import (
 	"context"
 	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
 	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/usecase/pricing"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"reflect"
 	"testing"
 )
@@ -15,10 +18,17 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 
 	orderRepoMock := NewMockorderRepo(ctrl)
 	bookRepoMock := NewMockbookRepo(ctrl)
+	addressRepoMock := NewMockaddressRepo(ctrl)
+	subtotalPipeline := pricing.NewPipeline(pricing.Config{Steps: []pricing.StepName{pricing.StepNameSubtotal}})
+	subtotalPipeline.Register(pricing.StepNameSubtotal, pricing.NewSubtotalStep())
+
+	shippingAddress := address.Address{ID: "a1", UserID: "1", RecipientName: "Rendy", City: "Jakarta", Region: "DKI Jakarta", Country: "ID"}
 
 	type fields struct {
-		orderRepo orderRepo
-		bookRepo  bookRepo
+		orderRepo   orderRepo
+		bookRepo    bookRepo
+		addressRepo addressRepo
+		pricer      pricer
 	}
 	type args struct {
 		ctx   context.Context
@@ -35,13 +45,16 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 		{
 			name: "can place order",
 			fields: fields{
-				orderRepo: orderRepoMock,
-				bookRepo:  bookRepoMock,
+				orderRepo:   orderRepoMock,
+				bookRepo:    bookRepoMock,
+				addressRepo: addressRepoMock,
+				pricer:      subtotalPipeline,
 			},
 			args: args{
 				ctx: context.Background(),
 				param: order.Main{
-					UserID: "1",
+					UserID:            "1",
+					ShippingAddressID: "a1",
 					Lines: []order.Line{
 						{
 							LineReferenceType: order.LineReferenceTypeBook,
@@ -56,26 +69,31 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 					}},
 			},
 			beforeTest: func() {
+				addressRepoMock.EXPECT().FindByID(context.Background(), "1", "a1").Return(shippingAddress, nil)
 				bookRepoMock.EXPECT().FindByIDs(context.Background(), []string{"10", "11"}).
 					Return([]book.Book{
 						{
 							ID:          "10",
 							Title:       "Book 1",
 							Description: "desc",
-							Price:       1.2,
+							Price:       money.New(120, money.USD),
 						},
 						{
 							ID:          "11",
 							Title:       "Book 2",
 							Description: "desc",
-							Price:       1.2,
+							Price:       money.New(120, money.USD),
 						},
 					}, nil)
 
 				orderInfo := order.Main{
-					UserID:     "1",
-					GrandTotal: 3.6,
-					Status:     order.StatusDone,
+					UserID:            "1",
+					ShippingAddressID: "a1",
+					ShippingAddress:   &shippingAddress,
+					GrandTotal:        money.New(360, money.USD),
+					Currency:          money.USD,
+					BaseCurrency:      money.USD,
+					Status:            order.StatusPendingPayment,
 					Lines: []order.Line{
 						{
 							LineReferenceType: order.LineReferenceTypeBook,
@@ -84,11 +102,11 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 								ID:          "10",
 								Title:       "Book 1",
 								Description: "desc",
-								Price:       1.2,
+								Price:       money.New(120, money.USD),
 							},
 							Quantity: 1,
-							Amount:   1.2,
-							Subtotal: 1.2,
+							Amount:   money.New(120, money.USD),
+							Subtotal: money.New(120, money.USD),
 						},
 						{
 							LineReferenceType: order.LineReferenceTypeBook,
@@ -97,20 +115,21 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 								ID:          "11",
 								Title:       "Book 2",
 								Description: "desc",
-								Price:       1.2,
+								Price:       money.New(120, money.USD),
 							},
 							Quantity: 2,
-							Amount:   1.2,
-							Subtotal: 2.4,
+							Amount:   money.New(120, money.USD),
+							Subtotal: money.New(240, money.USD),
 						},
 					},
 				}
 
 				orderResult := order.Main{
-					ID:         "1",
-					UserID:     "1",
-					GrandTotal: 3.6,
-					Status:     order.StatusDone,
+					ID:              "1",
+					UserID:          "1",
+					ShippingAddress: &shippingAddress,
+					GrandTotal:      money.New(360, money.USD),
+					Status:          order.StatusPendingPayment,
 					Lines: []order.Line{
 						{
 							ID:                "1",
@@ -121,11 +140,11 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 								ID:          "10",
 								Title:       "Book 1",
 								Description: "desc",
-								Price:       1.2,
+								Price:       money.New(120, money.USD),
 							},
 							Quantity: 1,
-							Amount:   1.2,
-							Subtotal: 1.2,
+							Amount:   money.New(120, money.USD),
+							Subtotal: money.New(120, money.USD),
 						},
 						{
 							ID:                "2",
@@ -136,11 +155,11 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 								ID:          "11",
 								Title:       "Book 2",
 								Description: "desc",
-								Price:       1.2,
+								Price:       money.New(120, money.USD),
 							},
 							Quantity: 2,
-							Amount:   1.2,
-							Subtotal: 2.4,
+							Amount:   money.New(120, money.USD),
+							Subtotal: money.New(240, money.USD),
 						},
 					},
 				}
@@ -148,10 +167,11 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 				orderRepoMock.EXPECT().Create(context.Background(), orderInfo).Return(orderResult, nil)
 			},
 			want: order.Main{
-				ID:         "1",
-				UserID:     "1",
-				GrandTotal: 3.6,
-				Status:     order.StatusDone,
+				ID:              "1",
+				UserID:          "1",
+				ShippingAddress: &shippingAddress,
+				GrandTotal:      money.New(360, money.USD),
+				Status:          order.StatusPendingPayment,
 				Lines: []order.Line{
 					{
 						ID:                "1",
@@ -162,11 +182,11 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 							ID:          "10",
 							Title:       "Book 1",
 							Description: "desc",
-							Price:       1.2,
+							Price:       money.New(120, money.USD),
 						},
 						Quantity: 1,
-						Amount:   1.2,
-						Subtotal: 1.2,
+						Amount:   money.New(120, money.USD),
+						Subtotal: money.New(120, money.USD),
 					},
 					{
 						ID:                "2",
@@ -177,22 +197,71 @@ func TestPlaceOrderUseCase_PlaceOrder(t *testing.T) {
 							ID:          "11",
 							Title:       "Book 2",
 							Description: "desc",
-							Price:       1.2,
+							Price:       money.New(120, money.USD),
 						},
 						Quantity: 2,
-						Amount:   1.2,
-						Subtotal: 2.4,
+						Amount:   money.New(120, money.USD),
+						Subtotal: money.New(240, money.USD),
 					},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "can reject order without shipping address",
+			fields: fields{
+				orderRepo:   orderRepoMock,
+				bookRepo:    bookRepoMock,
+				addressRepo: addressRepoMock,
+				pricer:      subtotalPipeline,
+			},
+			args: args{
+				ctx: context.Background(),
+				param: order.Main{
+					UserID: "1",
+					Lines: []order.Line{
+						{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "10", Quantity: 1},
+					}},
+			},
+			want: order.Main{
+				UserID: "1",
+				Lines: []order.Line{
+					{LineReferenceType: order.LineReferenceTypeBook, LineReferenceID: "10", Quantity: 1},
+				}},
+			wantErr: true,
+		},
+		{
+			name: "can reject the address of the other user",
+			fields: fields{
+				orderRepo:   orderRepoMock,
+				bookRepo:    bookRepoMock,
+				addressRepo: addressRepoMock,
+				pricer:      subtotalPipeline,
+			},
+			args: args{
+				ctx: context.Background(),
+				param: order.Main{
+					UserID:            "2",
+					ShippingAddressID: "a1",
+				},
+			},
+			beforeTest: func() {
+				addressRepoMock.EXPECT().FindByID(context.Background(), "2", "a1").Return(address.Address{}, ErrShippingAddressNotFound)
+			},
+			want: order.Main{
+				UserID:            "2",
+				ShippingAddressID: "a1",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := PlaceOrderUseCase{
-				orderRepo: tt.fields.orderRepo,
-				bookRepo:  tt.fields.bookRepo,
+				orderRepo:   tt.fields.orderRepo,
+				bookRepo:    tt.fields.bookRepo,
+				addressRepo: tt.fields.addressRepo,
+				pricer:      tt.fields.pricer,
 			}
 			if tt.beforeTest != nil {
 				tt.beforeTest()
@@ -2,8 +2,10 @@ package order
 
 import (
 	"context"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
 	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/usecase/pricing"
 )
 
 //go:generate mockgen -source=query.go -destination=repo_mock_test.go -package order
@@ -11,12 +13,21 @@ type orderRepo interface {
 	PaginateOrdersByUserID(ctx context.Context, userID string, param order.PaginationParam) (order.PaginationResult, error)
 	GetDetailByID(ctx context.Context, orderID string) (order.Main, error)
 	Create(ctx context.Context, param order.Main) (order.Main, error)
+	UpdateStatus(ctx context.Context, from order.Status, param order.TransitionParam) (order.History, error)
 }
 
 type bookRepo interface {
 	FindByIDs(ctx context.Context, id []string) ([]book.Book, error)
 }
 
+type addressRepo interface {
+	FindByID(ctx context.Context, userID, id string) (address.Address, error)
+}
+
+type pricer interface {
+	Price(ctx context.Context, quote *pricing.Quote) error
+}
+
 // QueriesUseCase only act as a proxy.
 type QueriesUseCase struct {
 	orderRepo orderRepo
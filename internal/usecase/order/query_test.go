@@ -6,6 +6,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rendyananta/example-online-book-store/internal/entity/book"
 	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/pagination"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"reflect"
 	"testing"
 )
@@ -92,8 +94,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 					Return(order.Main{
 						ID:         "1",
 						UserID:     "1",
-						GrandTotal: 2.4,
-						Status:     order.StatusDone,
+						GrandTotal: money.New(240, money.USD),
+						Status:     order.StatusPendingPayment,
 						Lines: []order.Line{
 							{
 								ID:                "1",
@@ -101,8 +103,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 								LineReferenceType: order.LineReferenceTypeBook,
 								LineReferenceID:   "10",
 								Quantity:          1,
-								Amount:            1.2,
-								Subtotal:          1.2,
+								Amount:            money.New(120, money.USD),
+								Subtotal:          money.New(120, money.USD),
 							},
 							{
 								ID:                "2",
@@ -110,8 +112,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 								LineReferenceType: order.LineReferenceTypeBook,
 								LineReferenceID:   "11",
 								Quantity:          1,
-								Amount:            1.2,
-								Subtotal:          1.2,
+								Amount:            money.New(120, money.USD),
+								Subtotal:          money.New(120, money.USD),
 							},
 						},
 					}, nil)
@@ -133,8 +135,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 			want: order.Main{
 				ID:         "1",
 				UserID:     "1",
-				GrandTotal: 2.4,
-				Status:     order.StatusDone,
+				GrandTotal: money.New(240, money.USD),
+				Status:     order.StatusPendingPayment,
 				Lines: []order.Line{
 					{
 						ID:                "1",
@@ -142,8 +144,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 						LineReferenceType: order.LineReferenceTypeBook,
 						LineReferenceID:   "10",
 						Quantity:          1,
-						Amount:            1.2,
-						Subtotal:          1.2,
+						Amount:            money.New(120, money.USD),
+						Subtotal:          money.New(120, money.USD),
 						LineItem: book.Book{
 							ID:          "10",
 							Title:       "book 1",
@@ -156,8 +158,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 						LineReferenceType: order.LineReferenceTypeBook,
 						LineReferenceID:   "11",
 						Quantity:          1,
-						Amount:            1.2,
-						Subtotal:          1.2,
+						Amount:            money.New(120, money.USD),
+						Subtotal:          money.New(120, money.USD),
 						LineItem: book.Book{
 							ID:          "11",
 							Title:       "book 2",
@@ -183,8 +185,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 					Return(order.Main{
 						ID:         "1",
 						UserID:     "1",
-						GrandTotal: 2.4,
-						Status:     order.StatusDone,
+						GrandTotal: money.New(240, money.USD),
+						Status:     order.StatusPendingPayment,
 						Lines: []order.Line{
 							{
 								ID:                "1",
@@ -192,8 +194,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 								LineReferenceType: order.LineReferenceTypeBook,
 								LineReferenceID:   "10",
 								Quantity:          1,
-								Amount:            1.2,
-								Subtotal:          1.2,
+								Amount:            money.New(120, money.USD),
+								Subtotal:          money.New(120, money.USD),
 							},
 							{
 								ID:                "2",
@@ -201,8 +203,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 								LineReferenceType: order.LineReferenceTypeBook,
 								LineReferenceID:   "11",
 								Quantity:          1,
-								Amount:            1.2,
-								Subtotal:          1.2,
+								Amount:            money.New(120, money.USD),
+								Subtotal:          money.New(120, money.USD),
 							},
 						},
 					}, nil)
@@ -213,8 +215,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 			want: order.Main{
 				ID:         "1",
 				UserID:     "1",
-				GrandTotal: 2.4,
-				Status:     order.StatusDone,
+				GrandTotal: money.New(240, money.USD),
+				Status:     order.StatusPendingPayment,
 				Lines: []order.Line{
 					{
 						ID:                "1",
@@ -222,8 +224,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 						LineReferenceType: order.LineReferenceTypeBook,
 						LineReferenceID:   "10",
 						Quantity:          1,
-						Amount:            1.2,
-						Subtotal:          1.2,
+						Amount:            money.New(120, money.USD),
+						Subtotal:          money.New(120, money.USD),
 					},
 					{
 						ID:                "2",
@@ -231,8 +233,8 @@ func TestQueriesUseCase_GetDetailByID(t *testing.T) {
 						LineReferenceType: order.LineReferenceTypeBook,
 						LineReferenceID:   "11",
 						Quantity:          1,
-						Amount:            1.2,
-						Subtotal:          1.2,
+						Amount:            money.New(120, money.USD),
+						Subtotal:          money.New(120, money.USD),
 					},
 				},
 			},
@@ -305,12 +307,11 @@ func TestQueriesUseCase_PaginateOrdersByUserID(t *testing.T) {
 						{
 							ID:         "1",
 							UserID:     "1",
-							GrandTotal: 2.4,
-							Status:     order.StatusDone,
+							GrandTotal: money.New(240, money.USD),
+							Status:     order.StatusPendingPayment,
 						},
 					},
-					PerPage: 2,
-					LastID:  "1",
+					PageInfo: pagination.PageInfo{PerPage: 2},
 				}, nil)
 			},
 			want: order.PaginationResult{
@@ -318,12 +319,11 @@ func TestQueriesUseCase_PaginateOrdersByUserID(t *testing.T) {
 					{
 						ID:         "1",
 						UserID:     "1",
-						GrandTotal: 2.4,
-						Status:     order.StatusDone,
+						GrandTotal: money.New(240, money.USD),
+						Status:     order.StatusPendingPayment,
 					},
 				},
-				PerPage: 2,
-				LastID:  "1",
+				PageInfo: pagination.PageInfo{PerPage: 2},
 			},
 		},
 		{
@@ -343,13 +343,11 @@ func TestQueriesUseCase_PaginateOrdersByUserID(t *testing.T) {
 				orderRepoMock.EXPECT().PaginateOrdersByUserID(context.Background(), "1", order.PaginationParam{
 					PerPage: 2,
 				}).Return(order.PaginationResult{
-					PerPage: 2,
-					LastID:  "",
+					PageInfo: pagination.PageInfo{PerPage: 2},
 				}, sql.ErrConnDone)
 			},
 			want: order.PaginationResult{
-				PerPage: 2,
-				LastID:  "",
+				PageInfo: pagination.PageInfo{PerPage: 2},
 			},
 			wantErr: true,
 		},
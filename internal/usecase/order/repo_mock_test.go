@@ -9,8 +9,10 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	address "github.com/rendyananta/example-online-book-store/internal/entity/address"
 	book "github.com/rendyananta/example-online-book-store/internal/entity/book"
 	order "github.com/rendyananta/example-online-book-store/internal/entity/order"
+	pricing "github.com/rendyananta/example-online-book-store/internal/usecase/pricing"
 )
 
 // MockorderRepo is a mock of orderRepo interface.
@@ -81,6 +83,21 @@ func (mr *MockorderRepoMockRecorder) PaginateOrdersByUserID(ctx, userID, param i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaginateOrdersByUserID", reflect.TypeOf((*MockorderRepo)(nil).PaginateOrdersByUserID), ctx, userID, param)
 }
 
+// UpdateStatus mocks base method.
+func (m *MockorderRepo) UpdateStatus(ctx context.Context, from order.Status, param order.TransitionParam) (order.History, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, from, param)
+	ret0, _ := ret[0].(order.History)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockorderRepoMockRecorder) UpdateStatus(ctx, from, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockorderRepo)(nil).UpdateStatus), ctx, from, param)
+}
+
 // MockbookRepo is a mock of bookRepo interface.
 type MockbookRepo struct {
 	ctrl     *gomock.Controller
@@ -118,3 +135,78 @@ func (mr *MockbookRepoMockRecorder) FindByIDs(ctx, id interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockbookRepo)(nil).FindByIDs), ctx, id)
 }
+
+// MockaddressRepo is a mock of addressRepo interface.
+type MockaddressRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockaddressRepoMockRecorder
+}
+
+// MockaddressRepoMockRecorder is the mock recorder for MockaddressRepo.
+type MockaddressRepoMockRecorder struct {
+	mock *MockaddressRepo
+}
+
+// NewMockaddressRepo creates a new mock instance.
+func NewMockaddressRepo(ctrl *gomock.Controller) *MockaddressRepo {
+	mock := &MockaddressRepo{ctrl: ctrl}
+	mock.recorder = &MockaddressRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaddressRepo) EXPECT() *MockaddressRepoMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockaddressRepo) FindByID(ctx context.Context, userID, id string) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID, id)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockaddressRepoMockRecorder) FindByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockaddressRepo)(nil).FindByID), ctx, userID, id)
+}
+
+// Mockpricer is a mock of pricer interface.
+type Mockpricer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpricerMockRecorder
+}
+
+// MockpricerMockRecorder is the mock recorder for Mockpricer.
+type MockpricerMockRecorder struct {
+	mock *Mockpricer
+}
+
+// NewMockpricer creates a new mock instance.
+func NewMockpricer(ctrl *gomock.Controller) *Mockpricer {
+	mock := &Mockpricer{ctrl: ctrl}
+	mock.recorder = &MockpricerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpricer) EXPECT() *MockpricerMockRecorder {
+	return m.recorder
+}
+
+// Price mocks base method.
+func (m *Mockpricer) Price(ctx context.Context, quote *pricing.Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Price", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Price indicates an expected call of Price.
+func (mr *MockpricerMockRecorder) Price(ctx, quote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Price", reflect.TypeOf((*Mockpricer)(nil).Price), ctx, quote)
+}
@@ -0,0 +1,59 @@
+package order
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+)
+
+// StatusUseCase moves the orders along their lifecycle, see order.CanTransition for the allowed transitions.
+type StatusUseCase struct {
+	orderRepo orderRepo
+}
+
+func NewStatusUseCase(orderRepo orderRepo) (*StatusUseCase, error) {
+	return &StatusUseCase{
+		orderRepo: orderRepo,
+	}, nil
+}
+
+// Transition moves the order into the status and returns the order along with its histories.
+func (uc StatusUseCase) Transition(ctx context.Context, param order.TransitionParam) (order.Main, error) {
+	orderDetail, err := uc.orderRepo.GetDetailByID(ctx, param.OrderID)
+	if err != nil {
+		return order.Main{}, err
+	}
+
+	if !order.CanTransition(orderDetail.Status, param.Status) {
+		return order.Main{}, ErrInvalidStatusTransition
+	}
+
+	if _, err = uc.orderRepo.UpdateStatus(ctx, orderDetail.Status, param); err != nil {
+		return order.Main{}, err
+	}
+
+	return uc.orderRepo.GetDetailByID(ctx, param.OrderID)
+}
+
+// Cancel lets the customer cancel the order which is not paid yet, the stock reserved by the order is given back.
+func (uc StatusUseCase) Cancel(ctx context.Context, orderID string, reason string) (order.Main, error) {
+	orderDetail, err := uc.orderRepo.GetDetailByID(ctx, orderID)
+	if err != nil {
+		return order.Main{}, err
+	}
+
+	if !order.CustomerCanCancel(orderDetail.Status) {
+		return order.Main{}, ErrNotCancellable
+	}
+
+	_, err = uc.orderRepo.UpdateStatus(ctx, orderDetail.Status, order.TransitionParam{
+		OrderID: orderID,
+		Status:  order.StatusCancelled,
+		Reason:  reason,
+	})
+	if err != nil {
+		return order.Main{}, err
+	}
+
+	return uc.orderRepo.GetDetailByID(ctx, orderID)
+}
@@ -0,0 +1,140 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+)
+
+func TestStatusUseCase_Transition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepoMock := NewMockorderRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		param      order.TransitionParam
+		beforeTest func()
+		want       order.Main
+		wantErr    error
+	}{
+		{
+			name:  "can move the order into the next status",
+			param: order.TransitionParam{OrderID: "1", Status: order.StatusPaid},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusPendingPayment}, nil)
+				orderRepoMock.EXPECT().UpdateStatus(context.Background(), order.StatusPendingPayment, order.TransitionParam{OrderID: "1", Status: order.StatusPaid}).
+					Return(order.History{ID: "2", FromStatus: order.StatusPendingPayment, Status: order.StatusPaid}, nil)
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusPaid}, nil)
+			},
+			want: order.Main{ID: "1", Status: order.StatusPaid},
+		},
+		{
+			name:  "can reject invalid transition",
+			param: order.TransitionParam{OrderID: "1", Status: order.StatusPaid},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusShipped}, nil)
+			},
+			want:    order.Main{},
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:  "can handle update error",
+			param: order.TransitionParam{OrderID: "1", Status: order.StatusShipped},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusProcessing}, nil)
+				orderRepoMock.EXPECT().UpdateStatus(context.Background(), order.StatusProcessing, order.TransitionParam{OrderID: "1", Status: order.StatusShipped}).
+					Return(order.History{}, sql.ErrConnDone)
+			},
+			want:    order.Main{},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := StatusUseCase{orderRepo: orderRepoMock}
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+			got, err := uc.Transition(context.Background(), tt.param)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Transition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Transition() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusUseCase_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepoMock := NewMockorderRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		beforeTest func()
+		want       order.Main
+		wantErr    error
+	}{
+		{
+			name: "can cancel the order pending the payment",
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusPendingPayment}, nil)
+				orderRepoMock.EXPECT().UpdateStatus(context.Background(), order.StatusPendingPayment, order.TransitionParam{OrderID: "1", Status: order.StatusCancelled, Reason: "changed my mind"}).
+					Return(order.History{ID: "2", FromStatus: order.StatusPendingPayment, Status: order.StatusCancelled}, nil)
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusCancelled}, nil)
+			},
+			want: order.Main{ID: "1", Status: order.StatusCancelled},
+		},
+		{
+			name: "can reject cancelling the paid order",
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusPaid}, nil)
+			},
+			want:    order.Main{},
+			wantErr: ErrNotCancellable,
+		},
+		{
+			name: "can reject cancelling the shipped order",
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", Status: order.StatusShipped}, nil)
+			},
+			want:    order.Main{},
+			wantErr: ErrNotCancellable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := StatusUseCase{orderRepo: orderRepoMock}
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+			got, err := uc.Cancel(context.Background(), "1", "changed my mind")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Cancel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cancel() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
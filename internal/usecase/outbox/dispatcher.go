@@ -0,0 +1,129 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/pkg/worker"
+)
+
+//go:generate mockgen -source=dispatcher.go -destination=outbox_repo_mock_test.go -package outbox
+type outboxRepo interface {
+	FindPending(ctx context.Context, now time.Time, limit int) ([]event.Event, error)
+	Claim(ctx context.Context, evt event.Event, until time.Time) (bool, error)
+	MarkDispatched(ctx context.Context, id string) error
+	Retry(ctx context.Context, id string, at time.Time, lastError string) error
+	Fail(ctx context.Context, id string, lastError string) error
+}
+
+// Handler receives the dispatched events. The event is delivered at least once, it is delivered again when
+// any handler of the event fails, thus the handler recognizes the redelivery by the event ID.
+type Handler interface {
+	Handle(ctx context.Context, evt event.Event) error
+}
+
+// HandlerFunc adapts the function into the Handler.
+type HandlerFunc func(ctx context.Context, evt event.Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, evt event.Event) error {
+	return f(ctx, evt)
+}
+
+var defaultConfig = worker.Config{
+	PollInterval:    time.Second,
+	BatchSize:       50,
+	MaxAttempts:     10,
+	RetryBackoff:    5 * time.Second,
+	MaxRetryBackoff: time.Hour,
+	ClaimTimeout:    time.Minute,
+}
+
+// Config of the dispatcher, the outbox event is the job of the worker.
+type Config = worker.Config
+
+// Dispatcher delivers the events written into the outbox to the registered handlers.
+type Dispatcher struct {
+	config   Config
+	repo     outboxRepo
+	handlers map[event.Type][]Handler
+	now      func() time.Time
+}
+
+func NewDispatcher(config Config, repo outboxRepo) (*Dispatcher, error) {
+	return &Dispatcher{
+		config:   config.WithDefaults(defaultConfig),
+		repo:     repo,
+		handlers: make(map[event.Type][]Handler),
+		now:      time.Now,
+	}, nil
+}
+
+// Register adds the handler of the event type, the handlers are registered before the dispatcher runs.
+func (d *Dispatcher) Register(eventType event.Type, handler Handler) {
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// Run polls the outbox until the context is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	worker.Poll(ctx, d.config, "outbox dispatcher", d.DispatchPending)
+}
+
+// DispatchPending delivers one batch of the pending events, it returns the number of the events
+// taken from the outbox.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	events, err := d.repo.FindPending(ctx, d.now(), d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	err = worker.ProcessClaimed(ctx, events, func(ctx context.Context, evt event.Event) (bool, error) {
+		return d.repo.Claim(ctx, evt, d.now().Add(d.config.ClaimTimeout))
+	}, func(ctx context.Context, evt event.Event) error {
+		evt.Attempts++
+		return d.dispatch(ctx, evt)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, evt event.Event) error {
+	var handleErr error
+	for _, handler := range d.handlers[evt.Type] {
+		if handleErr = handle(ctx, handler, evt); handleErr != nil {
+			break
+		}
+	}
+
+	if handleErr == nil {
+		return d.repo.MarkDispatched(ctx, evt.ID)
+	}
+
+	retryAt, retry := d.config.NextAttempt(d.now(), evt.Attempts)
+	if !retry {
+		slog.Error("giving up the outbox event", slog.String("error", handleErr.Error()),
+			slog.String("event_id", evt.ID), slog.String("type", evt.Type), slog.Int("attempts", evt.Attempts))
+		return d.repo.Fail(ctx, evt.ID, handleErr.Error())
+	}
+
+	slog.Warn("retrying the outbox event", slog.String("error", handleErr.Error()),
+		slog.String("event_id", evt.ID), slog.String("type", evt.Type), slog.Int("attempts", evt.Attempts))
+
+	return d.repo.Retry(ctx, evt.ID, retryAt, handleErr.Error())
+}
+
+// handle calls the handler, the panic of the handler fails the delivery instead of stopping the dispatcher.
+func handle(ctx context.Context, handler Handler, evt event.Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+	}()
+
+	return handler.Handle(ctx, evt)
+}
@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+)
+
+func TestDispatcher_DispatchPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockoutboxRepo(ctrl)
+	now := time.Date(2024, 10, 6, 10, 0, 0, 0, time.UTC)
+
+	placed := event.Event{ID: "1", Type: event.TypeOrderPlaced, AggregateID: "order-1"}
+	registered := event.Event{ID: "2", Type: event.TypeUserRegistered, AggregateID: "user-1", Attempts: 2}
+
+	tests := []struct {
+		name       string
+		handler    Handler
+		beforeTest func()
+		wantErr    bool
+	}{
+		{
+			name: "delivered events are marked dispatched",
+			handler: HandlerFunc(func(ctx context.Context, evt event.Event) error {
+				return nil
+			}),
+			beforeTest: func() {
+				repoMock.EXPECT().FindPending(gomock.Any(), now, 10).Return([]event.Event{placed, registered}, nil)
+				repoMock.EXPECT().Claim(gomock.Any(), placed, now.Add(time.Minute)).Return(true, nil)
+				repoMock.EXPECT().MarkDispatched(gomock.Any(), "1").Return(nil)
+				repoMock.EXPECT().Claim(gomock.Any(), registered, now.Add(time.Minute)).Return(true, nil)
+				repoMock.EXPECT().MarkDispatched(gomock.Any(), "2").Return(nil)
+			},
+		},
+		{
+			name: "event claimed by another dispatcher is skipped",
+			handler: HandlerFunc(func(ctx context.Context, evt event.Event) error {
+				t.Errorf("Handle() is called for the event claimed by another dispatcher")
+				return nil
+			}),
+			beforeTest: func() {
+				repoMock.EXPECT().FindPending(gomock.Any(), now, 10).Return([]event.Event{placed}, nil)
+				repoMock.EXPECT().Claim(gomock.Any(), placed, now.Add(time.Minute)).Return(false, nil)
+			},
+		},
+		{
+			name: "failed delivery is retried with backoff",
+			handler: HandlerFunc(func(ctx context.Context, evt event.Event) error {
+				return errors.New("connection refused")
+			}),
+			beforeTest: func() {
+				repoMock.EXPECT().FindPending(gomock.Any(), now, 10).Return([]event.Event{placed, registered}, nil)
+				repoMock.EXPECT().Claim(gomock.Any(), placed, now.Add(time.Minute)).Return(true, nil)
+				repoMock.EXPECT().Retry(gomock.Any(), "1", now.Add(5*time.Second), "connection refused").Return(nil)
+				repoMock.EXPECT().Claim(gomock.Any(), registered, now.Add(time.Minute)).Return(true, nil)
+				repoMock.EXPECT().Retry(gomock.Any(), "2", now.Add(20*time.Second), "connection refused").Return(nil)
+			},
+		},
+		{
+			name: "panicking handler fails the delivery",
+			handler: HandlerFunc(func(ctx context.Context, evt event.Event) error {
+				panic("nil map")
+			}),
+			beforeTest: func() {
+				repoMock.EXPECT().FindPending(gomock.Any(), now, 10).Return([]event.Event{placed}, nil)
+				repoMock.EXPECT().Claim(gomock.Any(), placed, now.Add(time.Minute)).Return(true, nil)
+				repoMock.EXPECT().Retry(gomock.Any(), "1", now.Add(5*time.Second), "handler panicked: nil map").Return(nil)
+			},
+		},
+		{
+			name: "last attempt gives up the event",
+			handler: HandlerFunc(func(ctx context.Context, evt event.Event) error {
+				return errors.New("gone")
+			}),
+			beforeTest: func() {
+				last := event.Event{ID: "3", Type: event.TypeOrderPlaced, Attempts: 4}
+				repoMock.EXPECT().FindPending(gomock.Any(), now, 10).Return([]event.Event{last}, nil)
+				repoMock.EXPECT().Claim(gomock.Any(), last, now.Add(time.Minute)).Return(true, nil)
+				repoMock.EXPECT().Fail(gomock.Any(), "3", "gone").Return(nil)
+			},
+		},
+		{
+			name: "outbox error",
+			handler: HandlerFunc(func(ctx context.Context, evt event.Event) error {
+				return nil
+			}),
+			beforeTest: func() {
+				repoMock.EXPECT().FindPending(gomock.Any(), now, 10).Return(nil, errors.New("database is locked"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := NewDispatcher(Config{BatchSize: 10, MaxAttempts: 5}, repoMock)
+			d.now = func() time.Time { return now }
+			d.Register(event.TypeOrderPlaced, tt.handler)
+			d.Register(event.TypeUserRegistered, tt.handler)
+
+			tt.beforeTest()
+
+			if _, err := d.DispatchPending(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("DispatchPending() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockoutboxRepo(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	delivered := make(chan event.Event, 1)
+	placed := event.Event{ID: "1", Type: event.TypeOrderPlaced}
+
+	repoMock.EXPECT().FindPending(gomock.Any(), gomock.Any(), defaultConfig.BatchSize).Return([]event.Event{placed}, nil)
+	repoMock.EXPECT().Claim(gomock.Any(), placed, gomock.Any()).Return(true, nil)
+	repoMock.EXPECT().MarkDispatched(gomock.Any(), "1").Return(nil)
+	repoMock.EXPECT().FindPending(gomock.Any(), gomock.Any(), defaultConfig.BatchSize).Return(nil, nil).AnyTimes()
+
+	d, _ := NewDispatcher(Config{PollInterval: time.Millisecond}, repoMock)
+	d.Register(event.TypeOrderPlaced, HandlerFunc(func(ctx context.Context, evt event.Event) error {
+		delivered <- evt
+		return nil
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case evt := <-delivered:
+		if evt.ID != "1" || evt.Attempts != 1 {
+			t.Errorf("Run() delivered = %+v, want the first attempt of the event 1", evt)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Run() did not deliver the event")
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Run() did not stop after the context is done")
+	}
+}
@@ -0,0 +1,18 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+)
+
+// LogHandler logs the dispatched events.
+type LogHandler struct{}
+
+func (LogHandler) Handle(_ context.Context, evt event.Event) error {
+	slog.Info("event dispatched", slog.String("event_id", evt.ID), slog.String("type", evt.Type),
+		slog.String("aggregate_id", evt.AggregateID))
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dispatcher.go
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	event "github.com/rendyananta/example-online-book-store/internal/entity/event"
+)
+
+// MockoutboxRepo is a mock of outboxRepo interface.
+type MockoutboxRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxRepoMockRecorder
+}
+
+// MockoutboxRepoMockRecorder is the mock recorder for MockoutboxRepo.
+type MockoutboxRepoMockRecorder struct {
+	mock *MockoutboxRepo
+}
+
+// NewMockoutboxRepo creates a new mock instance.
+func NewMockoutboxRepo(ctrl *gomock.Controller) *MockoutboxRepo {
+	mock := &MockoutboxRepo{ctrl: ctrl}
+	mock.recorder = &MockoutboxRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxRepo) EXPECT() *MockoutboxRepoMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockoutboxRepo) Claim(ctx context.Context, evt event.Event, until time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, evt, until)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockoutboxRepoMockRecorder) Claim(ctx, evt, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockoutboxRepo)(nil).Claim), ctx, evt, until)
+}
+
+// Fail mocks base method.
+func (m *MockoutboxRepo) Fail(ctx context.Context, id, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockoutboxRepoMockRecorder) Fail(ctx, id, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockoutboxRepo)(nil).Fail), ctx, id, lastError)
+}
+
+// FindPending mocks base method.
+func (m *MockoutboxRepo) FindPending(ctx context.Context, now time.Time, limit int) ([]event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending", ctx, now, limit)
+	ret0, _ := ret[0].([]event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockoutboxRepoMockRecorder) FindPending(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockoutboxRepo)(nil).FindPending), ctx, now, limit)
+}
+
+// MarkDispatched mocks base method.
+func (m *MockoutboxRepo) MarkDispatched(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDispatched", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDispatched indicates an expected call of MarkDispatched.
+func (mr *MockoutboxRepoMockRecorder) MarkDispatched(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDispatched", reflect.TypeOf((*MockoutboxRepo)(nil).MarkDispatched), ctx, id)
+}
+
+// Retry mocks base method.
+func (m *MockoutboxRepo) Retry(ctx context.Context, id string, at time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id, at, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockoutboxRepoMockRecorder) Retry(ctx, id, at, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockoutboxRepo)(nil).Retry), ctx, id, at, lastError)
+}
+
+// MockHandler is a mock of Handler interface.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockHandler) Handle(ctx context.Context, evt event.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", ctx, evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockHandlerMockRecorder) Handle(ctx, evt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockHandler)(nil).Handle), ctx, evt)
+}
@@ -0,0 +1,158 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/payment"
+	paymentpkg "github.com/rendyananta/example-online-book-store/pkg/payment"
+)
+
+//go:generate mockgen -source=payment.go -destination=repo_mock_test.go -package payment
+type transactionRepo interface {
+	Create(ctx context.Context, param payment.Transaction) (payment.Transaction, error)
+	FindPendingByOrderID(ctx context.Context, orderID string, gateway string) (payment.Transaction, error)
+	FindByReference(ctx context.Context, gateway string, reference string) (payment.Transaction, error)
+	Settle(ctx context.Context, id string, status payment.Status, payload []byte) error
+}
+
+type orderRepo interface {
+	GetDetailByID(ctx context.Context, orderID string) (order.Main, error)
+	UpdateStatus(ctx context.Context, from order.Status, param order.TransitionParam) (order.History, error)
+}
+
+type gatewayManager interface {
+	Gateway(name paymentpkg.GatewayName) (paymentpkg.GatewayName, paymentpkg.Gateway, error)
+}
+
+var (
+	ErrNotFound        = payment.ErrNotFound
+	ErrOrderNotPayable = payment.ErrOrderNotPayable
+	ErrAmountMismatch  = payment.ErrAmountMismatch
+	ErrAlreadySettled  = payment.ErrAlreadySettled
+	ErrNotOrderOwner   = payment.ErrNotOrderOwner
+)
+
+type UseCase struct {
+	transactionRepo transactionRepo
+	orderRepo       orderRepo
+	gatewayManager  gatewayManager
+}
+
+func NewPaymentUseCase(transactionRepo transactionRepo, orderRepo orderRepo, gatewayManager gatewayManager) (*UseCase, error) {
+	return &UseCase{
+		transactionRepo: transactionRepo,
+		orderRepo:       orderRepo,
+		gatewayManager:  gatewayManager,
+	}, nil
+}
+
+// CreateIntent asks the gateway to collect the grand total of the order, the pending payment
+// on the same gateway is returned instead so the customer is not charged twice.
+func (uc UseCase) CreateIntent(ctx context.Context, param payment.CreateIntentParam) (payment.Transaction, error) {
+	orderDetail, err := uc.orderRepo.GetDetailByID(ctx, param.OrderID)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+
+	if orderDetail.UserID != param.UserID {
+		return payment.Transaction{}, ErrNotOrderOwner
+	}
+
+	if orderDetail.Status != order.StatusPendingPayment {
+		return payment.Transaction{}, ErrOrderNotPayable
+	}
+
+	gatewayName, gateway, err := uc.gatewayManager.Gateway(param.Gateway)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+
+	pending, err := uc.transactionRepo.FindPendingByOrderID(ctx, orderDetail.ID, gatewayName)
+	if err == nil {
+		return pending, nil
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		return payment.Transaction{}, err
+	}
+
+	intent, err := gateway.CreateIntent(ctx, paymentpkg.IntentParam{
+		MerchantReference: orderDetail.ID,
+		Amount:            orderDetail.GrandTotal,
+	})
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+
+	return uc.transactionRepo.Create(ctx, payment.Transaction{
+		OrderID:    orderDetail.ID,
+		Gateway:    gatewayName,
+		Reference:  intent.Reference,
+		Amount:     orderDetail.GrandTotal,
+		Status:     intent.Status,
+		PaymentURL: intent.PaymentURL,
+	})
+}
+
+// HandleNotification settles the payment using the notification sent by the gateway, the order is
+// marked as paid on the successful payment. Notification of the settled payment is acknowledged as is.
+func (uc UseCase) HandleNotification(ctx context.Context, gatewayName paymentpkg.GatewayName, header http.Header, payload []byte) (payment.Transaction, error) {
+	gatewayName, gateway, err := uc.gatewayManager.Gateway(gatewayName)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+
+	notification, err := gateway.ParseNotification(ctx, header, payload)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+
+	transaction, err := uc.transactionRepo.FindByReference(ctx, gatewayName, notification.Reference)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+
+	if transaction.Status != payment.StatusPending || notification.Status == payment.StatusPending {
+		return transaction, nil
+	}
+
+	if notification.Status == payment.StatusSucceeded && !notification.Amount.Equal(transaction.Amount) {
+		return payment.Transaction{}, ErrAmountMismatch
+	}
+
+	if notification.Status == payment.StatusSucceeded {
+		// the order is marked before settling the payment, so the notification retried by the gateway
+		// after a failure in between still settles the payment.
+		_, err = uc.orderRepo.UpdateStatus(ctx, order.StatusPendingPayment, order.TransitionParam{
+			OrderID: transaction.OrderID,
+			Status:  order.StatusPaid,
+			Reason:  fmt.Sprintf("paid via %s", gatewayName),
+		})
+
+		// the order might have been moved elsewhere, e.g. cancelled before the payment is received,
+		// the payment is kept as succeeded so it can be refunded by the staff.
+		if errors.Is(err, order.ErrInvalidStatusTransition) {
+			slog.Warn("paid order is no longer pending the payment",
+				slog.String("order_id", transaction.OrderID), slog.String("transaction_id", transaction.ID))
+		} else if err != nil {
+			return payment.Transaction{}, err
+		}
+	}
+
+	if err = uc.transactionRepo.Settle(ctx, transaction.ID, notification.Status, payload); err != nil {
+		if errors.Is(err, ErrAlreadySettled) {
+			return uc.transactionRepo.FindByReference(ctx, gatewayName, notification.Reference)
+		}
+
+		return payment.Transaction{}, err
+	}
+
+	transaction.Status = notification.Status
+
+	return transaction, nil
+}
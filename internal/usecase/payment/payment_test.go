@@ -0,0 +1,256 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/payment"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	paymentpkg "github.com/rendyananta/example-online-book-store/pkg/payment"
+)
+
+func newTestGatewayManager() (paymentpkg.Manager, *paymentpkg.FakeGateway) {
+	fakeGateway, _ := paymentpkg.NewFakeGateway(paymentpkg.FakeGatewayConfig{Secret: "secret"})
+
+	manager := paymentpkg.NewManager(paymentpkg.Config{DefaultGateway: paymentpkg.GwNameFake})
+	manager.Register(paymentpkg.GwNameFake, fakeGateway)
+
+	return manager, fakeGateway
+}
+
+func TestUseCase_CreateIntent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transactionRepoMock := NewMocktransactionRepo(ctrl)
+	orderRepoMock := NewMockorderRepo(ctrl)
+	manager, _ := newTestGatewayManager()
+
+	pendingOrder := order.Main{ID: "1", UserID: "1", GrandTotal: money.New(1250, money.USD), Status: order.StatusPendingPayment}
+
+	tests := []struct {
+		name       string
+		param      payment.CreateIntentParam
+		beforeTest func()
+		check      func(t *testing.T, got payment.Transaction)
+		wantErr    error
+	}{
+		{
+			name:  "can create the payment on the default gateway",
+			param: payment.CreateIntentParam{UserID: "1", OrderID: "1"},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").Return(pendingOrder, nil)
+				transactionRepoMock.EXPECT().FindPendingByOrderID(context.Background(), "1", paymentpkg.GwNameFake).
+					Return(payment.Transaction{}, ErrNotFound)
+				transactionRepoMock.EXPECT().Create(context.Background(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, param payment.Transaction) (payment.Transaction, error) {
+						param.ID = "10"
+						return param, nil
+					})
+			},
+			check: func(t *testing.T, got payment.Transaction) {
+				if got.ID != "10" || got.Gateway != paymentpkg.GwNameFake || !got.Amount.Equal(money.New(1250, money.USD)) ||
+					got.Status != payment.StatusPending || got.Reference == "" || got.PaymentURL == "" {
+					t.Errorf("CreateIntent() got = %+v", got)
+				}
+			},
+		},
+		{
+			name:  "can return the pending payment",
+			param: payment.CreateIntentParam{UserID: "1", OrderID: "1", Gateway: paymentpkg.GwNameFake},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").Return(pendingOrder, nil)
+				transactionRepoMock.EXPECT().FindPendingByOrderID(context.Background(), "1", paymentpkg.GwNameFake).
+					Return(payment.Transaction{ID: "9", Reference: "ref-9"}, nil)
+			},
+			check: func(t *testing.T, got payment.Transaction) {
+				if !reflect.DeepEqual(got, payment.Transaction{ID: "9", Reference: "ref-9"}) {
+					t.Errorf("CreateIntent() got = %+v, want the pending payment", got)
+				}
+			},
+		},
+		{
+			name:  "can reject other user order",
+			param: payment.CreateIntentParam{UserID: "2", OrderID: "1"},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").Return(pendingOrder, nil)
+			},
+			wantErr: ErrNotOrderOwner,
+		},
+		{
+			name:  "can reject paid order",
+			param: payment.CreateIntentParam{UserID: "1", OrderID: "1"},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").
+					Return(order.Main{ID: "1", UserID: "1", Status: order.StatusPaid}, nil)
+			},
+			wantErr: ErrOrderNotPayable,
+		},
+		{
+			name:  "can reject unregistered gateway",
+			param: payment.CreateIntentParam{UserID: "1", OrderID: "1", Gateway: "unknown"},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").Return(pendingOrder, nil)
+			},
+			wantErr: paymentpkg.ErrGatewayUnregistered,
+		},
+		{
+			name:  "can handle repo error",
+			param: payment.CreateIntentParam{UserID: "1", OrderID: "1"},
+			beforeTest: func() {
+				orderRepoMock.EXPECT().GetDetailByID(context.Background(), "1").Return(pendingOrder, nil)
+				transactionRepoMock.EXPECT().FindPendingByOrderID(context.Background(), "1", paymentpkg.GwNameFake).
+					Return(payment.Transaction{}, sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewPaymentUseCase(transactionRepoMock, orderRepoMock, manager)
+			got, err := uc.CreateIntent(context.Background(), tt.param)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateIntent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestUseCase_HandleNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transactionRepoMock := NewMocktransactionRepo(ctrl)
+	orderRepoMock := NewMockorderRepo(ctrl)
+	manager, fakeGateway := newTestGatewayManager()
+
+	pending := payment.Transaction{ID: "10", OrderID: "1", Gateway: "fake", Reference: "ref-1", Amount: money.New(1250, money.USD), Status: payment.StatusPending}
+
+	signed := func(payload string) http.Header {
+		header := http.Header{}
+		header.Set(paymentpkg.FakeSignatureHeader, fakeGateway.Sign([]byte(payload)))
+		return header
+	}
+
+	succeeded := `{"reference":"ref-1","status":"succeeded","amount":12.5}`
+	failed := `{"reference":"ref-1","status":"failed","amount":12.5}`
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		payload    string
+		beforeTest func()
+		wantStatus payment.Status
+		wantErr    error
+	}{
+		{
+			name:    "can mark the order as paid",
+			header:  signed(succeeded),
+			payload: succeeded,
+			beforeTest: func() {
+				transactionRepoMock.EXPECT().FindByReference(context.Background(), "fake", "ref-1").Return(pending, nil)
+				orderRepoMock.EXPECT().UpdateStatus(context.Background(), order.StatusPendingPayment, order.TransitionParam{
+					OrderID: "1", Status: order.StatusPaid, Reason: "paid via fake",
+				}).Return(order.History{}, nil)
+				transactionRepoMock.EXPECT().Settle(context.Background(), "10", payment.StatusSucceeded, []byte(succeeded)).Return(nil)
+			},
+			wantStatus: payment.StatusSucceeded,
+		},
+		{
+			name:    "can settle the failed payment without touching the order",
+			header:  signed(failed),
+			payload: failed,
+			beforeTest: func() {
+				transactionRepoMock.EXPECT().FindByReference(context.Background(), "fake", "ref-1").Return(pending, nil)
+				transactionRepoMock.EXPECT().Settle(context.Background(), "10", payment.StatusFailed, []byte(failed)).Return(nil)
+			},
+			wantStatus: payment.StatusFailed,
+		},
+		{
+			name:    "can keep the payment of the cancelled order",
+			header:  signed(succeeded),
+			payload: succeeded,
+			beforeTest: func() {
+				transactionRepoMock.EXPECT().FindByReference(context.Background(), "fake", "ref-1").Return(pending, nil)
+				orderRepoMock.EXPECT().UpdateStatus(context.Background(), order.StatusPendingPayment, gomock.Any()).
+					Return(order.History{}, order.ErrInvalidStatusTransition)
+				transactionRepoMock.EXPECT().Settle(context.Background(), "10", payment.StatusSucceeded, []byte(succeeded)).Return(nil)
+			},
+			wantStatus: payment.StatusSucceeded,
+		},
+		{
+			name:    "can acknowledge the redelivered notification",
+			header:  signed(succeeded),
+			payload: succeeded,
+			beforeTest: func() {
+				settled := pending
+				settled.Status = payment.StatusSucceeded
+				transactionRepoMock.EXPECT().FindByReference(context.Background(), "fake", "ref-1").Return(settled, nil)
+			},
+			wantStatus: payment.StatusSucceeded,
+		},
+		{
+			name:    "can reject mismatch amount",
+			header:  signed(`{"reference":"ref-1","status":"succeeded","amount":1}`),
+			payload: `{"reference":"ref-1","status":"succeeded","amount":1}`,
+			beforeTest: func() {
+				transactionRepoMock.EXPECT().FindByReference(context.Background(), "fake", "ref-1").Return(pending, nil)
+			},
+			wantErr: ErrAmountMismatch,
+		},
+		{
+			name:    "can reject mismatch currency",
+			header:  signed(`{"reference":"ref-1","status":"succeeded","amount":{"amount":"12.50","currency":"EUR"}}`),
+			payload: `{"reference":"ref-1","status":"succeeded","amount":{"amount":"12.50","currency":"EUR"}}`,
+			beforeTest: func() {
+				transactionRepoMock.EXPECT().FindByReference(context.Background(), "fake", "ref-1").Return(pending, nil)
+			},
+			wantErr: ErrAmountMismatch,
+		},
+		{
+			name:       "can reject invalid signature",
+			header:     http.Header{paymentpkg.FakeSignatureHeader: []string{"00"}},
+			payload:    succeeded,
+			beforeTest: func() {},
+			wantErr:    paymentpkg.ErrInvalidSignature,
+		},
+		{
+			name:    "can handle unknown reference",
+			header:  signed(succeeded),
+			payload: succeeded,
+			beforeTest: func() {
+				transactionRepoMock.EXPECT().FindByReference(context.Background(), "fake", "ref-1").Return(payment.Transaction{}, ErrNotFound)
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewPaymentUseCase(transactionRepoMock, orderRepoMock, manager)
+			got, err := uc.HandleNotification(context.Background(), "fake", tt.header, []byte(tt.payload))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("HandleNotification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got.Status != tt.wantStatus {
+				t.Errorf("HandleNotification() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
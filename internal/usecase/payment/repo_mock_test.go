@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment.go
+
+// Package payment is a generated GoMock package.
+package payment
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	order "github.com/rendyananta/example-online-book-store/internal/entity/order"
+	payment "github.com/rendyananta/example-online-book-store/internal/entity/payment"
+	payment0 "github.com/rendyananta/example-online-book-store/pkg/payment"
+)
+
+// MocktransactionRepo is a mock of transactionRepo interface.
+type MocktransactionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionRepoMockRecorder
+}
+
+// MocktransactionRepoMockRecorder is the mock recorder for MocktransactionRepo.
+type MocktransactionRepoMockRecorder struct {
+	mock *MocktransactionRepo
+}
+
+// NewMocktransactionRepo creates a new mock instance.
+func NewMocktransactionRepo(ctrl *gomock.Controller) *MocktransactionRepo {
+	mock := &MocktransactionRepo{ctrl: ctrl}
+	mock.recorder = &MocktransactionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionRepo) EXPECT() *MocktransactionRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MocktransactionRepo) Create(ctx context.Context, param payment.Transaction) (payment.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, param)
+	ret0, _ := ret[0].(payment.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocktransactionRepoMockRecorder) Create(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocktransactionRepo)(nil).Create), ctx, param)
+}
+
+// FindByReference mocks base method.
+func (m *MocktransactionRepo) FindByReference(ctx context.Context, gateway, reference string) (payment.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByReference", ctx, gateway, reference)
+	ret0, _ := ret[0].(payment.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByReference indicates an expected call of FindByReference.
+func (mr *MocktransactionRepoMockRecorder) FindByReference(ctx, gateway, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByReference", reflect.TypeOf((*MocktransactionRepo)(nil).FindByReference), ctx, gateway, reference)
+}
+
+// FindPendingByOrderID mocks base method.
+func (m *MocktransactionRepo) FindPendingByOrderID(ctx context.Context, orderID, gateway string) (payment.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingByOrderID", ctx, orderID, gateway)
+	ret0, _ := ret[0].(payment.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingByOrderID indicates an expected call of FindPendingByOrderID.
+func (mr *MocktransactionRepoMockRecorder) FindPendingByOrderID(ctx, orderID, gateway interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingByOrderID", reflect.TypeOf((*MocktransactionRepo)(nil).FindPendingByOrderID), ctx, orderID, gateway)
+}
+
+// Settle mocks base method.
+func (m *MocktransactionRepo) Settle(ctx context.Context, id string, status payment.Status, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Settle", ctx, id, status, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Settle indicates an expected call of Settle.
+func (mr *MocktransactionRepoMockRecorder) Settle(ctx, id, status, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Settle", reflect.TypeOf((*MocktransactionRepo)(nil).Settle), ctx, id, status, payload)
+}
+
+// MockorderRepo is a mock of orderRepo interface.
+type MockorderRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderRepoMockRecorder
+}
+
+// MockorderRepoMockRecorder is the mock recorder for MockorderRepo.
+type MockorderRepoMockRecorder struct {
+	mock *MockorderRepo
+}
+
+// NewMockorderRepo creates a new mock instance.
+func NewMockorderRepo(ctrl *gomock.Controller) *MockorderRepo {
+	mock := &MockorderRepo{ctrl: ctrl}
+	mock.recorder = &MockorderRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderRepo) EXPECT() *MockorderRepoMockRecorder {
+	return m.recorder
+}
+
+// GetDetailByID mocks base method.
+func (m *MockorderRepo) GetDetailByID(ctx context.Context, orderID string) (order.Main, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetailByID", ctx, orderID)
+	ret0, _ := ret[0].(order.Main)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetailByID indicates an expected call of GetDetailByID.
+func (mr *MockorderRepoMockRecorder) GetDetailByID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailByID", reflect.TypeOf((*MockorderRepo)(nil).GetDetailByID), ctx, orderID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockorderRepo) UpdateStatus(ctx context.Context, from order.Status, param order.TransitionParam) (order.History, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, from, param)
+	ret0, _ := ret[0].(order.History)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockorderRepoMockRecorder) UpdateStatus(ctx, from, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockorderRepo)(nil).UpdateStatus), ctx, from, param)
+}
+
+// MockgatewayManager is a mock of gatewayManager interface.
+type MockgatewayManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockgatewayManagerMockRecorder
+}
+
+// MockgatewayManagerMockRecorder is the mock recorder for MockgatewayManager.
+type MockgatewayManagerMockRecorder struct {
+	mock *MockgatewayManager
+}
+
+// NewMockgatewayManager creates a new mock instance.
+func NewMockgatewayManager(ctrl *gomock.Controller) *MockgatewayManager {
+	mock := &MockgatewayManager{ctrl: ctrl}
+	mock.recorder = &MockgatewayManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgatewayManager) EXPECT() *MockgatewayManagerMockRecorder {
+	return m.recorder
+}
+
+// Gateway mocks base method.
+func (m *MockgatewayManager) Gateway(name payment0.GatewayName) (payment0.GatewayName, payment0.Gateway, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gateway", name)
+	ret0, _ := ret[0].(payment0.GatewayName)
+	ret1, _ := ret[1].(payment0.Gateway)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Gateway indicates an expected call of Gateway.
+func (mr *MockgatewayManagerMockRecorder) Gateway(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gateway", reflect.TypeOf((*MockgatewayManager)(nil).Gateway), name)
+}
@@ -0,0 +1,160 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
+)
+
+//go:generate mockgen -source=pricing.go -destination=pricing_mock_test.go -package pricing
+type voucherRepo interface {
+	FindByCodes(ctx context.Context, codes []string) ([]voucher.Voucher, error)
+}
+
+type shippingCalculator interface {
+	Calculate(ctx context.Context, parcel shipping.Parcel) (money.Money, error)
+}
+
+type exchangeRates interface {
+	Rate(ctx context.Context, from, to money.Currency) (money.Rate, error)
+}
+
+// Step prices a part of the order, it may append its own order lines to the quote.
+type Step interface {
+	Apply(ctx context.Context, quote *Quote) error
+}
+
+type StepName = string
+
+const (
+	StepNameSubtotal    StepName = "subtotal"
+	StepNameDiscount    StepName = "discount"
+	StepNameTax         StepName = "tax"
+	StepNamePlatformFee StepName = "platform_fee"
+	StepNameShipping    StepName = "shipping"
+	StepNameConversion  StepName = "conversion"
+)
+
+// DefaultSteps prices the books first, the discounts and the tax only take the books into account.
+// The conversion comes last, thus every line is priced in the currency of the books before it is converted.
+var DefaultSteps = []StepName{StepNameSubtotal, StepNameDiscount, StepNameTax, StepNamePlatformFee, StepNameShipping,
+	StepNameConversion}
+
+var (
+	ErrStepUnregistered = errors.New("pricing step not registered")
+	ErrCurrencyMismatch = order.ErrCurrencyMismatch
+)
+
+type Item struct {
+	Book     book.Book
+	Quantity int
+}
+
+// Quote is the order being priced, the steps append the order lines and keep the grand total up to date.
+// Every line is charged in the quote currency, which is the currency of the books until it is converted
+// into the target currency.
+type Quote struct {
+	Currency money.Currency
+	// TargetCurrency is requested by the customer, empty keeps the currency of the books.
+	TargetCurrency money.Currency
+	// BaseCurrency is the currency of the books, the ExchangeRate converted the lines from it into the quote currency.
+	BaseCurrency    money.Currency
+	ExchangeRate    money.Rate
+	UserID          string
+	Items           []Item
+	VoucherCodes    []string
+	ShippingAddress *address.Address
+	Lines           []order.Line
+	GrandTotal      money.Money
+}
+
+// AddLine appends the line and adds its subtotal to the grand total, the line must be in the quote currency.
+func (q *Quote) AddLine(line order.Line) error {
+	if line.Subtotal.Currency() != q.Currency {
+		return ErrCurrencyMismatch
+	}
+
+	q.Lines = append(q.Lines, line)
+	q.GrandTotal = q.GrandTotal.Add(line.Subtotal)
+
+	return nil
+}
+
+// Subtotal sums the subtotal of the lines of the given types.
+func (q *Quote) Subtotal(types ...order.LineReferenceType) money.Money {
+	total := money.Zero(q.Currency)
+	for _, line := range q.Lines {
+		if slices.Contains(types, line.LineReferenceType) {
+			total = total.Add(line.Subtotal)
+		}
+	}
+
+	return total
+}
+
+type Config struct {
+	Steps []StepName
+}
+
+// Pipeline runs the registered steps in the configured order.
+type Pipeline struct {
+	stepSet map[StepName]Step
+	config  Config
+}
+
+func NewPipeline(cfg Config) Pipeline {
+	if len(cfg.Steps) == 0 {
+		cfg.Steps = DefaultSteps
+	}
+
+	return Pipeline{
+		config:  cfg,
+		stepSet: make(map[StepName]Step),
+	}
+}
+
+func (p Pipeline) Register(name StepName, step Step) {
+	p.stepSet[name] = step
+}
+
+// Validate ensures every configured step is registered.
+func (p Pipeline) Validate() error {
+	for _, name := range p.config.Steps {
+		if _, registered := p.stepSet[name]; !registered {
+			return ErrStepUnregistered
+		}
+	}
+
+	return nil
+}
+
+func (p Pipeline) Price(ctx context.Context, quote *Quote) error {
+	for _, name := range p.config.Steps {
+		step, registered := p.stepSet[name]
+		if !registered {
+			return ErrStepUnregistered
+		}
+
+		if err := step.Apply(ctx, quote); err != nil {
+			return err
+		}
+	}
+
+	if quote.BaseCurrency == "" {
+		quote.BaseCurrency = quote.Currency
+	}
+
+	// the target currency is left unconverted when the conversion step is not configured.
+	if quote.TargetCurrency != "" && quote.TargetCurrency != quote.Currency {
+		return ErrCurrencyMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pricing.go
+
+// Package pricing is a generated GoMock package.
+package pricing
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	voucher "github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	money "github.com/rendyananta/example-online-book-store/pkg/money"
+	shipping "github.com/rendyananta/example-online-book-store/pkg/shipping"
+)
+
+// MockvoucherRepo is a mock of voucherRepo interface.
+type MockvoucherRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockvoucherRepoMockRecorder
+}
+
+// MockvoucherRepoMockRecorder is the mock recorder for MockvoucherRepo.
+type MockvoucherRepoMockRecorder struct {
+	mock *MockvoucherRepo
+}
+
+// NewMockvoucherRepo creates a new mock instance.
+func NewMockvoucherRepo(ctrl *gomock.Controller) *MockvoucherRepo {
+	mock := &MockvoucherRepo{ctrl: ctrl}
+	mock.recorder = &MockvoucherRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockvoucherRepo) EXPECT() *MockvoucherRepoMockRecorder {
+	return m.recorder
+}
+
+// FindByCodes mocks base method.
+func (m *MockvoucherRepo) FindByCodes(ctx context.Context, codes []string) ([]voucher.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCodes", ctx, codes)
+	ret0, _ := ret[0].([]voucher.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCodes indicates an expected call of FindByCodes.
+func (mr *MockvoucherRepoMockRecorder) FindByCodes(ctx, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCodes", reflect.TypeOf((*MockvoucherRepo)(nil).FindByCodes), ctx, codes)
+}
+
+// MockshippingCalculator is a mock of shippingCalculator interface.
+type MockshippingCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockshippingCalculatorMockRecorder
+}
+
+// MockshippingCalculatorMockRecorder is the mock recorder for MockshippingCalculator.
+type MockshippingCalculatorMockRecorder struct {
+	mock *MockshippingCalculator
+}
+
+// NewMockshippingCalculator creates a new mock instance.
+func NewMockshippingCalculator(ctrl *gomock.Controller) *MockshippingCalculator {
+	mock := &MockshippingCalculator{ctrl: ctrl}
+	mock.recorder = &MockshippingCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshippingCalculator) EXPECT() *MockshippingCalculatorMockRecorder {
+	return m.recorder
+}
+
+// Calculate mocks base method.
+func (m *MockshippingCalculator) Calculate(ctx context.Context, parcel shipping.Parcel) (money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", ctx, parcel)
+	ret0, _ := ret[0].(money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calculate indicates an expected call of Calculate.
+func (mr *MockshippingCalculatorMockRecorder) Calculate(ctx, parcel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockshippingCalculator)(nil).Calculate), ctx, parcel)
+}
+
+// MockexchangeRates is a mock of exchangeRates interface.
+type MockexchangeRates struct {
+	ctrl     *gomock.Controller
+	recorder *MockexchangeRatesMockRecorder
+}
+
+// MockexchangeRatesMockRecorder is the mock recorder for MockexchangeRates.
+type MockexchangeRatesMockRecorder struct {
+	mock *MockexchangeRates
+}
+
+// NewMockexchangeRates creates a new mock instance.
+func NewMockexchangeRates(ctrl *gomock.Controller) *MockexchangeRates {
+	mock := &MockexchangeRates{ctrl: ctrl}
+	mock.recorder = &MockexchangeRatesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockexchangeRates) EXPECT() *MockexchangeRatesMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockexchangeRates) Rate(ctx context.Context, from, to money.Currency) (money.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(money.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockexchangeRatesMockRecorder) Rate(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockexchangeRates)(nil).Rate), ctx, from, to)
+}
+
+// MockStep is a mock of Step interface.
+type MockStep struct {
+	ctrl     *gomock.Controller
+	recorder *MockStepMockRecorder
+}
+
+// MockStepMockRecorder is the mock recorder for MockStep.
+type MockStepMockRecorder struct {
+	mock *MockStep
+}
+
+// NewMockStep creates a new mock instance.
+func NewMockStep(ctrl *gomock.Controller) *MockStep {
+	mock := &MockStep{ctrl: ctrl}
+	mock.recorder = &MockStepMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStep) EXPECT() *MockStepMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockStep) Apply(ctx context.Context, quote *Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockStepMockRecorder) Apply(ctx, quote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockStep)(nil).Apply), ctx, quote)
+}
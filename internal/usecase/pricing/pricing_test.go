@@ -0,0 +1,119 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestPipeline_Price(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := NewMockStep(ctrl)
+	second := NewMockStep(ctrl)
+
+	p := NewPipeline(Config{Steps: []StepName{"second", "first"}})
+	p.Register("first", first)
+	p.Register("second", second)
+
+	quote := &Quote{}
+	gomock.InOrder(
+		second.EXPECT().Apply(context.Background(), quote).Return(nil),
+		first.EXPECT().Apply(context.Background(), quote).Return(nil),
+	)
+
+	if err := p.Price(context.Background(), quote); err != nil {
+		t.Errorf("Price() error = %v", err)
+	}
+
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if err := NewPipeline(Config{}).Validate(); !errors.Is(err, ErrStepUnregistered) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrStepUnregistered)
+	}
+
+	if err := NewPipeline(Config{}).Price(context.Background(), quote); !errors.Is(err, ErrStepUnregistered) {
+		t.Errorf("Price() error = %v, want %v", err, ErrStepUnregistered)
+	}
+}
+
+func TestPipeline_Price_unconverted(t *testing.T) {
+	p := NewPipeline(Config{Steps: []StepName{StepNameSubtotal}})
+	p.Register(StepNameSubtotal, NewSubtotalStep())
+
+	quote := &Quote{
+		TargetCurrency: money.EUR,
+		Items:          []Item{{Book: book.Book{ID: "10", Price: money.New(120, money.USD)}, Quantity: 1}},
+	}
+
+	// the books are left in their currency without the conversion step.
+	if err := p.Price(context.Background(), quote); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Price() error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+
+	quote.TargetCurrency = money.USD
+	quote.Lines, quote.GrandTotal, quote.Currency = nil, money.Money{}, ""
+	if err := p.Price(context.Background(), quote); err != nil || quote.BaseCurrency != money.USD || !quote.ExchangeRate.IsIdentity() {
+		t.Errorf("Price() got = %+v, %v", quote, err)
+	}
+}
+
+func TestPipeline_Price_fees(t *testing.T) {
+	p := NewPipeline(Config{Steps: []StepName{StepNameSubtotal, StepNameTax, StepNamePlatformFee}})
+	p.Register(StepNameSubtotal, NewSubtotalStep())
+	p.Register(StepNameTax, NewTaxStep(TaxConfig{Rate: 11, RegionRates: map[string]float64{"bali": 0}}))
+	p.Register(StepNamePlatformFee, NewPlatformFeeStep(PlatformFeeConfig{Flat: money.New(50, money.USD), Percentage: 1}))
+
+	items := []Item{
+		{Book: book.Book{ID: "10", Price: money.New(120, money.USD)}, Quantity: 1},
+		{Book: book.Book{ID: "11", Price: money.New(345, money.USD)}, Quantity: 2},
+	}
+
+	tests := []struct {
+		name           string
+		region         string
+		wantLines      []order.LineReferenceType
+		wantGrandTotal money.Money
+	}{
+		{
+			name:      "can add the tax and platform fee lines",
+			region:    "DKI Jakarta",
+			wantLines: []order.LineReferenceType{order.LineReferenceTypeBook, order.LineReferenceTypeBook, order.LineReferenceTypeTax, order.LineReferenceTypePlatformFee},
+			// 11% tax of 8.10 is 0.891, the platform fee is 0.50 plus 1% of 8.10 rounded half up.
+			wantGrandTotal: money.New(957, money.USD),
+		},
+		{
+			name:           "can leave out the zero rated tax",
+			region:         "Bali",
+			wantLines:      []order.LineReferenceType{order.LineReferenceTypeBook, order.LineReferenceTypeBook, order.LineReferenceTypePlatformFee},
+			wantGrandTotal: money.New(868, money.USD),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := &Quote{Items: items, ShippingAddress: &address.Address{Region: tt.region}}
+			if err := p.Price(context.Background(), quote); err != nil {
+				t.Fatalf("Price() error = %v", err)
+			}
+
+			var lines []order.LineReferenceType
+			for _, line := range quote.Lines {
+				lines = append(lines, line.LineReferenceType)
+			}
+
+			if !reflect.DeepEqual(lines, tt.wantLines) || !quote.GrandTotal.Equal(tt.wantGrandTotal) {
+				t.Errorf("Price() lines = %v, grand total = %v, want %v and %v", lines, quote.GrandTotal, tt.wantLines, tt.wantGrandTotal)
+			}
+		})
+	}
+}
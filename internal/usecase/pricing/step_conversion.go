@@ -0,0 +1,47 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// ConversionStep converts every line into the target currency of the quote, the amount of each line is rounded
+// half up and its subtotal is recalculated, thus the grand total is always the sum of the converted lines.
+type ConversionStep struct {
+	rates exchangeRates
+}
+
+func NewConversionStep(rates exchangeRates) ConversionStep {
+	return ConversionStep{rates: rates}
+}
+
+func (s ConversionStep) Apply(ctx context.Context, quote *Quote) error {
+	quote.BaseCurrency = quote.Currency
+	if quote.TargetCurrency == "" || quote.TargetCurrency == quote.Currency {
+		return nil
+	}
+
+	rate, err := s.rates.Rate(ctx, quote.Currency, quote.TargetCurrency)
+	if err != nil {
+		return err
+	}
+
+	lines := quote.Lines
+	quote.Currency = quote.TargetCurrency
+	quote.ExchangeRate = rate
+	quote.Lines = make([]order.Line, 0, len(lines))
+	quote.GrandTotal = money.Zero(quote.TargetCurrency)
+
+	for _, line := range lines {
+		line.Amount = line.Amount.Convert(quote.TargetCurrency, rate, money.RoundHalfUp)
+		line.Subtotal = line.Amount.Mul(int64(line.Quantity))
+
+		if err = quote.AddLine(line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestConversionStep_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ratesMock := NewMockexchangeRates(ctrl)
+	step := NewConversionStep(ratesMock)
+
+	newQuote := func(target money.Currency) *Quote {
+		quote := &Quote{Currency: money.USD, TargetCurrency: target}
+		_ = quote.AddLine(order.Line{LineReferenceType: order.LineReferenceTypeBook, Amount: money.New(509, money.USD),
+			Quantity: 3, Subtotal: money.New(1527, money.USD)})
+		_ = quote.AddLine(order.Line{LineReferenceType: order.LineReferenceTypeDiscount, Amount: money.New(-152, money.USD),
+			Quantity: 1, Subtotal: money.New(-152, money.USD)})
+
+		return quote
+	}
+
+	rate, _ := money.ParseRate("0.9205")
+	ratesMock.EXPECT().Rate(context.Background(), money.USD, money.EUR).Return(rate, nil)
+
+	quote := newQuote(money.EUR)
+	if err := step.Apply(context.Background(), quote); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	// 5.09 USD is 4.685345 EUR, the line is charged 3 times the rounded 4.69 EUR.
+	if quote.Currency != money.EUR || quote.BaseCurrency != money.USD || quote.ExchangeRate.String() != "0.9205" ||
+		!quote.Lines[0].Subtotal.Equal(money.New(1407, money.EUR)) || !quote.Lines[1].Subtotal.Equal(money.New(-140, money.EUR)) ||
+		!quote.GrandTotal.Equal(money.New(1267, money.EUR)) {
+		t.Errorf("Apply() got = %+v", quote)
+	}
+
+	quote = newQuote("")
+	if err := step.Apply(context.Background(), quote); err != nil || quote.Currency != money.USD ||
+		!quote.ExchangeRate.IsIdentity() || !quote.GrandTotal.Equal(money.New(1375, money.USD)) {
+		t.Errorf("Apply() without target currency got = %+v, %v", quote, err)
+	}
+
+	ratesMock.EXPECT().Rate(context.Background(), money.USD, money.JPY).Return(money.Rate{}, errors.New("currency is not supported"))
+	if err := step.Apply(context.Background(), newQuote(money.JPY)); err == nil {
+		t.Errorf("Apply() error = %v, want the rate error", err)
+	}
+}
@@ -0,0 +1,84 @@
+package pricing
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// DiscountStep adds the discount line of each voucher code in the given order,
+// the discounts never take the grand total below zero.
+type DiscountStep struct {
+	voucherRepo voucherRepo
+}
+
+func NewDiscountStep(voucherRepo voucherRepo) DiscountStep {
+	return DiscountStep{voucherRepo: voucherRepo}
+}
+
+func (s DiscountStep) Apply(ctx context.Context, quote *Quote) error {
+	codes := make([]string, 0, len(quote.VoucherCodes))
+	for _, code := range quote.VoucherCodes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+
+	if len(codes) == 0 {
+		return nil
+	}
+
+	vouchers, err := s.voucherRepo.FindByCodes(ctx, codes)
+	if err != nil {
+		return err
+	}
+
+	voucherByCode := make(map[string]voucher.Voucher)
+	for _, v := range vouchers {
+		voucherByCode[v.Code] = v
+	}
+
+	items := make([]voucher.Item, 0, len(quote.Lines))
+	for _, line := range quote.Lines {
+		if bookItem, isBook := line.LineItem.(book.Book); isBook && line.LineReferenceType == order.LineReferenceTypeBook {
+			items = append(items, voucher.Item{Book: bookItem, Subtotal: line.Subtotal})
+		}
+	}
+
+	now := time.Now()
+
+	for _, code := range codes {
+		v, ok := voucherByCode[code]
+		if !ok {
+			return voucher.ErrNotFound
+		}
+
+		discount, err := v.Discount(items, now)
+		if err != nil {
+			return err
+		}
+
+		discount = money.Min(discount, quote.GrandTotal)
+
+		err = quote.AddLine(order.Line{
+			LineReferenceType: order.LineReferenceTypeDiscount,
+			LineReferenceID:   v.ID,
+			LineItem:          v,
+			Amount:            discount.Neg(),
+			Quantity:          1,
+			Subtotal:          discount.Neg(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestDiscountStep_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	voucherRepoMock := NewMockvoucherRepo(ctrl)
+
+	items := []Item{
+		{Book: book.Book{ID: "10", Price: money.New(1000, money.USD), Genres: []book.Genre{{ID: "g1"}}}, Quantity: 1},
+		{Book: book.Book{ID: "11", Price: money.New(500, money.USD)}, Quantity: 2},
+	}
+
+	fiction := voucher.Voucher{ID: "v1", Code: "FICTION50", DiscountType: voucher.DiscountTypePercentage, Percentage: 50, ScopeType: voucher.ScopeTypeGenre, ScopeID: "g1"}
+	flat := voucher.Voucher{ID: "v2", Code: "FLAT20", DiscountType: voucher.DiscountTypeFixed, Amount: money.New(2000, money.USD)}
+
+	tests := []struct {
+		name           string
+		codes          []string
+		beforeTest     func()
+		wantGrandTotal money.Money
+		wantDiscounts  []money.Money
+		wantErr        error
+	}{
+		{
+			name:  "can apply the vouchers as discount lines",
+			codes: []string{"fiction50", "FLAT20", "FICTION50"},
+			beforeTest: func() {
+				voucherRepoMock.EXPECT().FindByCodes(context.Background(), []string{"FICTION50", "FLAT20"}).
+					Return([]voucher.Voucher{flat, fiction}, nil)
+			},
+			wantGrandTotal: money.New(0, money.USD),
+			wantDiscounts:  []money.Money{money.New(-500, money.USD), money.New(-1500, money.USD)},
+		},
+		{
+			name:       "can skip without voucher codes",
+			beforeTest: func() {},
+			// the books subtotal is left as is.
+			wantGrandTotal: money.New(2000, money.USD),
+		},
+		{
+			name:  "can reject unknown voucher",
+			codes: []string{"UNKNOWN"},
+			beforeTest: func() {
+				voucherRepoMock.EXPECT().FindByCodes(context.Background(), []string{"UNKNOWN"}).Return([]voucher.Voucher{}, nil)
+			},
+			wantErr: voucher.ErrNotFound,
+		},
+		{
+			name:  "can reject the voucher not applicable to the books",
+			codes: []string{"FICTION50"},
+			beforeTest: func() {
+				scoped := fiction
+				scoped.ScopeID = "g9"
+				voucherRepoMock.EXPECT().FindByCodes(context.Background(), []string{"FICTION50"}).Return([]voucher.Voucher{scoped}, nil)
+			},
+			wantErr: voucher.ErrNotEligible,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			quote := &Quote{Items: items, VoucherCodes: tt.codes}
+			_ = NewSubtotalStep().Apply(context.Background(), quote)
+
+			err := NewDiscountStep(voucherRepoMock).Apply(context.Background(), quote)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			var discounts []money.Money
+			for _, line := range quote.Lines {
+				if line.LineReferenceType == order.LineReferenceTypeDiscount {
+					discounts = append(discounts, line.Subtotal)
+				}
+			}
+
+			if !quote.GrandTotal.Equal(tt.wantGrandTotal) || !reflect.DeepEqual(discounts, tt.wantDiscounts) {
+				t.Errorf("Apply() grand total = %v, discounts = %v, want %v and %v", quote.GrandTotal, discounts, tt.wantGrandTotal, tt.wantDiscounts)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type PlatformFeeConfig struct {
+	Flat money.Money
+	// Percentage of the discounted books subtotal, added on top of the flat fee. It is rounded half up.
+	Percentage float64
+}
+
+// PlatformFeeStep adds the platform fee line, nothing is added when the fee is zero.
+type PlatformFeeStep struct {
+	config PlatformFeeConfig
+}
+
+func NewPlatformFeeStep(config PlatformFeeConfig) PlatformFeeStep {
+	return PlatformFeeStep{config: config}
+}
+
+func (s PlatformFeeStep) Apply(_ context.Context, quote *Quote) error {
+	base := quote.Subtotal(order.LineReferenceTypeBook, order.LineReferenceTypeDiscount)
+	if !s.config.Flat.IsZero() && s.config.Flat.Currency() != quote.Currency {
+		return ErrCurrencyMismatch
+	}
+
+	fee := base.Percent(s.config.Percentage, money.RoundHalfUp).Add(s.config.Flat)
+	if !fee.IsPositive() {
+		return nil
+	}
+
+	return quote.AddLine(order.Line{
+		LineReferenceType: order.LineReferenceTypePlatformFee,
+		Amount:            fee,
+		Quantity:          1,
+		Subtotal:          fee,
+	})
+}
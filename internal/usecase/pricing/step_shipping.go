@@ -0,0 +1,41 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
+)
+
+// ShippingStep adds the shipping fee line of the items to the shipping address.
+type ShippingStep struct {
+	calculator shippingCalculator
+}
+
+func NewShippingStep(calculator shippingCalculator) ShippingStep {
+	return ShippingStep{calculator: calculator}
+}
+
+func (s ShippingStep) Apply(ctx context.Context, quote *Quote) error {
+	if quote.ShippingAddress == nil {
+		return order.ErrShippingAddressRequired
+	}
+
+	parcel := shipping.Parcel{Region: quote.ShippingAddress.Region, Items: make([]shipping.Item, 0, len(quote.Items))}
+	for _, item := range quote.Items {
+		parcel.Items = append(parcel.Items, shipping.Item{Weight: item.Book.Weight, Quantity: item.Quantity})
+	}
+
+	fee, err := s.calculator.Calculate(ctx, parcel)
+	if err != nil {
+		return err
+	}
+
+	return quote.AddLine(order.Line{
+		LineReferenceType: order.LineReferenceTypeShippingFee,
+		LineReferenceID:   quote.ShippingAddress.ID,
+		Amount:            fee,
+		Quantity:          1,
+		Subtotal:          fee,
+	})
+}
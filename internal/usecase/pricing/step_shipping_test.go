@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/address"
+	"github.com/rendyananta/example-online-book-store/internal/entity/book"
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+	"github.com/rendyananta/example-online-book-store/pkg/shipping"
+)
+
+func TestShippingStep_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	calculatorMock := NewMockshippingCalculator(ctrl)
+	step := NewShippingStep(calculatorMock)
+
+	quote := &Quote{
+		Items:           []Item{{Book: book.Book{ID: "10", Weight: 300}, Quantity: 2}},
+		ShippingAddress: &address.Address{ID: "a1", Region: "Bali"},
+		Currency:        money.USD,
+		GrandTotal:      money.New(1000, money.USD),
+	}
+
+	calculatorMock.EXPECT().Calculate(context.Background(), shipping.Parcel{
+		Region: "Bali",
+		Items:  []shipping.Item{{Weight: 300, Quantity: 2}},
+	}).Return(money.New(350, money.USD), nil)
+
+	if err := step.Apply(context.Background(), quote); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if len(quote.Lines) != 1 || quote.Lines[0].LineReferenceType != order.LineReferenceTypeShippingFee ||
+		quote.Lines[0].LineReferenceID != "a1" || !quote.GrandTotal.Equal(money.New(1350, money.USD)) {
+		t.Errorf("Apply() got = %+v", quote)
+	}
+
+	// the fee is charged in the currency of the books.
+	calculatorMock.EXPECT().Calculate(context.Background(), gomock.Any()).Return(money.New(350, money.EUR), nil)
+	if err := step.Apply(context.Background(), quote); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Apply() error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+
+	if err := step.Apply(context.Background(), &Quote{}); !errors.Is(err, order.ErrShippingAddressRequired) {
+		t.Errorf("Apply() error = %v, want %v", err, order.ErrShippingAddressRequired)
+	}
+}
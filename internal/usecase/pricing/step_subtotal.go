@@ -0,0 +1,36 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+)
+
+// SubtotalStep adds the book line of every item using the book price, the books decide the quote currency.
+type SubtotalStep struct{}
+
+func NewSubtotalStep() SubtotalStep {
+	return SubtotalStep{}
+}
+
+func (s SubtotalStep) Apply(_ context.Context, quote *Quote) error {
+	for _, item := range quote.Items {
+		if quote.Currency == "" {
+			quote.Currency = item.Book.Price.Currency()
+		}
+
+		err := quote.AddLine(order.Line{
+			LineReferenceType: order.LineReferenceTypeBook,
+			LineReferenceID:   item.Book.ID,
+			LineItem:          item.Book,
+			Amount:            item.Book.Price,
+			Quantity:          item.Quantity,
+			Subtotal:          item.Book.Price.Mul(int64(item.Quantity)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package pricing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/order"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type TaxConfig struct {
+	// Rate in percent of the discounted books subtotal, used for the region without its own rate.
+	Rate float64
+	// RegionRates holds the rate in percent keyed by the lower-cased region of the shipping address.
+	RegionRates map[string]float64
+	// Rounding of the tax to the minor unit, half up by default.
+	Rounding money.RoundingMode
+}
+
+// Tax is the line item of the tax line.
+type Tax struct {
+	Region string  `json:"region"`
+	Rate   float64 `json:"rate"`
+}
+
+// TaxStep adds the tax line of the discounted books subtotal, nothing is added when the rate is zero.
+type TaxStep struct {
+	config TaxConfig
+}
+
+func NewTaxStep(config TaxConfig) TaxStep {
+	return TaxStep{config: config}
+}
+
+func (s TaxStep) Apply(_ context.Context, quote *Quote) error {
+	var region string
+	if quote.ShippingAddress != nil {
+		region = quote.ShippingAddress.Region
+	}
+
+	rate, listed := s.config.RegionRates[strings.ToLower(strings.TrimSpace(region))]
+	if !listed {
+		rate = s.config.Rate
+	}
+
+	base := quote.Subtotal(order.LineReferenceTypeBook, order.LineReferenceTypeDiscount)
+	tax := base.Percent(rate, s.config.Rounding)
+	if !tax.IsPositive() {
+		return nil
+	}
+
+	return quote.AddLine(order.Line{
+		LineReferenceType: order.LineReferenceTypeTax,
+		LineReferenceID:   region,
+		LineItem:          Tax{Region: region, Rate: rate},
+		Amount:            tax,
+		Quantity:          1,
+		Subtotal:          tax,
+	})
+}
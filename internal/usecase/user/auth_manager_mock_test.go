@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	auth "github.com/rendyananta/example-online-book-store/pkg/auth"
 )
 
 // MockauthManager is a mock of authManager interface.
@@ -34,17 +35,33 @@ func (m *MockauthManager) EXPECT() *MockauthManagerMockRecorder {
 	return m.recorder
 }
 
-// Token mocks base method.
-func (m *MockauthManager) Token(ctx context.Context, userID string) (string, error) {
+// Refresh mocks base method.
+func (m *MockauthManager) Refresh(ctx context.Context, refreshToken string, currentRole auth.RoleResolver) (auth.Tokens, auth.UserSession, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Token", ctx, userID)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken, currentRole)
+	ret0, _ := ret[0].(auth.Tokens)
+	ret1, _ := ret[1].(auth.UserSession)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockauthManagerMockRecorder) Refresh(ctx, refreshToken, currentRole interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockauthManager)(nil).Refresh), ctx, refreshToken, currentRole)
+}
+
+// TokenPair mocks base method.
+func (m *MockauthManager) TokenPair(ctx context.Context, userID, userType, userAgent string) (auth.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenPair", ctx, userID, userType, userAgent)
+	ret0, _ := ret[0].(auth.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Token indicates an expected call of Token.
-func (mr *MockauthManagerMockRecorder) Token(ctx, userID interface{}) *gomock.Call {
+// TokenPair indicates an expected call of TokenPair.
+func (mr *MockauthManagerMockRecorder) TokenPair(ctx, userID, userType, userAgent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockauthManager)(nil).Token), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenPair", reflect.TypeOf((*MockauthManager)(nil).TokenPair), ctx, userID, userType, userAgent)
 }
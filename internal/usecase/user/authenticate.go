@@ -2,13 +2,16 @@ package user
 
 import (
 	"context"
+	"errors"
 	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
 	"golang.org/x/crypto/bcrypt"
 )
 
 //go:generate mockgen -source=authenticate.go -destination=auth_manager_mock_test.go -package user
 type authManager interface {
-	Token(ctx context.Context, userID string) (string, error)
+	TokenPair(ctx context.Context, userID string, userType string, userAgent string) (auth.Tokens, error)
+	Refresh(ctx context.Context, refreshToken string, currentRole auth.RoleResolver) (auth.Tokens, auth.UserSession, error)
 }
 
 type AuthenticatorUseCase struct {
@@ -34,17 +37,51 @@ func (a AuthenticatorUseCase) Authenticate(ctx context.Context, param user.Authe
 		return user.AuthenticateResult{}, err
 	}
 
-	token, err := a.authManager.Token(ctx, u.ID)
+	tokens, err := a.authManager.TokenPair(ctx, u.ID, u.Role, param.UserAgent)
 	if err != nil {
 		return user.AuthenticateResult{}, err
 	}
 
+	return authenticateResult(u, tokens), nil
+}
+
+// Refresh exchanges the refresh token for the next pair of tokens, the used refresh token cannot be exchanged again.
+// The next token carries the current role of the user, the deleted user cannot refresh.
+func (a AuthenticatorUseCase) Refresh(ctx context.Context, refreshToken string) (user.AuthenticateResult, error) {
+	var u user.User
+
+	tokens, _, err := a.authManager.Refresh(ctx, refreshToken, func(ctx context.Context, userID string) (string, error) {
+		var err error
+		u, err = a.userRepo.FindByID(ctx, userID)
+
+		return u.Role, err
+	})
+	if errors.Is(err, auth.ErrInvalidRefreshToken) || errors.Is(err, user.ErrNotFound) {
+		return user.AuthenticateResult{}, user.ErrInvalidRefreshToken
+	}
+
+	if errors.Is(err, auth.ErrRefreshTokenReused) {
+		return user.AuthenticateResult{}, user.ErrRefreshTokenReused
+	}
+
+	if err != nil {
+		return user.AuthenticateResult{}, err
+	}
+
+	return authenticateResult(u, tokens), nil
+}
+
+func authenticateResult(u user.User, tokens auth.Tokens) user.AuthenticateResult {
 	return user.AuthenticateResult{
 		User: user.User{
 			ID:    u.ID,
 			Name:  u.Name,
 			Email: u.Email,
+			Role:  u.Role,
 		},
-		Token: token,
-	}, nil
+		Token:            tokens.Token,
+		ExpiredAt:        tokens.ExpiredAt,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiredAt: tokens.RefreshExpiredAt,
+	}
 }
@@ -2,10 +2,13 @@ package user
 
 import (
 	"context"
+	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestAuthenticatorUseCase_Authenticate(t *testing.T) {
@@ -14,6 +17,7 @@ func TestAuthenticatorUseCase_Authenticate(t *testing.T) {
 
 	userRepoMock := NewMockuserRepo(ctrl)
 	authManagerMock := NewMockauthManager(ctrl)
+	expiredAt := time.Now().Add(time.Hour)
 
 	type fields struct {
 		userRepo    userRepo
@@ -40,8 +44,9 @@ func TestAuthenticatorUseCase_Authenticate(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				param: user.AuthenticateParam{
-					Email:    "user@example.com",
-					Password: "123123",
+					Email:     "user@example.com",
+					Password:  "123123",
+					UserAgent: "Firefox",
 				},
 			},
 			beforeTest: func() {
@@ -50,18 +55,22 @@ func TestAuthenticatorUseCase_Authenticate(t *testing.T) {
 					Name:     "User",
 					Email:    "user@example.com",
 					Password: "$2a$10$kzKHrJg9yufBEw3bpbUU8uoEtjAN3sREqWNR/b8eyX3s./1xSaAkq",
+					Role:     user.RoleStaff,
 				}, nil)
 
-				authManagerMock.EXPECT().Token(context.Background(), "1").
-					Return("token-example", nil)
+				authManagerMock.EXPECT().TokenPair(context.Background(), "1", user.RoleStaff, "Firefox").
+					Return(auth.Tokens{Token: "token-example", ExpiredAt: expiredAt, RefreshToken: "refresh-example"}, nil)
 			},
 			want: user.AuthenticateResult{
 				User: user.User{
 					ID:    "1",
 					Name:  "User",
 					Email: "user@example.com",
+					Role:  user.RoleStaff,
 				},
-				Token: "token-example",
+				Token:        "token-example",
+				ExpiredAt:    expiredAt,
+				RefreshToken: "refresh-example",
 			},
 			wantErr: false,
 		},
@@ -111,6 +120,134 @@ func TestAuthenticatorUseCase_Authenticate(t *testing.T) {
 	}
 }
 
+// refreshAs resolves the current role of the user the way the auth manager does, the resolver error refuses
+// the refresh.
+func refreshAs(userID string, tokens auth.Tokens) func(context.Context, string, auth.RoleResolver) (auth.Tokens, auth.UserSession, error) {
+	return func(ctx context.Context, _ string, currentRole auth.RoleResolver) (auth.Tokens, auth.UserSession, error) {
+		role, err := currentRole(ctx, userID)
+		if err != nil {
+			return auth.Tokens{}, auth.UserSession{}, err
+		}
+
+		return tokens, auth.UserSession{ID: userID, Type: role}, nil
+	}
+}
+
+func TestAuthenticatorUseCase_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepoMock := NewMockuserRepo(ctrl)
+	authManagerMock := NewMockauthManager(ctrl)
+
+	tests := []struct {
+		name       string
+		token      string
+		beforeTest func()
+		want       user.AuthenticateResult
+		wantErr    error
+	}{
+		{
+			name:  "can rotate the refresh token",
+			token: "session.secret",
+			beforeTest: func() {
+				authManagerMock.EXPECT().Refresh(context.Background(), "session.secret", gomock.Any()).
+					DoAndReturn(refreshAs("1", auth.Tokens{Token: "token-next", RefreshToken: "session.next"}))
+
+				userRepoMock.EXPECT().FindByID(context.Background(), "1").Return(user.User{
+					ID:       "1",
+					Name:     "User",
+					Email:    "user@example.com",
+					Password: "hashed",
+					Role:     user.RoleCustomer,
+				}, nil)
+			},
+			want: user.AuthenticateResult{
+				User: user.User{
+					ID:    "1",
+					Name:  "User",
+					Email: "user@example.com",
+					Role:  user.RoleCustomer,
+				},
+				Token:        "token-next",
+				RefreshToken: "session.next",
+			},
+		},
+		{
+			name:  "can refresh with the role changed after the login",
+			token: "session.promoted",
+			beforeTest: func() {
+				authManagerMock.EXPECT().Refresh(context.Background(), "session.promoted", gomock.Any()).
+					DoAndReturn(refreshAs("1", auth.Tokens{Token: "token-staff", RefreshToken: "session.next"}))
+
+				userRepoMock.EXPECT().FindByID(context.Background(), "1").Return(user.User{
+					ID:    "1",
+					Name:  "User",
+					Email: "user@example.com",
+					Role:  user.RoleStaff,
+				}, nil)
+			},
+			want: user.AuthenticateResult{
+				User: user.User{
+					ID:    "1",
+					Name:  "User",
+					Email: "user@example.com",
+					Role:  user.RoleStaff,
+				},
+				Token:        "token-staff",
+				RefreshToken: "session.next",
+			},
+		},
+		{
+			name:  "can reject the refresh of the deleted user",
+			token: "session.deleted",
+			beforeTest: func() {
+				authManagerMock.EXPECT().Refresh(context.Background(), "session.deleted", gomock.Any()).
+					DoAndReturn(refreshAs("1", auth.Tokens{}))
+
+				userRepoMock.EXPECT().FindByID(context.Background(), "1").Return(user.User{}, user.ErrNotFound)
+			},
+			wantErr: user.ErrInvalidRefreshToken,
+		},
+		{
+			name:  "can handle invalid refresh token",
+			token: "unknown",
+			beforeTest: func() {
+				authManagerMock.EXPECT().Refresh(context.Background(), "unknown", gomock.Any()).
+					Return(auth.Tokens{}, auth.UserSession{}, auth.ErrInvalidRefreshToken)
+			},
+			wantErr: user.ErrInvalidRefreshToken,
+		},
+		{
+			name:  "can handle reused refresh token",
+			token: "session.used",
+			beforeTest: func() {
+				authManagerMock.EXPECT().Refresh(context.Background(), "session.used", gomock.Any()).
+					Return(auth.Tokens{}, auth.UserSession{}, auth.ErrRefreshTokenReused)
+			},
+			wantErr: user.ErrRefreshTokenReused,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := AuthenticatorUseCase{
+				userRepo:    userRepoMock,
+				authManager: authManagerMock,
+			}
+			tt.beforeTest()
+
+			got, err := a.Refresh(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Refresh() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Refresh() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewAuthenticatorUseCase(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -26,6 +26,7 @@ func (r RegisterUseCase) Register(ctx context.Context, param user.RegisterParam)
 		Name:     param.Name,
 		Email:    param.Email,
 		Password: string(hashedPassword),
+		Role:     user.RoleCustomer,
 	})
 
 	if err != nil {
@@ -9,4 +9,6 @@ import (
 type userRepo interface {
 	FindByEmail(ctx context.Context, email string) (user.User, error)
 	Create(ctx context.Context, param user.User) (user.User, error)
+	FindByID(ctx context.Context, id string) (user.User, error)
+	UpdateRole(ctx context.Context, id string, role user.Role) error
 }
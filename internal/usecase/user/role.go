@@ -0,0 +1,35 @@
+package user
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+)
+
+// RoleAssignmentUseCase lets the admin promote or demote the other users.
+type RoleAssignmentUseCase struct {
+	userRepo userRepo
+}
+
+func NewRoleAssignmentUseCase(userRepo userRepo) (*RoleAssignmentUseCase, error) {
+	return &RoleAssignmentUseCase{
+		userRepo: userRepo,
+	}, nil
+}
+
+// AssignRole changes the role of the user, the access tokens issued before keep their role until
+// they are expired or refreshed.
+func (r RoleAssignmentUseCase) AssignRole(ctx context.Context, id string, role user.Role) (user.User, error) {
+	if err := r.userRepo.UpdateRole(ctx, id, role); err != nil {
+		return user.User{}, err
+	}
+
+	u, err := r.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	u.Password = ""
+
+	return u, nil
+}
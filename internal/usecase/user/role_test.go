@@ -0,0 +1,104 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	userrp "github.com/rendyananta/example-online-book-store/internal/repo/user"
+)
+
+func TestRoleAssignmentUseCase_AssignRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepoMock := NewMockuserRepo(ctrl)
+
+	type args struct {
+		ctx  context.Context
+		id   string
+		role user.Role
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func()
+		want       user.User
+		wantErr    bool
+	}{
+		{
+			name: "can assign role",
+			args: args{
+				ctx:  context.Background(),
+				id:   "1234",
+				role: user.RoleStaff,
+			},
+			beforeTest: func() {
+				userRepoMock.EXPECT().UpdateRole(context.Background(), "1234", user.RoleStaff).Return(nil)
+				userRepoMock.EXPECT().FindByID(context.Background(), "1234").Return(user.User{
+					ID:       "1234",
+					Name:     "Example User",
+					Email:    "example@email.com",
+					Password: "hashed-password",
+					Role:     user.RoleStaff,
+				}, nil)
+			},
+			want: user.User{
+				ID:    "1234",
+				Name:  "Example User",
+				Email: "example@email.com",
+				Role:  user.RoleStaff,
+			},
+		},
+		{
+			name: "can handle unknown user",
+			args: args{
+				ctx:  context.Background(),
+				id:   "1234",
+				role: user.RoleAdmin,
+			},
+			beforeTest: func() {
+				userRepoMock.EXPECT().UpdateRole(context.Background(), "1234", user.RoleAdmin).Return(userrp.ErrNotFound)
+			},
+			want:    user.User{},
+			wantErr: true,
+		},
+		{
+			name: "can handle error when reading the user",
+			args: args{
+				ctx:  context.Background(),
+				id:   "1234",
+				role: user.RoleAdmin,
+			},
+			beforeTest: func() {
+				userRepoMock.EXPECT().UpdateRole(context.Background(), "1234", user.RoleAdmin).Return(nil)
+				userRepoMock.EXPECT().FindByID(context.Background(), "1234").Return(user.User{}, sql.ErrConnDone)
+			},
+			want:    user.User{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RoleAssignmentUseCase{
+				userRepo: userRepoMock,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			got, err := r.AssignRole(tt.args.ctx, tt.args.id, tt.args.role)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AssignRole() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AssignRole() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+)
+
+//go:generate mockgen -source=session.go -destination=session_manager_mock_test.go -package user
+type sessionManager interface {
+	Revoke(ctx context.Context, token string) error
+	Sessions(ctx context.Context, userID string) ([]auth.UserSession, error)
+	RevokeSession(ctx context.Context, userID string, sessionID string) error
+	RevokeAll(ctx context.Context, userID string) error
+}
+
+// SessionUseCase lets the user see and end the logged in sessions.
+type SessionUseCase struct {
+	sessionManager sessionManager
+}
+
+func NewSessionUseCase(sessionManager sessionManager) (*SessionUseCase, error) {
+	return &SessionUseCase{
+		sessionManager: sessionManager,
+	}, nil
+}
+
+// Logout ends the session of the token.
+func (s SessionUseCase) Logout(ctx context.Context, token string) error {
+	return s.sessionManager.Revoke(ctx, token)
+}
+
+// Sessions lists the active sessions of the user, the session of the current request is marked.
+func (s SessionUseCase) Sessions(ctx context.Context, userID string, currentSessionID string) ([]user.Session, error) {
+	sessions, err := s.sessionManager.Sessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]user.Session, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, user.Session{
+			ID:        session.SessionID,
+			UserAgent: session.UserAgent,
+			Current:   currentSessionID != "" && session.SessionID == currentSessionID,
+			CreatedAt: session.CreatedAt,
+			ExpiredAt: session.ExpiredAt,
+		})
+	}
+
+	return result, nil
+}
+
+// Revoke ends the session of the user, the session of the other user is not found.
+func (s SessionUseCase) Revoke(ctx context.Context, userID string, sessionID string) error {
+	err := s.sessionManager.RevokeSession(ctx, userID, sessionID)
+	if errors.Is(err, auth.ErrSessionKeyNotFound) {
+		return user.ErrSessionNotFound
+	}
+
+	return err
+}
+
+// RevokeAll ends every session of the user, including the current one.
+func (s SessionUseCase) RevokeAll(ctx context.Context, userID string) error {
+	return s.sessionManager.RevokeAll(ctx, userID)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: session.go
+
+// Package user is a generated GoMock package.
+package user
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	auth "github.com/rendyananta/example-online-book-store/pkg/auth"
+)
+
+// MocksessionManager is a mock of sessionManager interface.
+type MocksessionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionManagerMockRecorder
+}
+
+// MocksessionManagerMockRecorder is the mock recorder for MocksessionManager.
+type MocksessionManagerMockRecorder struct {
+	mock *MocksessionManager
+}
+
+// NewMocksessionManager creates a new mock instance.
+func NewMocksessionManager(ctrl *gomock.Controller) *MocksessionManager {
+	mock := &MocksessionManager{ctrl: ctrl}
+	mock.recorder = &MocksessionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionManager) EXPECT() *MocksessionManagerMockRecorder {
+	return m.recorder
+}
+
+// Revoke mocks base method.
+func (m *MocksessionManager) Revoke(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MocksessionManagerMockRecorder) Revoke(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MocksessionManager)(nil).Revoke), ctx, token)
+}
+
+// RevokeAll mocks base method.
+func (m *MocksessionManager) RevokeAll(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MocksessionManagerMockRecorder) RevokeAll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MocksessionManager)(nil).RevokeAll), ctx, userID)
+}
+
+// RevokeSession mocks base method.
+func (m *MocksessionManager) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MocksessionManagerMockRecorder) RevokeSession(ctx, userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MocksessionManager)(nil).RevokeSession), ctx, userID, sessionID)
+}
+
+// Sessions mocks base method.
+func (m *MocksessionManager) Sessions(ctx context.Context, userID string) ([]auth.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions", ctx, userID)
+	ret0, _ := ret[0].([]auth.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MocksessionManagerMockRecorder) Sessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MocksessionManager)(nil).Sessions), ctx, userID)
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/user"
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+)
+
+func TestSessionUseCase_Sessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionManagerMock := NewMocksessionManager(ctrl)
+	createdAt := time.Date(2024, 10, 6, 10, 0, 0, 0, time.UTC)
+
+	sessionManagerMock.EXPECT().Sessions(context.Background(), "1").Return([]auth.UserSession{
+		{ID: "1", SessionID: "b", UserAgent: "Safari", CreatedAt: createdAt.Add(time.Hour), ExpiredAt: createdAt.Add(2 * time.Hour)},
+		{ID: "1", SessionID: "a", UserAgent: "Firefox", CreatedAt: createdAt, ExpiredAt: createdAt.Add(time.Hour)},
+	}, nil)
+
+	uc, _ := NewSessionUseCase(sessionManagerMock)
+	got, err := uc.Sessions(context.Background(), "1", "a")
+	if err != nil {
+		t.Fatalf("Sessions() error = %v", err)
+	}
+
+	want := []user.Session{
+		{ID: "b", UserAgent: "Safari", CreatedAt: createdAt.Add(time.Hour), ExpiredAt: createdAt.Add(2 * time.Hour)},
+		{ID: "a", UserAgent: "Firefox", Current: true, CreatedAt: createdAt, ExpiredAt: createdAt.Add(time.Hour)},
+	}
+
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Sessions() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestSessionUseCase_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessionManagerMock := NewMocksessionManager(ctrl)
+	uc, _ := NewSessionUseCase(sessionManagerMock)
+
+	sessionManagerMock.EXPECT().RevokeSession(context.Background(), "1", "a").Return(nil)
+	if err := uc.Revoke(context.Background(), "1", "a"); err != nil {
+		t.Errorf("Revoke() error = %v", err)
+	}
+
+	sessionManagerMock.EXPECT().RevokeSession(context.Background(), "1", "z").Return(auth.ErrSessionKeyNotFound)
+	if err := uc.Revoke(context.Background(), "1", "z"); !errors.Is(err, user.ErrSessionNotFound) {
+		t.Errorf("Revoke() of the unknown session error = %v, wantErr %v", err, user.ErrSessionNotFound)
+	}
+}
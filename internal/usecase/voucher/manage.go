@@ -0,0 +1,53 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+)
+
+//go:generate mockgen -source=manage.go -destination=manage_repo_mock_test.go -package voucher
+type voucherRepo interface {
+	Create(ctx context.Context, param voucher.Voucher) (voucher.Voucher, error)
+	FindByCode(ctx context.Context, code string) (voucher.Voucher, error)
+}
+
+var (
+	ErrNotFound          = voucher.ErrNotFound
+	ErrCodeAlreadyExists = voucher.ErrCodeAlreadyExists
+)
+
+// ManagementUseCase lets the staff manage the vouchers.
+type ManagementUseCase struct {
+	repo voucherRepo
+}
+
+func NewManagementUseCase(repo voucherRepo) (*ManagementUseCase, error) {
+	return &ManagementUseCase{repo: repo}, nil
+}
+
+// Create stores the voucher rule, the code is case-insensitive and stored in upper case.
+func (uc ManagementUseCase) Create(ctx context.Context, param voucher.Voucher) (voucher.Voucher, error) {
+	param.Code = strings.ToUpper(strings.TrimSpace(param.Code))
+
+	if err := param.Validate(); err != nil {
+		return voucher.Voucher{}, err
+	}
+
+	_, err := uc.repo.FindByCode(ctx, param.Code)
+	if err == nil {
+		return voucher.Voucher{}, ErrCodeAlreadyExists
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		return voucher.Voucher{}, err
+	}
+
+	return uc.repo.Create(ctx, param)
+}
+
+func (uc ManagementUseCase) GetByCode(ctx context.Context, code string) (voucher.Voucher, error) {
+	return uc.repo.FindByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: manage.go
+
+// Package voucher is a generated GoMock package.
+package voucher
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	voucher "github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+)
+
+// MockvoucherRepo is a mock of voucherRepo interface.
+type MockvoucherRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockvoucherRepoMockRecorder
+}
+
+// MockvoucherRepoMockRecorder is the mock recorder for MockvoucherRepo.
+type MockvoucherRepoMockRecorder struct {
+	mock *MockvoucherRepo
+}
+
+// NewMockvoucherRepo creates a new mock instance.
+func NewMockvoucherRepo(ctrl *gomock.Controller) *MockvoucherRepo {
+	mock := &MockvoucherRepo{ctrl: ctrl}
+	mock.recorder = &MockvoucherRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockvoucherRepo) EXPECT() *MockvoucherRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockvoucherRepo) Create(ctx context.Context, param voucher.Voucher) (voucher.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, param)
+	ret0, _ := ret[0].(voucher.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockvoucherRepoMockRecorder) Create(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockvoucherRepo)(nil).Create), ctx, param)
+}
+
+// FindByCode mocks base method.
+func (m *MockvoucherRepo) FindByCode(ctx context.Context, code string) (voucher.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, code)
+	ret0, _ := ret[0].(voucher.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockvoucherRepoMockRecorder) FindByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockvoucherRepo)(nil).FindByCode), ctx, code)
+}
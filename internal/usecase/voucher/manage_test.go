@@ -0,0 +1,59 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/voucher"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestManagementUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockvoucherRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		param      voucher.Voucher
+		beforeTest func()
+		wantErr    error
+	}{
+		{
+			name:  "can create voucher using the upper case code",
+			param: voucher.Voucher{Code: " welcome10 ", DiscountType: voucher.DiscountTypePercentage, Percentage: 10},
+			beforeTest: func() {
+				repoMock.EXPECT().FindByCode(context.Background(), "WELCOME10").Return(voucher.Voucher{}, ErrNotFound)
+				repoMock.EXPECT().Create(context.Background(), voucher.Voucher{Code: "WELCOME10", DiscountType: voucher.DiscountTypePercentage, Percentage: 10}).
+					Return(voucher.Voucher{ID: "1", Code: "WELCOME10"}, nil)
+			},
+		},
+		{
+			name:  "can reject taken code",
+			param: voucher.Voucher{Code: "WELCOME10", DiscountType: voucher.DiscountTypeFixed, Amount: money.New(100, money.USD)},
+			beforeTest: func() {
+				repoMock.EXPECT().FindByCode(context.Background(), "WELCOME10").Return(voucher.Voucher{ID: "1"}, nil)
+			},
+			wantErr: ErrCodeAlreadyExists,
+		},
+		{
+			name:       "can reject invalid rule",
+			param:      voucher.Voucher{Code: "HALF", DiscountType: voucher.DiscountTypePercentage, Percentage: 150},
+			beforeTest: func() {},
+			wantErr:    voucher.ErrInvalidRule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewManagementUseCase(repoMock)
+			if _, err := uc.Create(context.Background(), tt.param); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+)
+
+const defaultDeliveriesLimit = 50
+
+// ManagementUseCase manages the webhook subscriptions and their delivery log.
+type ManagementUseCase struct {
+	subscriptions subscriptionRepo
+	deliveries    deliveryRepo
+}
+
+func NewManagementUseCase(subscriptions subscriptionRepo, deliveries deliveryRepo) (*ManagementUseCase, error) {
+	return &ManagementUseCase{subscriptions: subscriptions, deliveries: deliveries}, nil
+}
+
+func (uc ManagementUseCase) List(ctx context.Context) ([]webhook.Subscription, error) {
+	return uc.subscriptions.FindAll(ctx)
+}
+
+func (uc ManagementUseCase) Get(ctx context.Context, id string) (webhook.Subscription, error) {
+	return uc.subscriptions.FindByID(ctx, id)
+}
+
+func (uc ManagementUseCase) Create(ctx context.Context, param webhook.WriteParam) (webhook.Subscription, error) {
+	eventTypes, err := normalizeEventTypes(param.EventTypes)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	param.EventTypes = eventTypes
+
+	return uc.subscriptions.Create(ctx, param)
+}
+
+// Update replaces the subscription, the secret is kept when it is empty. The queued deliveries are sent
+// with the updated URL and secret.
+func (uc ManagementUseCase) Update(ctx context.Context, id string, param webhook.WriteParam) (webhook.Subscription, error) {
+	eventTypes, err := normalizeEventTypes(param.EventTypes)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	param.EventTypes = eventTypes
+
+	return uc.subscriptions.Update(ctx, id, param)
+}
+
+func (uc ManagementUseCase) Delete(ctx context.Context, id string) error {
+	return uc.subscriptions.Delete(ctx, id)
+}
+
+// Deliveries lists the latest deliveries of the subscription, optionally of the status only.
+func (uc ManagementUseCase) Deliveries(ctx context.Context, subscriptionID string, status webhook.DeliveryStatus, limit int) ([]webhook.Delivery, error) {
+	if _, err := uc.subscriptions.FindByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > defaultDeliveriesLimit {
+		limit = defaultDeliveriesLimit
+	}
+
+	return uc.deliveries.FindDeliveries(ctx, subscriptionID, status, limit)
+}
+
+// Replay queues the delivery again, it is sent with the same payload and the fresh attempts.
+func (uc ManagementUseCase) Replay(ctx context.Context, subscriptionID, deliveryID string) (webhook.Delivery, error) {
+	if _, err := uc.subscriptions.FindByID(ctx, subscriptionID); err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return uc.deliveries.ReplayDelivery(ctx, subscriptionID, deliveryID)
+}
+
+// normalizeEventTypes sorts and deduplicates the event types, the event type the webhooks cannot send is rejected.
+func normalizeEventTypes(eventTypes []event.Type) ([]event.Type, error) {
+	result := make([]event.Type, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !slices.Contains(webhook.EventTypes, eventType) {
+			return nil, ErrUnsupportedEventType
+		}
+
+		result = append(result, eventType)
+	}
+
+	if len(result) == 0 {
+		return nil, ErrUnsupportedEventType
+	}
+
+	slices.Sort(result)
+
+	return slices.Compact(result), nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+)
+
+func TestManagementUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionRepoMock := NewMocksubscriptionRepo(ctrl)
+	deliveryRepoMock := NewMockdeliveryRepo(ctrl)
+
+	tests := []struct {
+		name       string
+		eventTypes []event.Type
+		beforeTest func()
+		wantErr    error
+	}{
+		{
+			name:       "event types are sorted and deduplicated",
+			eventTypes: []event.Type{event.TypeOrderStatusChanged, event.TypeOrderPlaced, event.TypeOrderStatusChanged},
+			beforeTest: func() {
+				subscriptionRepoMock.EXPECT().Create(gomock.Any(), webhook.WriteParam{
+					URL:        "https://partner.example.com/hooks",
+					Secret:     "partner-secret",
+					EventTypes: []event.Type{event.TypeOrderPlaced, event.TypeOrderStatusChanged},
+					Active:     true,
+				}).Return(webhook.Subscription{ID: "sub-1"}, nil)
+			},
+		},
+		{
+			name:       "can reject event type the webhooks cannot send",
+			eventTypes: []event.Type{event.TypeOrderPlaced, event.TypeUserRegistered},
+			beforeTest: func() {},
+			wantErr:    ErrUnsupportedEventType,
+		},
+		{
+			name:       "can reject empty event types",
+			beforeTest: func() {},
+			wantErr:    ErrUnsupportedEventType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.beforeTest()
+
+			uc, _ := NewManagementUseCase(subscriptionRepoMock, deliveryRepoMock)
+			_, err := uc.Create(context.Background(), webhook.WriteParam{
+				URL:        "https://partner.example.com/hooks",
+				Secret:     "partner-secret",
+				EventTypes: tt.eventTypes,
+				Active:     true,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManagementUseCase_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionRepoMock := NewMocksubscriptionRepo(ctrl)
+	deliveryRepoMock := NewMockdeliveryRepo(ctrl)
+	uc, _ := NewManagementUseCase(subscriptionRepoMock, deliveryRepoMock)
+
+	subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-1").Return(webhook.Subscription{ID: "sub-1"}, nil)
+	deliveryRepoMock.EXPECT().ReplayDelivery(gomock.Any(), "sub-1", "d-1").
+		Return(webhook.Delivery{ID: "d-1", Status: webhook.DeliveryStatusPending}, nil)
+
+	got, err := uc.Replay(context.Background(), "sub-1", "d-1")
+	if err != nil || got.Status != webhook.DeliveryStatusPending {
+		t.Errorf("Replay() got = %+v, %v", got, err)
+	}
+
+	subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-2").Return(webhook.Subscription{}, ErrNotFound)
+	if _, err = uc.Replay(context.Background(), "sub-2", "d-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Replay() of the unknown subscription error = %v, wantErr %v", err, ErrNotFound)
+	}
+}
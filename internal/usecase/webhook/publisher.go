@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+)
+
+// Publisher queues the dispatched event for each subscription accepting it, the queued deliveries are
+// sent by the Sender. It is registered to the event dispatcher as the handler of the webhook event types.
+type Publisher struct {
+	subscriptions subscriptionRepo
+	deliveries    deliveryRepo
+}
+
+func NewPublisher(subscriptions subscriptionRepo, deliveries deliveryRepo) (*Publisher, error) {
+	return &Publisher{subscriptions: subscriptions, deliveries: deliveries}, nil
+}
+
+func (p *Publisher) Handle(ctx context.Context, evt event.Event) error {
+	subscriptions, err := p.subscriptions.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	deliveries := make([]webhook.Delivery, 0, len(subscriptions))
+
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(evt.Type) {
+			continue
+		}
+
+		// the payload is kept with the delivery, thus the replay sends the same body.
+		if payload == nil {
+			payload, err = json.Marshal(webhook.Payload{
+				ID:        evt.ID,
+				Type:      evt.Type,
+				CreatedAt: evt.CreatedAt,
+				Data:      evt.Payload,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		deliveries = append(deliveries, webhook.Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        evt.ID,
+			EventType:      evt.Type,
+			Payload:        payload,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return p.deliveries.CreateDeliveries(ctx, deliveries)
+}
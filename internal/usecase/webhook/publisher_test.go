@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+)
+
+func TestPublisher_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionRepoMock := NewMocksubscriptionRepo(ctrl)
+	deliveryRepoMock := NewMockdeliveryRepo(ctrl)
+
+	createdAt := time.Date(2024, 10, 6, 10, 0, 0, 0, time.UTC)
+	evt := event.Event{
+		ID:          "event-1",
+		Type:        event.TypeOrderStatusChanged,
+		AggregateID: "order-1",
+		Payload:     json.RawMessage(`{"order_id":"order-1","status":"cancelled"}`),
+		CreatedAt:   &createdAt,
+	}
+
+	subscriptions := []webhook.Subscription{
+		{ID: "sub-1", Active: true, EventTypes: []event.Type{event.TypeOrderPlaced, event.TypeOrderStatusChanged}},
+		{ID: "sub-2", Active: true, EventTypes: []event.Type{event.TypeOrderPlaced}},
+		{ID: "sub-3", Active: true, EventTypes: []event.Type{event.TypeOrderStatusChanged}},
+	}
+
+	wantPayload := `{"id":"event-1","type":"order.status_changed","created_at":"2024-10-06T10:00:00Z","data":{"order_id":"order-1","status":"cancelled"}}`
+
+	subscriptionRepoMock.EXPECT().FindActive(gomock.Any()).Return(subscriptions, nil)
+	deliveryRepoMock.EXPECT().CreateDeliveries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, deliveries []webhook.Delivery) error {
+			if len(deliveries) != 2 || deliveries[0].SubscriptionID != "sub-1" || deliveries[1].SubscriptionID != "sub-3" {
+				t.Fatalf("CreateDeliveries() got = %+v, want the deliveries of sub-1 and sub-3", deliveries)
+			}
+
+			for _, delivery := range deliveries {
+				if delivery.EventID != "event-1" || delivery.EventType != event.TypeOrderStatusChanged || string(delivery.Payload) != wantPayload {
+					t.Errorf("CreateDeliveries() delivery = %+v, payload %s", delivery, delivery.Payload)
+				}
+			}
+
+			return nil
+		})
+
+	p, _ := NewPublisher(subscriptionRepoMock, deliveryRepoMock)
+	if err := p.Handle(context.Background(), evt); err != nil {
+		t.Errorf("Handle() error = %v", err)
+	}
+
+	// the event without any subscription accepting it queues nothing.
+	subscriptionRepoMock.EXPECT().FindActive(gomock.Any()).Return(subscriptions[1:2], nil)
+	if err := p.Handle(context.Background(), evt); err != nil {
+		t.Errorf("Handle() error = %v", err)
+	}
+}
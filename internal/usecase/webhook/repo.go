@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+	webhookpkg "github.com/rendyananta/example-online-book-store/pkg/webhook"
+)
+
+//go:generate mockgen -source=repo.go -destination=repo_mock_test.go -package webhook
+type subscriptionRepo interface {
+	Create(ctx context.Context, param webhook.WriteParam) (webhook.Subscription, error)
+	Update(ctx context.Context, id string, param webhook.WriteParam) (webhook.Subscription, error)
+	Delete(ctx context.Context, id string) error
+	FindByID(ctx context.Context, id string) (webhook.Subscription, error)
+	FindAll(ctx context.Context) ([]webhook.Subscription, error)
+	FindActive(ctx context.Context) ([]webhook.Subscription, error)
+}
+
+type deliveryRepo interface {
+	CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]webhook.Delivery, error)
+	ClaimDelivery(ctx context.Context, delivery webhook.Delivery, until time.Time) (bool, error)
+	MarkDelivered(ctx context.Context, id string, attempt webhook.AttemptResult) error
+	RetryDelivery(ctx context.Context, id string, at time.Time, attempt webhook.AttemptResult) error
+	FailDelivery(ctx context.Context, id string, attempt webhook.AttemptResult) error
+	ReplayDelivery(ctx context.Context, subscriptionID, id string) (webhook.Delivery, error)
+	FindDeliveries(ctx context.Context, subscriptionID string, status webhook.DeliveryStatus, limit int) ([]webhook.Delivery, error)
+}
+
+type webhookClient interface {
+	Send(ctx context.Context, request webhookpkg.Request) (webhookpkg.Response, error)
+}
+
+var (
+	ErrNotFound             = webhook.ErrNotFound
+	ErrDeliveryNotFound     = webhook.ErrDeliveryNotFound
+	ErrUnsupportedEventType = webhook.ErrUnsupportedEventType
+)
@@ -0,0 +1,304 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repo.go
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	webhook "github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+	webhook0 "github.com/rendyananta/example-online-book-store/pkg/webhook"
+)
+
+// MocksubscriptionRepo is a mock of subscriptionRepo interface.
+type MocksubscriptionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MocksubscriptionRepoMockRecorder
+}
+
+// MocksubscriptionRepoMockRecorder is the mock recorder for MocksubscriptionRepo.
+type MocksubscriptionRepoMockRecorder struct {
+	mock *MocksubscriptionRepo
+}
+
+// NewMocksubscriptionRepo creates a new mock instance.
+func NewMocksubscriptionRepo(ctrl *gomock.Controller) *MocksubscriptionRepo {
+	mock := &MocksubscriptionRepo{ctrl: ctrl}
+	mock.recorder = &MocksubscriptionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksubscriptionRepo) EXPECT() *MocksubscriptionRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MocksubscriptionRepo) Create(ctx context.Context, param webhook.WriteParam) (webhook.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, param)
+	ret0, _ := ret[0].(webhook.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocksubscriptionRepoMockRecorder) Create(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocksubscriptionRepo)(nil).Create), ctx, param)
+}
+
+// Delete mocks base method.
+func (m *MocksubscriptionRepo) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MocksubscriptionRepoMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MocksubscriptionRepo)(nil).Delete), ctx, id)
+}
+
+// FindActive mocks base method.
+func (m *MocksubscriptionRepo) FindActive(ctx context.Context) ([]webhook.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", ctx)
+	ret0, _ := ret[0].([]webhook.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MocksubscriptionRepoMockRecorder) FindActive(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MocksubscriptionRepo)(nil).FindActive), ctx)
+}
+
+// FindAll mocks base method.
+func (m *MocksubscriptionRepo) FindAll(ctx context.Context) ([]webhook.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]webhook.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MocksubscriptionRepoMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MocksubscriptionRepo)(nil).FindAll), ctx)
+}
+
+// FindByID mocks base method.
+func (m *MocksubscriptionRepo) FindByID(ctx context.Context, id string) (webhook.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(webhook.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MocksubscriptionRepoMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MocksubscriptionRepo)(nil).FindByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MocksubscriptionRepo) Update(ctx context.Context, id string, param webhook.WriteParam) (webhook.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, param)
+	ret0, _ := ret[0].(webhook.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MocksubscriptionRepoMockRecorder) Update(ctx, id, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MocksubscriptionRepo)(nil).Update), ctx, id, param)
+}
+
+// MockdeliveryRepo is a mock of deliveryRepo interface.
+type MockdeliveryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryRepoMockRecorder
+}
+
+// MockdeliveryRepoMockRecorder is the mock recorder for MockdeliveryRepo.
+type MockdeliveryRepoMockRecorder struct {
+	mock *MockdeliveryRepo
+}
+
+// NewMockdeliveryRepo creates a new mock instance.
+func NewMockdeliveryRepo(ctrl *gomock.Controller) *MockdeliveryRepo {
+	mock := &MockdeliveryRepo{ctrl: ctrl}
+	mock.recorder = &MockdeliveryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryRepo) EXPECT() *MockdeliveryRepoMockRecorder {
+	return m.recorder
+}
+
+// ClaimDelivery mocks base method.
+func (m *MockdeliveryRepo) ClaimDelivery(ctx context.Context, delivery webhook.Delivery, until time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDelivery", ctx, delivery, until)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDelivery indicates an expected call of ClaimDelivery.
+func (mr *MockdeliveryRepoMockRecorder) ClaimDelivery(ctx, delivery, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDelivery", reflect.TypeOf((*MockdeliveryRepo)(nil).ClaimDelivery), ctx, delivery, until)
+}
+
+// CreateDeliveries mocks base method.
+func (m *MockdeliveryRepo) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveries indicates an expected call of CreateDeliveries.
+func (mr *MockdeliveryRepoMockRecorder) CreateDeliveries(ctx, deliveries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveries", reflect.TypeOf((*MockdeliveryRepo)(nil).CreateDeliveries), ctx, deliveries)
+}
+
+// FailDelivery mocks base method.
+func (m *MockdeliveryRepo) FailDelivery(ctx context.Context, id string, attempt webhook.AttemptResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailDelivery", ctx, id, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailDelivery indicates an expected call of FailDelivery.
+func (mr *MockdeliveryRepoMockRecorder) FailDelivery(ctx, id, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailDelivery", reflect.TypeOf((*MockdeliveryRepo)(nil).FailDelivery), ctx, id, attempt)
+}
+
+// FindDeliveries mocks base method.
+func (m *MockdeliveryRepo) FindDeliveries(ctx context.Context, subscriptionID string, status webhook.DeliveryStatus, limit int) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveries", ctx, subscriptionID, status, limit)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveries indicates an expected call of FindDeliveries.
+func (mr *MockdeliveryRepoMockRecorder) FindDeliveries(ctx, subscriptionID, status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveries", reflect.TypeOf((*MockdeliveryRepo)(nil).FindDeliveries), ctx, subscriptionID, status, limit)
+}
+
+// FindDueDeliveries mocks base method.
+func (m *MockdeliveryRepo) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDueDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDueDeliveries indicates an expected call of FindDueDeliveries.
+func (mr *MockdeliveryRepoMockRecorder) FindDueDeliveries(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueDeliveries", reflect.TypeOf((*MockdeliveryRepo)(nil).FindDueDeliveries), ctx, now, limit)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockdeliveryRepo) MarkDelivered(ctx context.Context, id string, attempt webhook.AttemptResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockdeliveryRepoMockRecorder) MarkDelivered(ctx, id, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockdeliveryRepo)(nil).MarkDelivered), ctx, id, attempt)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockdeliveryRepo) ReplayDelivery(ctx context.Context, subscriptionID, id string) (webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", ctx, subscriptionID, id)
+	ret0, _ := ret[0].(webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockdeliveryRepoMockRecorder) ReplayDelivery(ctx, subscriptionID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockdeliveryRepo)(nil).ReplayDelivery), ctx, subscriptionID, id)
+}
+
+// RetryDelivery mocks base method.
+func (m *MockdeliveryRepo) RetryDelivery(ctx context.Context, id string, at time.Time, attempt webhook.AttemptResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDelivery", ctx, id, at, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryDelivery indicates an expected call of RetryDelivery.
+func (mr *MockdeliveryRepoMockRecorder) RetryDelivery(ctx, id, at, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDelivery", reflect.TypeOf((*MockdeliveryRepo)(nil).RetryDelivery), ctx, id, at, attempt)
+}
+
+// MockwebhookClient is a mock of webhookClient interface.
+type MockwebhookClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookClientMockRecorder
+}
+
+// MockwebhookClientMockRecorder is the mock recorder for MockwebhookClient.
+type MockwebhookClientMockRecorder struct {
+	mock *MockwebhookClient
+}
+
+// NewMockwebhookClient creates a new mock instance.
+func NewMockwebhookClient(ctrl *gomock.Controller) *MockwebhookClient {
+	mock := &MockwebhookClient{ctrl: ctrl}
+	mock.recorder = &MockwebhookClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookClient) EXPECT() *MockwebhookClientMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockwebhookClient) Send(ctx context.Context, request webhook0.Request) (webhook0.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, request)
+	ret0, _ := ret[0].(webhook0.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockwebhookClientMockRecorder) Send(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockwebhookClient)(nil).Send), ctx, request)
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+	webhookpkg "github.com/rendyananta/example-online-book-store/pkg/webhook"
+	"github.com/rendyananta/example-online-book-store/pkg/worker"
+)
+
+var defaultConfig = worker.Config{
+	PollInterval:    time.Second,
+	BatchSize:       20,
+	MaxAttempts:     10,
+	RetryBackoff:    10 * time.Second,
+	MaxRetryBackoff: 6 * time.Hour,
+	// the claim is longer than the timeout of the webhook client.
+	ClaimTimeout: time.Minute,
+}
+
+// Config of the sender, the webhook delivery is the job of the worker.
+type Config = worker.Config
+
+// Sender posts the queued deliveries to the subscriptions, the failed delivery is retried with the exponential
+// backoff until it is given up.
+type Sender struct {
+	config        Config
+	subscriptions subscriptionRepo
+	deliveries    deliveryRepo
+	client        webhookClient
+	now           func() time.Time
+}
+
+func NewSender(config Config, subscriptions subscriptionRepo, deliveries deliveryRepo, client webhookClient) (*Sender, error) {
+	return &Sender{
+		config:        config.WithDefaults(defaultConfig),
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        client,
+		now:           time.Now,
+	}, nil
+}
+
+// Run polls the due deliveries until the context is done.
+func (s *Sender) Run(ctx context.Context) {
+	worker.Poll(ctx, s.config, "webhook sender", s.SendDue)
+}
+
+// SendDue sends one batch of the due deliveries, it returns the number of the deliveries taken.
+func (s *Sender) SendDue(ctx context.Context) (int, error) {
+	deliveries, err := s.deliveries.FindDueDeliveries(ctx, s.now(), s.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := make(map[string]webhook.Subscription)
+
+	err = worker.ProcessClaimed(ctx, deliveries, func(ctx context.Context, delivery webhook.Delivery) (bool, error) {
+		if _, ok := subscriptions[delivery.SubscriptionID]; !ok {
+			subscription, err := s.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+			if errors.Is(err, ErrNotFound) {
+				// the subscription is deleted meanwhile, its deliveries are no longer due.
+				return false, nil
+			}
+
+			if err != nil {
+				return false, err
+			}
+
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		return s.deliveries.ClaimDelivery(ctx, delivery, s.now().Add(s.config.ClaimTimeout))
+	}, func(ctx context.Context, delivery webhook.Delivery) error {
+		delivery.Attempts++
+		return s.send(ctx, subscriptions[delivery.SubscriptionID], delivery)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(deliveries), nil
+}
+
+func (s *Sender) send(ctx context.Context, subscription webhook.Subscription, delivery webhook.Delivery) error {
+	response, sendErr := s.client.Send(ctx, webhookpkg.Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID,
+		Payload:    delivery.Payload,
+	})
+
+	attempt := webhook.AttemptResult{
+		ResponseStatus: response.StatusCode,
+		ResponseBody:   response.Body,
+	}
+
+	if sendErr == nil {
+		return s.deliveries.MarkDelivered(ctx, delivery.ID, attempt)
+	}
+
+	// the attempt interrupted by the shutdown is sent again once the claim expires.
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	attempt.Error = sendErr.Error()
+
+	retryAt, retry := s.config.NextAttempt(s.now(), delivery.Attempts)
+	if !retry {
+		slog.Error("giving up the webhook delivery", slog.String("error", attempt.Error),
+			slog.String("delivery_id", delivery.ID), slog.String("subscription_id", delivery.SubscriptionID),
+			slog.Int("response_status", attempt.ResponseStatus), slog.Int("attempts", delivery.Attempts))
+		return s.deliveries.FailDelivery(ctx, delivery.ID, attempt)
+	}
+
+	slog.Warn("retrying the webhook delivery", slog.String("error", attempt.Error),
+		slog.String("delivery_id", delivery.ID), slog.String("subscription_id", delivery.SubscriptionID),
+		slog.Int("response_status", attempt.ResponseStatus), slog.Int("attempts", delivery.Attempts))
+
+	return s.deliveries.RetryDelivery(ctx, delivery.ID, retryAt, attempt)
+}
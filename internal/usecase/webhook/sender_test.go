@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rendyananta/example-online-book-store/internal/entity/event"
+	"github.com/rendyananta/example-online-book-store/internal/entity/webhook"
+	webhookpkg "github.com/rendyananta/example-online-book-store/pkg/webhook"
+)
+
+func TestSender_SendDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscriptionRepoMock := NewMocksubscriptionRepo(ctrl)
+	deliveryRepoMock := NewMockdeliveryRepo(ctrl)
+	now := time.Date(2024, 10, 6, 10, 0, 0, 0, time.UTC)
+
+	var received []*http.Request
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := webhookpkg.Verify("partner-secret", r.Header.Get(webhookpkg.HeaderSignature), body, time.Minute, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		received = append(received, r)
+
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("try later"))
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer receiver.Close()
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	subscription := webhook.Subscription{ID: "sub-1", URL: receiver.URL + "/hooks", Secret: "partner-secret", Active: true}
+	failing := webhook.Subscription{ID: "sub-2", URL: receiver.URL + "/failing", Secret: "partner-secret", Active: true}
+	gone := webhook.Subscription{ID: "sub-3", URL: unreachable.URL, Secret: "partner-secret", Active: true}
+
+	delivery := func(id, subscriptionID string, attempts int) webhook.Delivery {
+		return webhook.Delivery{
+			ID:             id,
+			SubscriptionID: subscriptionID,
+			EventID:        "event-1",
+			EventType:      event.TypeOrderPlaced,
+			Payload:        []byte(`{"id":"event-1","type":"order.placed"}`),
+			Status:         webhook.DeliveryStatusPending,
+			Attempts:       attempts,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		maxAttempts  int
+		beforeTest   func()
+		wantReceived int
+	}{
+		{
+			name: "delivered with the signed payload",
+			beforeTest: func() {
+				first, second := delivery("d-1", "sub-1", 0), delivery("d-2", "sub-1", 0)
+				deliveryRepoMock.EXPECT().FindDueDeliveries(gomock.Any(), now, 10).Return([]webhook.Delivery{first, second}, nil)
+				// the subscription is loaded once per batch.
+				subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-1").Return(subscription, nil)
+				deliveryRepoMock.EXPECT().ClaimDelivery(gomock.Any(), first, now.Add(time.Minute)).Return(true, nil)
+				deliveryRepoMock.EXPECT().MarkDelivered(gomock.Any(), "d-1", webhook.AttemptResult{ResponseStatus: 200, ResponseBody: "ok"}).Return(nil)
+				deliveryRepoMock.EXPECT().ClaimDelivery(gomock.Any(), second, now.Add(time.Minute)).Return(true, nil)
+				deliveryRepoMock.EXPECT().MarkDelivered(gomock.Any(), "d-2", webhook.AttemptResult{ResponseStatus: 200, ResponseBody: "ok"}).Return(nil)
+			},
+			wantReceived: 2,
+		},
+		{
+			name: "delivery claimed by another sender is skipped",
+			beforeTest: func() {
+				first := delivery("d-1", "sub-1", 0)
+				deliveryRepoMock.EXPECT().FindDueDeliveries(gomock.Any(), now, 10).Return([]webhook.Delivery{first}, nil)
+				subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-1").Return(subscription, nil)
+				deliveryRepoMock.EXPECT().ClaimDelivery(gomock.Any(), first, now.Add(time.Minute)).Return(false, nil)
+			},
+		},
+		{
+			name: "delivery of the deleted subscription is skipped",
+			beforeTest: func() {
+				first := delivery("d-1", "sub-1", 0)
+				deliveryRepoMock.EXPECT().FindDueDeliveries(gomock.Any(), now, 10).Return([]webhook.Delivery{first}, nil)
+				subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-1").Return(webhook.Subscription{}, ErrNotFound)
+			},
+		},
+		{
+			name: "failed response is retried with backoff",
+			beforeTest: func() {
+				first := delivery("d-1", "sub-2", 2)
+				deliveryRepoMock.EXPECT().FindDueDeliveries(gomock.Any(), now, 10).Return([]webhook.Delivery{first}, nil)
+				subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-2").Return(failing, nil)
+				deliveryRepoMock.EXPECT().ClaimDelivery(gomock.Any(), first, now.Add(time.Minute)).Return(true, nil)
+				// the third attempt waits four times the first backoff.
+				deliveryRepoMock.EXPECT().RetryDelivery(gomock.Any(), "d-1", now.Add(40*time.Second), webhook.AttemptResult{
+					ResponseStatus: 500,
+					ResponseBody:   "try later",
+					Error:          webhookpkg.ErrUnexpectedStatus.Error(),
+				}).Return(nil)
+			},
+			wantReceived: 1,
+		},
+		{
+			name: "unreachable receiver is retried without response",
+			beforeTest: func() {
+				first := delivery("d-1", "sub-3", 0)
+				deliveryRepoMock.EXPECT().FindDueDeliveries(gomock.Any(), now, 10).Return([]webhook.Delivery{first}, nil)
+				subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-3").Return(gone, nil)
+				deliveryRepoMock.EXPECT().ClaimDelivery(gomock.Any(), first, now.Add(time.Minute)).Return(true, nil)
+				deliveryRepoMock.EXPECT().RetryDelivery(gomock.Any(), "d-1", now.Add(10*time.Second), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ time.Time, attempt webhook.AttemptResult) error {
+						if attempt.ResponseStatus != 0 || attempt.Error == "" {
+							t.Errorf("RetryDelivery() attempt = %+v, want the error without response", attempt)
+						}
+						return nil
+					})
+			},
+		},
+		{
+			name:        "delivery is given up after the last attempt",
+			maxAttempts: 3,
+			beforeTest: func() {
+				first := delivery("d-1", "sub-2", 2)
+				deliveryRepoMock.EXPECT().FindDueDeliveries(gomock.Any(), now, 10).Return([]webhook.Delivery{first}, nil)
+				subscriptionRepoMock.EXPECT().FindByID(gomock.Any(), "sub-2").Return(failing, nil)
+				deliveryRepoMock.EXPECT().ClaimDelivery(gomock.Any(), first, now.Add(time.Minute)).Return(true, nil)
+				deliveryRepoMock.EXPECT().FailDelivery(gomock.Any(), "d-1", webhook.AttemptResult{
+					ResponseStatus: 500,
+					ResponseBody:   "try later",
+					Error:          webhookpkg.ErrUnexpectedStatus.Error(),
+				}).Return(nil)
+			},
+			wantReceived: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			tt.beforeTest()
+
+			s, _ := NewSender(Config{BatchSize: 10, MaxAttempts: tt.maxAttempts}, subscriptionRepoMock, deliveryRepoMock, webhookpkg.NewClient(webhookpkg.ClientConfig{}))
+			s.now = func() time.Time { return now }
+
+			if _, err := s.SendDue(context.Background()); err != nil {
+				t.Errorf("SendDue() error = %v", err)
+			}
+
+			if len(received) != tt.wantReceived {
+				t.Fatalf("receiver got %d requests, want %d", len(received), tt.wantReceived)
+			}
+
+			for _, r := range received {
+				if r.Header.Get(webhookpkg.HeaderEvent) != event.TypeOrderPlaced || r.Header.Get(webhookpkg.HeaderEventID) != "event-1" ||
+					r.Header.Get(webhookpkg.HeaderDelivery) == "" {
+					t.Errorf("receiver got headers = %v", r.Header)
+				}
+			}
+		})
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
-	mathrand "math/rand"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -22,104 +25,249 @@ type cacheDriver interface {
 }
 
 type Config struct {
+	// Strategy is either StrategySession, the default, or StrategySigned.
+	Strategy      string
 	TokenLifetime time.Duration
-	CipherKeys    []string
+	// RefreshTokenLifetime is how long the session can be refreshed, counted from the login.
+	RefreshTokenLifetime time.Duration
+	// CipherKeys are ordered from the oldest to the newest, the newest key encrypts the tokens while the older
+	// keys keep decrypting the tokens issued before the rotation.
+	CipherKeys []string
+	// SigningAlgorithm is the algorithm of the signed token strategy, either AlgorithmHS256 or AlgorithmEdDSA.
+	SigningAlgorithm string
+	// SigningKeys are ordered from the oldest to the newest like the cipher keys. The HS256 key is the secret of
+	// at least 32 bytes, while the EdDSA key is the base64 encoded Ed25519 seed.
+	SigningKeys []string
+	// Denylist lets the signed tokens be revoked before they are expired, at the cost of reading the cache
+	// on every request.
+	Denylist bool
 }
 
 type UserSession struct {
 	ID        string    `json:"id"`
 	Type      string    `json:"type"`
 	ExpiredAt time.Time `json:"expired_at"`
+	// SessionID identifies the session among the sessions of the user, it is empty for the session
+	// issued before the sessions were indexed.
+	SessionID string    `json:"session_id,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// cipherKey is the cipher of the configured key, the token carries the key ID to tell which key decrypts it.
+type cipherKey struct {
+	id    string
+	block cipher.Block
+}
+
+func newCipherKey(key string) (cipherKey, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return cipherKey{}, err
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	return cipherKey{id: hex.EncodeToString(sum[:4]), block: block}, nil
 }
 
 type Manager struct {
 	config      Config
 	cacheDriver cacheDriver
-	ciphers     []cipher.Block
+	// ciphers is ordered from the newest key.
+	ciphers []cipherKey
+	// signingKeys is ordered from the newest key.
+	signingKeys []signingKey
+	// indexMu serializes the updates of the session index and the refresh token families, they are read,
+	// changed and written back.
+	indexMu sync.Mutex
 }
 
 func NewAuthManager(conf Config, cacheDriver cacheDriver) (*Manager, error) {
-	if len(conf.CipherKeys) == 0 {
-		return nil, ErrCipherKeysIsEmpty
-	}
+	var ciphers []cipherKey
+	var signingKeys []signingKey
+
+	switch conf.Strategy {
+	case "", StrategySession:
+		if len(conf.CipherKeys) == 0 {
+			return nil, ErrCipherKeysIsEmpty
+		}
 
-	ciphers := make([]cipher.Block, 0, len(conf.CipherKeys))
+		ciphers = make([]cipherKey, 0, len(conf.CipherKeys))
 
-	// loop in reverse
-	for i := len(conf.CipherKeys) - 1; i >= 0; i-- {
-		c, err := aes.NewCipher([]byte(conf.CipherKeys[i]))
-		if err != nil {
-			return nil, err
+		// loop in reverse, the newest key comes first.
+		for i := len(conf.CipherKeys) - 1; i >= 0; i-- {
+			c, err := newCipherKey(conf.CipherKeys[i])
+			if err != nil {
+				return nil, err
+			}
+
+			ciphers = append(ciphers, c)
+		}
+	case StrategySigned:
+		if len(conf.SigningKeys) == 0 {
+			return nil, ErrSigningKeysIsEmpty
 		}
 
-		ciphers = append(ciphers, c)
+		if conf.SigningAlgorithm == "" {
+			conf.SigningAlgorithm = AlgorithmHS256
+		}
+
+		signingKeys = make([]signingKey, 0, len(conf.SigningKeys))
+
+		for i := len(conf.SigningKeys) - 1; i >= 0; i-- {
+			k, err := newSigningKey(conf.SigningAlgorithm, conf.SigningKeys[i])
+			if err != nil {
+				return nil, err
+			}
+
+			signingKeys = append(signingKeys, k)
+		}
+	default:
+		return nil, ErrUnsupportedStrategy
 	}
 
 	if conf.TokenLifetime == 0 {
 		conf.TokenLifetime = defaultTTL
 	}
 
+	if conf.RefreshTokenLifetime == 0 {
+		conf.RefreshTokenLifetime = defaultRefreshTTL
+	}
+
 	return &Manager{
 		config:      conf,
 		cacheDriver: cacheDriver,
 		ciphers:     ciphers,
+		signingKeys: signingKeys,
 	}, nil
 }
 
-func (a *Manager) Token(ctx context.Context, userID string) (string, error) {
-	ttl := time.Now().Add(a.config.TokenLifetime)
+func (a *Manager) signed() bool {
+	return a.config.Strategy == StrategySigned
+}
 
-	session := UserSession{
+// Token issues the token of the user session, the user type is the role of the user which is checked
+// by the middleware. The user agent is kept to tell the sessions of the user apart.
+func (a *Manager) Token(ctx context.Context, userID string, userType string, userAgent string) (string, error) {
+	session, err := a.newSession(userID, userType, userAgent)
+	if err != nil {
+		return "", err
+	}
+
+	token, sessionKey, err := a.issueAccess(ctx, session, session.SessionID)
+	if err != nil {
+		return "", err
+	}
+
+	// the signed token is not kept anywhere.
+	if a.signed() {
+		return token, nil
+	}
+
+	if err = a.addToIndex(ctx, session.ID, sessionIndexEntry{ID: session.SessionID, Key: sessionKey, ExpiredAt: session.ExpiredAt}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (a *Manager) newSession(userID string, userType string, userAgent string) (UserSession, error) {
+	sessionID, err := newSessionID()
+	if err != nil {
+		return UserSession{}, err
+	}
+
+	now := time.Now()
+
+	return UserSession{
 		ID:        userID,
-		ExpiredAt: ttl,
+		Type:      userType,
+		ExpiredAt: now.Add(a.config.TokenLifetime),
+		SessionID: sessionID,
+		UserAgent: userAgent,
+		CreatedAt: now,
+	}, nil
+}
+
+// issueAccess issues the access token of the strategy, the signed token has no session key.
+func (a *Manager) issueAccess(ctx context.Context, session UserSession, keyID string) (string, string, error) {
+	if a.signed() {
+		token, err := a.sign(session)
+		return token, "", err
 	}
 
+	return a.accessToken(ctx, session, keyID)
+}
+
+// accessToken stores the session under the key named by the key ID and encrypts the key into the token.
+func (a *Manager) accessToken(ctx context.Context, session UserSession, keyID string) (string, string, error) {
 	contents, err := json.Marshal(session)
 	if err != nil {
-		return "", nil
+		return "", "", err
 	}
 
-	gcm, err := cipher.NewGCM(a.ciphers[0])
+	sessionKey := fmt.Sprintf("auth:%s_%s_%s", session.Type, session.ID, keyID)
+
+	token, err := a.seal(sessionKey)
 	if err != nil {
-		return "", nil
+		return "", "", err
 	}
 
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", err
+	err = a.cacheDriver.Set(ctx, sessionKey, contents, time.Until(session.ExpiredAt))
+	if err != nil {
+		return "", "", err
 	}
 
-	randomizer := mathrand.New(mathrand.NewSource(time.Now().UTC().Local().UnixMicro()))
-	randVal := randomizer.Uint64()
-
-	sessionKey := fmt.Sprintf("auth:%s_%s_%d", defaultUserType, userID, randVal)
+	return token, sessionKey, nil
+}
 
-	var encryptedSessionKey = gcm.Seal(nonce, nonce, []byte(sessionKey), nil)
+// seal encrypts the session key using the newest cipher key, the token is prefixed by the key ID which is
+// authenticated as the additional data.
+func (a *Manager) seal(sessionKey string) (string, error) {
+	newest := a.ciphers[0]
 
-	err = a.cacheDriver.Set(ctx, sessionKey, contents, a.config.TokenLifetime)
+	gcm, err := cipher.NewGCM(newest.block)
 	if err != nil {
 		return "", err
 	}
 
-	return base64.StdEncoding.EncodeToString(encryptedSessionKey), nil
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	var encryptedSessionKey = gcm.Seal(nonce, nonce, []byte(sessionKey), []byte(newest.id))
+
+	return newest.id + tokenKeyIDSeparator + base64.StdEncoding.EncodeToString(encryptedSessionKey), nil
 }
 
 func (a *Manager) User(ctx context.Context, token string) (UserSession, error) {
-	key, err := a.sessionKeyFor(ctx, token)
+	session, _, err := a.user(ctx, token)
+	return session, err
+}
+
+// user reads the session of the token, the token sealed by the older cipher key is reissued using the newest key.
+func (a *Manager) user(ctx context.Context, token string) (UserSession, string, error) {
+	if a.signed() {
+		return a.signedUser(ctx, token)
+	}
+
+	key, current, err := a.openToken(token)
 	if err != nil {
-		return UserSession{}, ErrUnauthenticated
+		return UserSession{}, "", ErrUnauthenticated
 	}
 
 	result, err := a.cacheDriver.Get(ctx, key)
 	if err != nil || result == nil {
 		slog.Info("err get cache", slog.String("error", err.Error()))
-		return UserSession{}, ErrUnauthenticated
+		return UserSession{}, "", ErrUnauthenticated
 	}
 
 	var authenticatedUser UserSession
 
 	if err := json.Unmarshal(result, &authenticatedUser); err != nil {
-		return UserSession{}, err
+		return UserSession{}, "", err
 	}
 
 	if time.Now().UnixMilli() > authenticatedUser.ExpiredAt.UnixMilli() {
@@ -127,21 +275,83 @@ func (a *Manager) User(ctx context.Context, token string) (UserSession, error) {
 			log.Printf("auth: unable to delete session for user key [%s], err: %s", key, err)
 		}
 
-		return UserSession{}, ErrTokenExpired
+		return UserSession{}, "", ErrTokenExpired
+	}
+
+	if current {
+		return authenticatedUser, "", nil
+	}
+
+	// failing to reissue keeps the token of the older key working until it is expired.
+	reissued, err := a.seal(key)
+	if err != nil {
+		slog.Warn("auth: cannot reissue the token", slog.String("error", err.Error()))
+		return authenticatedUser, "", nil
 	}
 
-	return authenticatedUser, nil
+	return authenticatedUser, reissued, nil
 }
 
-func (a *Manager) sessionKeyFor(_ context.Context, token string) (string, error) {
-	decoded, err := base64.StdEncoding.DecodeString(token)
+// Reissue upgrades the token sealed by the older cipher key to the newest key, the session stays the same.
+// The token of the newest key is returned as is.
+func (a *Manager) Reissue(ctx context.Context, token string) (string, error) {
+	_, reissued, err := a.user(ctx, token)
 	if err != nil {
 		return "", err
 	}
 
-	gcm, err := cipher.NewGCM(a.ciphers[0])
+	if reissued == "" {
+		return token, nil
+	}
+
+	return reissued, nil
+}
+
+func (a *Manager) sessionKeyFor(_ context.Context, token string) (string, error) {
+	key, _, err := a.openToken(token)
+	return key, err
+}
+
+// openToken decrypts the session key of the token, it reports whether the token is sealed by the newest key.
+// The token issued before the tokens carried the key ID is tried with each key, the newest first.
+func (a *Manager) openToken(token string) (string, bool, error) {
+	keyID, encoded, hasKeyID := strings.Cut(token, tokenKeyIDSeparator)
+	if !hasKeyID {
+		encoded = token
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", false, err
+	}
+
+	if hasKeyID {
+		for i, key := range a.ciphers {
+			if key.id != keyID {
+				continue
+			}
+
+			plaintext, err := openSessionKey(key.block, decoded, []byte(keyID))
+			return plaintext, i == 0, err
+		}
+
+		return "", false, ErrUnknownCipherKey
+	}
+
+	for _, key := range a.ciphers {
+		plaintext, err := openSessionKey(key.block, decoded, nil)
+		if err == nil {
+			return plaintext, false, nil
+		}
+	}
+
+	return "", false, ErrInvalidToken
+}
+
+func openSessionKey(block cipher.Block, decoded []byte, additionalData []byte) (string, error) {
+	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	nonceSize := gcm.NonceSize()
@@ -149,11 +359,9 @@ func (a *Manager) sessionKeyFor(_ context.Context, token string) (string, error)
 		return "", ErrInvalidTokenSize
 	}
 
-	cipherText := []byte(decoded)
+	nonce, ciphertext := decoded[:nonceSize], decoded[nonceSize:]
 
-	nonce, ciphertext := cipherText[:nonceSize], cipherText[nonceSize:]
-
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, additionalData)
 	if err != nil {
 		return "", err
 	}
@@ -162,6 +370,10 @@ func (a *Manager) sessionKeyFor(_ context.Context, token string) (string, error)
 }
 
 func (a *Manager) Revoke(ctx context.Context, token string) error {
+	if a.signed() {
+		return a.revokeSigned(ctx, token)
+	}
+
 	key, err := a.sessionKeyFor(ctx, token)
 
 	// if we cannot find the key, then the token is already revoked.
@@ -169,5 +381,23 @@ func (a *Manager) Revoke(ctx context.Context, token string) error {
 		return nil
 	}
 
+	// the indexed session is removed from the index of its user as well.
+	contents, err := a.cacheDriver.Get(ctx, key)
+	if err != nil {
+		return a.cacheDriver.Del(ctx, key)
+	}
+
+	var session UserSession
+	if err = json.Unmarshal(contents, &session); err != nil || session.SessionID == "" {
+		return a.cacheDriver.Del(ctx, key)
+	}
+
+	_, err = a.revokeIndexed(ctx, session.ID, func(entry sessionIndexEntry) bool {
+		return entry.Key == key
+	})
+	if err != nil {
+		return err
+	}
+
 	return a.cacheDriver.Del(ctx, key)
 }
@@ -2,8 +2,8 @@ package auth
 
 import (
 	"context"
-	"crypto/aes"
 	"crypto/cipher"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +11,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/cache"
 )
 
 type arrayCacheDriver struct {
@@ -25,7 +27,7 @@ func (a *arrayCacheDriver) Get(ctx context.Context, key string) ([]byte, error)
 
 	val, ok := a.array[key]
 	if !ok {
-		return nil, errors.New("key not found")
+		return nil, cache.ErrNotFound
 	}
 
 	return val, nil
@@ -55,6 +57,11 @@ type mockFuncOpt struct {
 	delFunc func() error
 }
 
+func testCipherKey(key string) cipherKey {
+	c, _ := newCipherKey(key)
+	return c
+}
+
 func mockCacheDriver() *arrayCacheDriver {
 	return &arrayCacheDriver{
 		array: make(map[string][]byte, 0),
@@ -89,18 +96,13 @@ func TestNewAuthManager(t *testing.T) {
 			},
 			want: &Manager{
 				config: Config{
-					TokenLifetime: defaultTTL,
-					CipherKeys:    []string{"0rMTKewMPeSGi6vi", "Vo6g1ixi33zxc2Kb"},
+					TokenLifetime:        defaultTTL,
+					RefreshTokenLifetime: defaultRefreshTTL,
+					CipherKeys:           []string{"0rMTKewMPeSGi6vi", "Vo6g1ixi33zxc2Kb"},
 				},
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("Vo6g1ixi33zxc2Kb"))
-						return c
-					}(),
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("Vo6g1ixi33zxc2Kb"),
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 				cacheDriver: mockCacheDriver(),
 			},
@@ -145,11 +147,12 @@ func TestAuthManager_Token(t *testing.T) {
 	type fields struct {
 		config      Config
 		cacheDriver cacheDriver
-		ciphers     []cipher.Block
+		ciphers     []cipherKey
 	}
 	type args struct {
-		ctx    context.Context
-		userID string
+		ctx      context.Context
+		userID   string
+		userType string
 	}
 	tests := []struct {
 		name          string
@@ -166,16 +169,14 @@ func TestAuthManager_Token(t *testing.T) {
 					CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
 				},
 				cacheDriver: mockCacheDriver(),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
-				ctx:    context.Background(),
-				userID: fmt.Sprint(10),
+				ctx:      context.Background(),
+				userID:   fmt.Sprint(10),
+				userType: "customer",
 			},
 			wantStringVal: true,
 			wantErr:       false,
@@ -192,16 +193,14 @@ func TestAuthManager_Token(t *testing.T) {
 						return errors.New("failed to set session")
 					},
 				}),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
-				ctx:    context.Background(),
-				userID: fmt.Sprint(10),
+				ctx:      context.Background(),
+				userID:   fmt.Sprint(10),
+				userType: "customer",
 			},
 			wantStringVal: false,
 			wantErr:       true,
@@ -214,7 +213,7 @@ func TestAuthManager_Token(t *testing.T) {
 				cacheDriver: tt.fields.cacheDriver,
 				ciphers:     tt.fields.ciphers,
 			}
-			got, err := a.Token(tt.args.ctx, tt.args.userID)
+			got, err := a.Token(tt.args.ctx, tt.args.userID, tt.args.userType, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Manager.Token() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -231,7 +230,7 @@ func TestAuthManager_User(t *testing.T) {
 	type fields struct {
 		config      Config
 		cacheDriver cacheDriver
-		ciphers     []cipher.Block
+		ciphers     []cipherKey
 	}
 	type args struct {
 		ctx   context.Context
@@ -256,17 +255,15 @@ func TestAuthManager_User(t *testing.T) {
 					getFunc: func() ([]byte, error) {
 						val, _ := json.Marshal(UserSession{
 							ID:        fmt.Sprint(10),
-							ExpiredAt: time.Now().Add(24 * time.Hour).Truncate(24 * time.Hour),
+							Type:      "customer",
+							ExpiredAt: time.Now().Add(24 * time.Hour).Truncate(24 * time.Hour).UTC(),
 						})
 
 						return val, nil
 					},
 				}),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
@@ -274,12 +271,13 @@ func TestAuthManager_User(t *testing.T) {
 				token: "",
 			},
 			beforeTest: func(a *Manager, args *args) {
-				token, _ := a.Token(context.Background(), fmt.Sprint(10))
+				token, _ := a.Token(context.Background(), fmt.Sprint(10), "", "")
 				args.token = token
 			},
 			want: UserSession{
 				ID:        fmt.Sprint(10),
-				ExpiredAt: time.Now().Add(24 * time.Hour).Truncate(24 * time.Hour),
+				Type:      "customer",
+				ExpiredAt: time.Now().Add(24 * time.Hour).Truncate(24 * time.Hour).UTC(),
 			},
 			wantErr: false,
 		},
@@ -300,11 +298,8 @@ func TestAuthManager_User(t *testing.T) {
 						return val, nil
 					},
 				}),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
@@ -312,7 +307,7 @@ func TestAuthManager_User(t *testing.T) {
 				token: "",
 			},
 			beforeTest: func(a *Manager, args *args) {
-				token, _ := a.Token(context.Background(), fmt.Sprint(10))
+				token, _ := a.Token(context.Background(), fmt.Sprint(10), "", "")
 				args.token = token
 			},
 			want:    UserSession{},
@@ -347,7 +342,7 @@ func TestAuthManager_sessionKeyFor(t *testing.T) {
 	type fields struct {
 		config      Config
 		cacheDriver cacheDriver
-		ciphers     []cipher.Block
+		ciphers     []cipherKey
 	}
 	type args struct {
 		ctx   context.Context
@@ -369,11 +364,8 @@ func TestAuthManager_sessionKeyFor(t *testing.T) {
 					CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
 				},
 				cacheDriver: mockCacheDriver(),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
@@ -381,7 +373,7 @@ func TestAuthManager_sessionKeyFor(t *testing.T) {
 				token: "",
 			},
 			beforeTest: func(a *Manager, args *args) {
-				token, _ := a.Token(context.Background(), fmt.Sprint(10))
+				token, _ := a.Token(context.Background(), fmt.Sprint(10), "", "")
 				args.token = token
 			},
 			want:    "auth:_10_",
@@ -395,11 +387,8 @@ func TestAuthManager_sessionKeyFor(t *testing.T) {
 					CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
 				},
 				cacheDriver: mockCacheDriver(),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
@@ -439,7 +428,7 @@ func TestAuthManager_Revoke(t *testing.T) {
 	type fields struct {
 		config      Config
 		cacheDriver cacheDriver
-		ciphers     []cipher.Block
+		ciphers     []cipherKey
 	}
 	type args struct {
 		ctx   context.Context
@@ -460,11 +449,8 @@ func TestAuthManager_Revoke(t *testing.T) {
 					CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
 				},
 				cacheDriver: mockCacheDriver(),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
@@ -472,7 +458,7 @@ func TestAuthManager_Revoke(t *testing.T) {
 				token: "",
 			},
 			beforeTest: func(a *Manager, args *args) {
-				token, _ := a.Token(context.Background(), fmt.Sprint(10))
+				token, _ := a.Token(context.Background(), fmt.Sprint(10), "", "")
 				args.token = token
 			},
 			wantErr: false,
@@ -485,11 +471,8 @@ func TestAuthManager_Revoke(t *testing.T) {
 					CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
 				},
 				cacheDriver: mockCacheDriver(),
-				ciphers: []cipher.Block{
-					func() cipher.Block {
-						c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-						return c
-					}(),
+				ciphers: []cipherKey{
+					testCipherKey("0rMTKewMPeSGi6vi"),
 				},
 			},
 			args: args{
@@ -517,3 +500,56 @@ func TestAuthManager_Revoke(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthManager_KeyRotation(t *testing.T) {
+	cacheDriver := mockCacheDriver()
+	ctx := context.Background()
+
+	before, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, cacheDriver)
+	after, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi", "Vo6g1ixi33zxc2Kb"}}, cacheDriver)
+	removed, _ := NewAuthManager(Config{CipherKeys: []string{"Vo6g1ixi33zxc2Kb"}}, cacheDriver)
+
+	token, _ := before.Token(ctx, "10", "customer", "")
+
+	// the token issued before the tokens carried the key ID is sealed without the additional data.
+	sessionKey, _ := before.sessionKeyFor(ctx, token)
+	gcm, _ := cipher.NewGCM(before.ciphers[0].block)
+	nonce := make([]byte, gcm.NonceSize())
+	legacy := base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(sessionKey), nil))
+
+	for name, old := range map[string]string{"with key ID": token, "without key ID": legacy} {
+		t.Run(name, func(t *testing.T) {
+			session, reissued, err := after.user(ctx, old)
+			if err != nil || session.ID != "10" {
+				t.Fatalf("user() of the older key got = %+v, %v", session, err)
+			}
+
+			if !strings.HasPrefix(reissued, after.ciphers[0].id+tokenKeyIDSeparator) {
+				t.Fatalf("user() reissued = %v, want the token of the newest key", reissued)
+			}
+
+			if got, err := after.Reissue(ctx, old); err != nil || !strings.HasPrefix(got, after.ciphers[0].id) {
+				t.Errorf("Reissue() got = %v, %v, want the token of the newest key", got, err)
+			}
+
+			// the reissued token keeps working once the older key is removed, the session stays the same.
+			upgraded, err := removed.User(ctx, reissued)
+			if err != nil || upgraded.SessionID != session.SessionID {
+				t.Errorf("User() of the reissued token got = %+v, %v", upgraded, err)
+			}
+
+			if _, err = removed.User(ctx, old); err == nil {
+				t.Errorf("User() of the removed key error = nil, want unauthenticated")
+			}
+
+			// the token of the newest key is not reissued.
+			if _, reissued, _ = removed.user(ctx, reissued); reissued != "" {
+				t.Errorf("user() of the newest key reissued = %v, want none", reissued)
+			}
+		})
+	}
+
+	if _, _, err := after.openToken("ffffffff." + strings.SplitN(token, ".", 2)[1]); !errors.Is(err, ErrUnknownCipherKey) {
+		t.Errorf("openToken() of the unknown key ID error = %v, wantErr %v", err, ErrUnknownCipherKey)
+	}
+}
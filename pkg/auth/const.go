@@ -3,10 +3,23 @@ package auth
 import "time"
 
 const (
-	defaultUserType = ""
-	defaultTTL      = 60 * time.Minute
+	StrategySession = "session"
+	StrategySigned  = "signed"
 )
 
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+const (
+	defaultTTL        = 60 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+)
+
+// tokenKeyIDSeparator separates the cipher key ID from the encrypted session key, it is not a base64 character.
+const tokenKeyIDSeparator = "."
+
 type CtxKey string
 
 const CtxKeyUserSession CtxKey = "user_session"
@@ -3,9 +3,21 @@ package auth
 import "errors"
 
 var (
-	ErrCipherKeysIsEmpty  error = errors.New("cipher keys config is empty")
-	ErrUnauthenticated    error = errors.New("unauthenticated")
-	ErrTokenExpired       error = errors.New("token expired")
-	ErrSessionKeyNotFound error = errors.New("session key not found")
-	ErrInvalidTokenSize   error = errors.New("invalid token size")
+	ErrCipherKeysIsEmpty    error = errors.New("cipher keys config is empty")
+	ErrUnauthenticated      error = errors.New("unauthenticated")
+	ErrForbidden            error = errors.New("forbidden")
+	ErrTokenExpired         error = errors.New("token expired")
+	ErrSessionKeyNotFound   error = errors.New("session key not found")
+	ErrInvalidTokenSize     error = errors.New("invalid token size")
+	ErrInvalidToken         error = errors.New("invalid token")
+	ErrUnknownCipherKey     error = errors.New("unknown cipher key")
+	ErrInvalidRefreshToken  error = errors.New("invalid refresh token")
+	ErrRefreshTokenReused   error = errors.New("refresh token reused")
+	ErrUnsupportedStrategy  error = errors.New("unsupported token strategy")
+	ErrSigningKeysIsEmpty   error = errors.New("signing keys config is empty")
+	ErrUnsupportedAlgorithm error = errors.New("unsupported signing algorithm")
+	ErrInvalidSigningKey    error = errors.New("invalid signing key")
+	ErrUnknownSigningKey    error = errors.New("unknown signing key")
+	ErrSessionsNotTracked   error = errors.New("sessions are not tracked by the signed tokens")
+	ErrRevocationDisabled   error = errors.New("revocation of the signed tokens is disabled")
 )
@@ -3,12 +3,15 @@ package auth
 import (
 	"context"
 	"net/http"
+	"slices"
 	"strings"
 )
 
 const (
 	httpHeaderAuthKey = "Authorization"
 	authTokenPrefix   = "Bearer "
+	// HTTPHeaderReissuedToken carries the token upgraded to the newest cipher key, the client replaces its token with it.
+	HTTPHeaderReissuedToken = "X-Auth-Token"
 )
 
 type errorWriter interface {
@@ -18,6 +21,7 @@ type errorWriter interface {
 type Middleware struct {
 	auth      *Manager
 	errWriter errorWriter
+	userTypes []string
 }
 
 func NewMiddleware(authManager *Manager, httpErrWriter errorWriter) *Middleware {
@@ -27,27 +31,54 @@ func NewMiddleware(authManager *Manager, httpErrWriter errorWriter) *Middleware
 	}
 }
 
+// RequireRole returns the middleware which only lets through the authenticated users of the given types.
+func (m *Middleware) RequireRole(userTypes ...string) *Middleware {
+	return &Middleware{
+		auth:      m.auth,
+		errWriter: m.errWriter,
+		userTypes: userTypes,
+	}
+}
+
+// TokenFromRequest reads the bearer token of the request.
+func TokenFromRequest(r *http.Request) (string, error) {
+	auth := r.Header.Get(httpHeaderAuthKey)
+
+	if auth == "" {
+		return "", ErrUnauthenticated
+	}
+
+	after, ok := strings.CutPrefix(auth, authTokenPrefix)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+
+	return after, nil
+}
+
 func (m *Middleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		auth := r.Header.Get(httpHeaderAuthKey)
-
-		if auth == "" {
-			m.errWriter.Write(w, r, ErrUnauthenticated)
+		token, err := TokenFromRequest(r)
+		if err != nil {
+			m.errWriter.Write(w, r, err)
 			return
 		}
 
-		after, ok := strings.CutPrefix(auth, authTokenPrefix)
-		if !ok {
+		session, reissued, err := m.auth.user(r.Context(), token)
+		if err != nil {
 			m.errWriter.Write(w, r, ErrUnauthenticated)
 			return
 		}
 
-		session, err := m.auth.User(r.Context(), after)
-		if err != nil {
-			m.errWriter.Write(w, r, ErrUnauthenticated)
+		if len(m.userTypes) > 0 && !slices.Contains(m.userTypes, session.Type) {
+			m.errWriter.Write(w, r, ErrForbidden)
 			return
 		}
 
+		if reissued != "" {
+			w.Header().Set(HTTPHeaderReissuedToken, reissued)
+		}
+
 		newCtx := context.WithValue(r.Context(), CtxKeyUserSession, &session)
 		newReq := r.Clone(newCtx)
 
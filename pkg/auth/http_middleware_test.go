@@ -2,8 +2,6 @@ package auth
 
 import (
 	"context"
-	"crypto/aes"
-	"crypto/cipher"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +17,12 @@ type simpleErrorWriter struct {
 }
 
 func (s *simpleErrorWriter) Write(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrForbidden) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
 	if !errors.Is(err, ErrUnauthenticated) {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
@@ -33,6 +37,7 @@ func TestMiddleware_Handle(t *testing.T) {
 	type fields struct {
 		auth      *Manager
 		errWriter errorWriter
+		userTypes []string
 	}
 	type args struct {
 		next http.Handler
@@ -98,14 +103,43 @@ func TestMiddleware_Handle(t *testing.T) {
 						CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
 					},
 					cacheDriver: mockCacheDriver(),
-					ciphers: []cipher.Block{
-						func() cipher.Block {
-							c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-							return c
-						}(),
+					ciphers: []cipherKey{
+						testCipherKey("0rMTKewMPeSGi6vi"),
+					},
+				},
+				errWriter: &simpleErrorWriter{},
+			},
+			args: args{
+				next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte("success"))
+				}),
+				req: func() *http.Request {
+					req, _ := http.NewRequest(http.MethodGet, "/", nil)
+					return req
+				}(),
+			},
+			beforeTest: func(m *Middleware, args *args) {
+				token, _ := m.auth.Token(context.Background(), fmt.Sprint(10), "customer", "")
+				args.req.Header.Add(httpHeaderAuthKey, fmt.Sprintf("Bearer %s", token))
+			},
+			want:           "success",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "token valid with required role",
+			fields: fields{
+				auth: &Manager{
+					config: Config{
+						TokenLifetime: defaultTTL,
+						CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
+					},
+					cacheDriver: mockCacheDriver(),
+					ciphers: []cipherKey{
+						testCipherKey("0rMTKewMPeSGi6vi"),
 					},
 				},
 				errWriter: &simpleErrorWriter{},
+				userTypes: []string{"staff", "admin"},
 			},
 			args: args{
 				next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -117,12 +151,44 @@ func TestMiddleware_Handle(t *testing.T) {
 				}(),
 			},
 			beforeTest: func(m *Middleware, args *args) {
-				token, _ := m.auth.Token(context.Background(), fmt.Sprint(10))
+				token, _ := m.auth.Token(context.Background(), fmt.Sprint(10), "admin", "")
 				args.req.Header.Add(httpHeaderAuthKey, fmt.Sprintf("Bearer %s", token))
 			},
 			want:           "success",
 			wantStatusCode: http.StatusOK,
 		},
+		{
+			name: "token valid without required role",
+			fields: fields{
+				auth: &Manager{
+					config: Config{
+						TokenLifetime: defaultTTL,
+						CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
+					},
+					cacheDriver: mockCacheDriver(),
+					ciphers: []cipherKey{
+						testCipherKey("0rMTKewMPeSGi6vi"),
+					},
+				},
+				errWriter: &simpleErrorWriter{},
+				userTypes: []string{"staff", "admin"},
+			},
+			args: args{
+				next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte("success"))
+				}),
+				req: func() *http.Request {
+					req, _ := http.NewRequest(http.MethodGet, "/", nil)
+					return req
+				}(),
+			},
+			beforeTest: func(m *Middleware, args *args) {
+				token, _ := m.auth.Token(context.Background(), fmt.Sprint(10), "customer", "")
+				args.req.Header.Add(httpHeaderAuthKey, fmt.Sprintf("Bearer %s", token))
+			},
+			want:           ErrForbidden.Error(),
+			wantStatusCode: http.StatusForbidden,
+		},
 		{
 			name: "token expired",
 			fields: fields{
@@ -132,11 +198,8 @@ func TestMiddleware_Handle(t *testing.T) {
 						CipherKeys:    []string{"0rMTKewMPeSGi6vi"},
 					},
 					cacheDriver: mockCacheDriver(),
-					ciphers: []cipher.Block{
-						func() cipher.Block {
-							c, _ := aes.NewCipher([]byte("0rMTKewMPeSGi6vi"))
-							return c
-						}(),
+					ciphers: []cipherKey{
+						testCipherKey("0rMTKewMPeSGi6vi"),
 					},
 				},
 				errWriter: &simpleErrorWriter{},
@@ -151,7 +214,7 @@ func TestMiddleware_Handle(t *testing.T) {
 				}(),
 			},
 			beforeTest: func(m *Middleware, args *args) {
-				token, _ := m.auth.Token(context.Background(), fmt.Sprint(10))
+				token, _ := m.auth.Token(context.Background(), fmt.Sprint(10), "customer", "")
 				args.req.Header.Add(httpHeaderAuthKey, fmt.Sprintf("Bearer %s", token))
 
 				time.Sleep(20 * time.Millisecond)
@@ -165,6 +228,7 @@ func TestMiddleware_Handle(t *testing.T) {
 			m := &Middleware{
 				auth:      tt.fields.auth,
 				errWriter: tt.fields.errWriter,
+				userTypes: tt.fields.userTypes,
 			}
 
 			if tt.beforeTest != nil {
@@ -190,3 +254,53 @@ func TestMiddleware_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestMiddleware_RequireRole(t *testing.T) {
+	m := NewMiddleware(&Manager{}, &simpleErrorWriter{})
+
+	got := m.RequireRole("admin")
+	if got == m || !reflect.DeepEqual(got.userTypes, []string{"admin"}) || got.auth != m.auth {
+		t.Errorf("Middleware.RequireRole() = %v, want new middleware requiring [admin]", got)
+	}
+
+	if len(m.userTypes) != 0 {
+		t.Errorf("Middleware.RequireRole() modifies the base middleware user types = %v", m.userTypes)
+	}
+}
+
+func TestMiddleware_HandleReissuedToken(t *testing.T) {
+	cacheDriver := mockCacheDriver()
+	before, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, cacheDriver)
+	after, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi", "Vo6g1ixi33zxc2Kb"}}, cacheDriver)
+
+	old, _ := before.Token(context.Background(), "10", "customer", "")
+	current, _ := after.Token(context.Background(), "10", "customer", "")
+
+	handler := NewMiddleware(after, &simpleErrorWriter{}).Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("success"))
+	}))
+
+	serve := func(token string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Add(httpHeaderAuthKey, authTokenPrefix+token)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return recorder.Result()
+	}
+
+	resp := serve(old)
+	reissued := resp.Header.Get(HTTPHeaderReissuedToken)
+	if resp.StatusCode != http.StatusOK || reissued == "" || reissued == old {
+		t.Fatalf("Middleware.Handle() of the older key status = %v, reissued = %v", resp.StatusCode, reissued)
+	}
+
+	if resp = serve(reissued); resp.StatusCode != http.StatusOK || resp.Header.Get(HTTPHeaderReissuedToken) != "" {
+		t.Errorf("Middleware.Handle() of the reissued token status = %v, reissued = %v", resp.StatusCode, resp.Header.Get(HTTPHeaderReissuedToken))
+	}
+
+	if resp = serve(current); resp.Header.Get(HTTPHeaderReissuedToken) != "" {
+		t.Errorf("Middleware.Handle() of the newest key reissued = %v, want none", resp.Header.Get(HTTPHeaderReissuedToken))
+	}
+}
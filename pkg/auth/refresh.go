@@ -0,0 +1,289 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/cache"
+)
+
+// Tokens is the pair of the short-lived access token and the long-lived refresh token which issues the next pair.
+type Tokens struct {
+	Token            string    `json:"token"`
+	ExpiredAt        time.Time `json:"expired_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiredAt time.Time `json:"refresh_expired_at"`
+}
+
+// refreshFamily is the chain of the refresh tokens of a session, the hash of the latest refresh token and the
+// hashes of the used ones are kept. Presenting any used token of the family means one of them is leaked.
+type refreshFamily struct {
+	SessionID       string    `json:"session_id"`
+	UserID          string    `json:"user_id"`
+	Type            string    `json:"type"`
+	UserAgent       string    `json:"user_agent,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	TokenHash       string    `json:"token_hash"`
+	UsedTokenHashes []string  `json:"used_token_hashes,omitempty"`
+	SessionKey      string    `json:"session_key"`
+	ExpiredAt       time.Time `json:"expired_at"`
+}
+
+// used tells whether the hash belongs to the refresh token of the family which is already exchanged.
+func (f refreshFamily) used(tokenHash string) bool {
+	for _, usedHash := range f.UsedTokenHashes {
+		if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(usedHash)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f refreshFamily) session(expiredAt time.Time) UserSession {
+	return UserSession{
+		ID:        f.UserID,
+		Type:      f.Type,
+		ExpiredAt: expiredAt,
+		SessionID: f.SessionID,
+		UserAgent: f.UserAgent,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+// refreshFamilyKey is the cache key of the refresh token family of the session.
+func refreshFamilyKey(sessionID string) string {
+	return "auth:refresh:" + sessionID
+}
+
+// newRefreshSecret generates the secret part of the refresh token, the token is the session ID and the secret.
+func newRefreshSecret(sessionID string) (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+
+	return sessionID + "." + encoded, hashRefreshSecret(encoded), nil
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *Manager) readFamily(ctx context.Context, sessionID string) (refreshFamily, error) {
+	contents, err := a.cacheDriver.Get(ctx, refreshFamilyKey(sessionID))
+	if err != nil {
+		return refreshFamily{}, err
+	}
+
+	var family refreshFamily
+	if err = json.Unmarshal(contents, &family); err != nil {
+		return refreshFamily{}, err
+	}
+
+	return family, nil
+}
+
+// delSessionKey removes the stored session of the access token, the signed token has none.
+func (a *Manager) delSessionKey(ctx context.Context, sessionKey string) error {
+	if sessionKey == "" {
+		return nil
+	}
+
+	return a.cacheDriver.Del(ctx, sessionKey)
+}
+
+func (a *Manager) writeFamily(ctx context.Context, family refreshFamily) error {
+	contents, err := json.Marshal(family)
+	if err != nil {
+		return err
+	}
+
+	return a.cacheDriver.Set(ctx, refreshFamilyKey(family.SessionID), contents, time.Until(family.ExpiredAt))
+}
+
+// TokenPair issues the access token together with the refresh token of the new session.
+func (a *Manager) TokenPair(ctx context.Context, userID string, userType string, userAgent string) (Tokens, error) {
+	session, err := a.newSession(userID, userType, userAgent)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	token, sessionKey, err := a.issueAccess(ctx, session, session.SessionID)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	refreshToken, tokenHash, err := newRefreshSecret(session.SessionID)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	family := refreshFamily{
+		SessionID:  session.SessionID,
+		UserID:     userID,
+		Type:       userType,
+		UserAgent:  userAgent,
+		CreatedAt:  session.CreatedAt,
+		TokenHash:  tokenHash,
+		SessionKey: sessionKey,
+		ExpiredAt:  session.CreatedAt.Add(a.config.RefreshTokenLifetime),
+	}
+
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	if err = a.writeFamily(ctx, family); err != nil {
+		return Tokens{}, err
+	}
+
+	if !a.signed() {
+		err = a.putIndexEntry(ctx, userID, sessionIndexEntry{ID: session.SessionID, Key: sessionKey, ExpiredAt: family.ExpiredAt})
+		if err != nil {
+			return Tokens{}, err
+		}
+	}
+
+	return Tokens{
+		Token:            token,
+		ExpiredAt:        session.ExpiredAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiredAt: family.ExpiredAt,
+	}, nil
+}
+
+// RoleResolver returns the current role of the user, the error refuses the refresh, e.g. the deleted user.
+type RoleResolver func(ctx context.Context, userID string) (string, error)
+
+// Refresh rotates the refresh token, it issues the next pair of the session with the current role of the user
+// and ends the previous access token. The session expires RefreshTokenLifetime after the login, refreshing does
+// not extend it. The refresh token which is already used revokes the whole session, both the thief and the user
+// have to log in again, the token which was never issued is only rejected.
+func (a *Manager) Refresh(ctx context.Context, refreshToken string, currentRole RoleResolver) (Tokens, UserSession, error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return Tokens{}, UserSession{}, ErrInvalidRefreshToken
+	}
+
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	family, err := a.readFamily(ctx, sessionID)
+	if errors.Is(err, cache.ErrNotFound) {
+		return Tokens{}, UserSession{}, ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return Tokens{}, UserSession{}, err
+	}
+
+	now := time.Now()
+	if now.After(family.ExpiredAt) {
+		return Tokens{}, UserSession{}, ErrInvalidRefreshToken
+	}
+
+	// the signed tokens have no index, logging out everywhere is recorded in the denylist instead.
+	if a.signed() && a.config.Denylist {
+		denied, err := a.readDenylist(ctx, family.UserID)
+		if err != nil {
+			return Tokens{}, UserSession{}, err
+		}
+
+		if denied.revokes(family.session(family.ExpiredAt)) {
+			return Tokens{}, UserSession{}, ErrInvalidRefreshToken
+		}
+	}
+
+	tokenHash := hashRefreshSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(family.TokenHash)) != 1 {
+		// the session ID is not a secret, the unknown secret is rejected without ending the session.
+		if !family.used(tokenHash) {
+			return Tokens{}, UserSession{}, ErrInvalidRefreshToken
+		}
+
+		_, err = a.revokeMatched(ctx, family.UserID, func(entry sessionIndexEntry) bool {
+			return entry.ID == sessionID
+		})
+		if err != nil {
+			return Tokens{}, UserSession{}, err
+		}
+
+		// the family is removed even when the index has lost the session.
+		if err = a.delSessionKey(ctx, family.SessionKey); err != nil {
+			return Tokens{}, UserSession{}, err
+		}
+
+		if err = a.cacheDriver.Del(ctx, refreshFamilyKey(sessionID)); err != nil {
+			return Tokens{}, UserSession{}, err
+		}
+
+		if a.signed() && a.config.Denylist {
+			if err = a.denySession(ctx, family.UserID, sessionID); err != nil {
+				return Tokens{}, UserSession{}, err
+			}
+		}
+
+		return Tokens{}, UserSession{}, ErrRefreshTokenReused
+	}
+
+	role, err := currentRole(ctx, family.UserID)
+	if err != nil {
+		return Tokens{}, UserSession{}, err
+	}
+
+	keyID, err := newSessionID()
+	if err != nil {
+		return Tokens{}, UserSession{}, err
+	}
+
+	family.Type = role
+	session := family.session(now.Add(a.config.TokenLifetime))
+
+	token, sessionKey, err := a.issueAccess(ctx, session, keyID)
+	if err != nil {
+		return Tokens{}, UserSession{}, err
+	}
+
+	if err = a.delSessionKey(ctx, family.SessionKey); err != nil {
+		return Tokens{}, UserSession{}, err
+	}
+
+	nextRefreshToken, nextTokenHash, err := newRefreshSecret(sessionID)
+	if err != nil {
+		return Tokens{}, UserSession{}, err
+	}
+
+	// the family lives RefreshTokenLifetime from the login, thus the used hashes are bounded by the refreshes in it.
+	family.UsedTokenHashes = append(family.UsedTokenHashes, family.TokenHash)
+	family.TokenHash = nextTokenHash
+	family.SessionKey = sessionKey
+
+	if err = a.writeFamily(ctx, family); err != nil {
+		return Tokens{}, UserSession{}, err
+	}
+
+	if !a.signed() {
+		err = a.putIndexEntry(ctx, family.UserID, sessionIndexEntry{ID: sessionID, Key: sessionKey, ExpiredAt: family.ExpiredAt})
+		if err != nil {
+			return Tokens{}, UserSession{}, err
+		}
+	}
+
+	return Tokens{
+		Token:            token,
+		ExpiredAt:        session.ExpiredAt,
+		RefreshToken:     nextRefreshToken,
+		RefreshExpiredAt: family.ExpiredAt,
+	}, session, nil
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func customerRole(context.Context, string) (string, error) {
+	return "customer", nil
+}
+
+func TestAuthManager_Refresh(t *testing.T) {
+	a, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, mockCacheDriver())
+	ctx := context.Background()
+
+	issued, err := a.TokenPair(ctx, "10", "customer", "Firefox")
+	if err != nil || issued.Token == "" || issued.RefreshToken == "" {
+		t.Fatalf("TokenPair() got = %+v, %v", issued, err)
+	}
+
+	first, _ := a.User(ctx, issued.Token)
+
+	refreshed, session, err := a.Refresh(ctx, issued.RefreshToken, customerRole)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if refreshed.RefreshToken == issued.RefreshToken || refreshed.Token == issued.Token {
+		t.Errorf("Refresh() got = %+v, want the rotated pair", refreshed)
+	}
+
+	// refreshing does not extend the session.
+	if !refreshed.RefreshExpiredAt.Equal(issued.RefreshExpiredAt) {
+		t.Errorf("Refresh() refresh expired at = %v, want %v", refreshed.RefreshExpiredAt, issued.RefreshExpiredAt)
+	}
+
+	if session.ID != "10" || session.Type != "customer" || session.SessionID != first.SessionID {
+		t.Errorf("Refresh() session got = %+v, want the session %v of the user", session, first.SessionID)
+	}
+
+	// the previous access token is ended by the refresh.
+	if _, err = a.User(ctx, issued.Token); err == nil {
+		t.Errorf("User() of the previous access token error = nil, want unauthenticated")
+	}
+
+	current, err := a.User(ctx, refreshed.Token)
+	if err != nil || current.SessionID != first.SessionID || current.UserAgent != "Firefox" {
+		t.Errorf("User() of the refreshed token got = %+v, %v", current, err)
+	}
+
+	if sessions, _ := a.Sessions(ctx, "10"); len(sessions) != 1 {
+		t.Errorf("Sessions() got = %+v, want the refreshed session only", sessions)
+	}
+
+	// presenting the used refresh token again revokes the session, the latest pair included.
+	if _, _, err = a.Refresh(ctx, issued.RefreshToken, customerRole); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("Refresh() of the used token error = %v, wantErr %v", err, ErrRefreshTokenReused)
+	}
+
+	if _, err = a.User(ctx, refreshed.Token); err == nil {
+		t.Errorf("User() after the reuse error = nil, want unauthenticated")
+	}
+
+	if _, _, err = a.Refresh(ctx, refreshed.RefreshToken, customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() of the revoked family error = %v, wantErr %v", err, ErrInvalidRefreshToken)
+	}
+
+	if sessions, _ := a.Sessions(ctx, "10"); len(sessions) != 0 {
+		t.Errorf("Sessions() after the reuse got = %+v", sessions)
+	}
+
+	for _, token := range []string{"", "malformed", "unknown.secret"} {
+		if _, _, err = a.Refresh(ctx, token, customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+			t.Errorf("Refresh(%q) error = %v, wantErr %v", token, err, ErrInvalidRefreshToken)
+		}
+	}
+}
+
+func TestAuthManager_RefreshUnknownSecret(t *testing.T) {
+	a, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, mockCacheDriver())
+	ctx := context.Background()
+
+	issued, _ := a.TokenPair(ctx, "10", "customer", "Firefox")
+	session, _ := a.User(ctx, issued.Token)
+
+	// the session ID is public, guessing the secret does not log the user out.
+	if _, _, err := a.Refresh(ctx, session.SessionID+".guessed", customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() of the unknown secret error = %v, wantErr %v", err, ErrInvalidRefreshToken)
+	}
+
+	if _, err := a.User(ctx, issued.Token); err != nil {
+		t.Errorf("User() after the unknown secret error = %v", err)
+	}
+
+	second, _, err := a.Refresh(ctx, issued.RefreshToken, customerRole)
+	if err != nil {
+		t.Fatalf("Refresh() after the unknown secret error = %v", err)
+	}
+
+	if _, _, err = a.Refresh(ctx, second.RefreshToken, customerRole); err != nil {
+		t.Fatalf("Refresh() of the second token error = %v", err)
+	}
+
+	// any of the used tokens is detected, not only the previous one.
+	if _, _, err = a.Refresh(ctx, issued.RefreshToken, customerRole); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("Refresh() of the first used token error = %v, wantErr %v", err, ErrRefreshTokenReused)
+	}
+}
+
+func TestAuthManager_RefreshAfterLogout(t *testing.T) {
+	a, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, mockCacheDriver())
+	ctx := context.Background()
+
+	loggedOut, _ := a.TokenPair(ctx, "10", "customer", "Firefox")
+	other, _ := a.TokenPair(ctx, "10", "customer", "Safari")
+
+	if err := a.Revoke(ctx, loggedOut.Token); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	if _, _, err := a.Refresh(ctx, loggedOut.RefreshToken, customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() after the logout error = %v, wantErr %v", err, ErrInvalidRefreshToken)
+	}
+
+	if err := a.RevokeAll(ctx, "10"); err != nil {
+		t.Fatalf("RevokeAll() error = %v", err)
+	}
+
+	if _, _, err := a.Refresh(ctx, other.RefreshToken, customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() after logging out everywhere error = %v, wantErr %v", err, ErrInvalidRefreshToken)
+	}
+}
+
+func TestAuthManager_RefreshCurrentRole(t *testing.T) {
+	a, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, mockCacheDriver())
+	ctx := context.Background()
+
+	issued, _ := a.TokenPair(ctx, "10", "admin", "Firefox")
+
+	// the demoted user gets the access token of the current role.
+	refreshed, session, err := a.Refresh(ctx, issued.RefreshToken, customerRole)
+	if err != nil || session.Type != "customer" {
+		t.Fatalf("Refresh() after the role change got = %+v, %v, want the customer session", session, err)
+	}
+
+	if current, _ := a.User(ctx, refreshed.Token); current.Type != "customer" {
+		t.Errorf("User() of the refreshed token type = %v, want customer", current.Type)
+	}
+
+	// the deleted user cannot refresh, the refresh token is not rotated.
+	errDeleted := errors.New("user not found")
+	deleted := func(context.Context, string) (string, error) {
+		return "", errDeleted
+	}
+
+	if _, _, err = a.Refresh(ctx, refreshed.RefreshToken, deleted); !errors.Is(err, errDeleted) {
+		t.Errorf("Refresh() of the deleted user error = %v, wantErr %v", err, errDeleted)
+	}
+
+	if _, err = a.User(ctx, refreshed.Token); err != nil {
+		t.Errorf("User() after the refused refresh error = %v", err)
+	}
+}
+
+func TestAuthManager_RefreshExpired(t *testing.T) {
+	a, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}, RefreshTokenLifetime: 50 * time.Millisecond}, mockCacheDriver())
+	ctx := context.Background()
+
+	issued, _ := a.TokenPair(ctx, "10", "customer", "Firefox")
+
+	refreshed, _, err := a.Refresh(ctx, issued.RefreshToken, customerRole)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if _, _, err = a.Refresh(ctx, refreshed.RefreshToken, customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() after the session lifetime error = %v, wantErr %v", err, ErrInvalidRefreshToken)
+	}
+}
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/cache"
+)
+
+// sessionIndexEntry is the session of the user kept in the session index, the expiry lets the index drop
+// the expired sessions without reading them. The session which can be refreshed expires with its refresh token.
+type sessionIndexEntry struct {
+	ID        string    `json:"id"`
+	Key       string    `json:"key"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+func newSessionID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+// sessionIndexKey is the cache key of the session keys index of the user.
+func sessionIndexKey(userID string) string {
+	return "auth:sessions:" + userID
+}
+
+func (a *Manager) readIndex(ctx context.Context, userID string) ([]sessionIndexEntry, error) {
+	contents, err := a.cacheDriver.Get(ctx, sessionIndexKey(userID))
+	if errors.Is(err, cache.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the malformed index is replaced rather than blocking the user from logging in.
+	var entries []sessionIndexEntry
+	if err = json.Unmarshal(contents, &entries); err != nil {
+		slog.Warn("auth: malformed session index", slog.String("error", err.Error()), slog.String("user_id", userID))
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	return slices.DeleteFunc(entries, func(entry sessionIndexEntry) bool {
+		return now.After(entry.ExpiredAt)
+	}), nil
+}
+
+// writeIndex keeps the index until the last of its sessions expires, the empty index is removed.
+func (a *Manager) writeIndex(ctx context.Context, userID string, entries []sessionIndexEntry) error {
+	if len(entries) == 0 {
+		return a.cacheDriver.Del(ctx, sessionIndexKey(userID))
+	}
+
+	var expiredAt time.Time
+	for _, entry := range entries {
+		if entry.ExpiredAt.After(expiredAt) {
+			expiredAt = entry.ExpiredAt
+		}
+	}
+
+	contents, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	return a.cacheDriver.Set(ctx, sessionIndexKey(userID), contents, time.Until(expiredAt))
+}
+
+func (a *Manager) addToIndex(ctx context.Context, userID string, entry sessionIndexEntry) error {
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	return a.putIndexEntry(ctx, userID, entry)
+}
+
+// putIndexEntry adds the entry or replaces the entry of the same session, the caller holds the index lock.
+func (a *Manager) putIndexEntry(ctx context.Context, userID string, entry sessionIndexEntry) error {
+	entries, err := a.readIndex(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	entries = slices.DeleteFunc(entries, func(existing sessionIndexEntry) bool {
+		return existing.ID == entry.ID
+	})
+
+	return a.writeIndex(ctx, userID, append(entries, entry))
+}
+
+// revokeIndexed removes the sessions of the user matched by the given function, it reports whether
+// any session is matched.
+func (a *Manager) revokeIndexed(ctx context.Context, userID string, match func(entry sessionIndexEntry) bool) (bool, error) {
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	return a.revokeMatched(ctx, userID, match)
+}
+
+// revokeMatched ends the matched sessions together with their refresh tokens, the caller holds the index lock.
+func (a *Manager) revokeMatched(ctx context.Context, userID string, match func(entry sessionIndexEntry) bool) (bool, error) {
+	entries, err := a.readIndex(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	remaining := make([]sessionIndexEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !match(entry) {
+			remaining = append(remaining, entry)
+			continue
+		}
+
+		if err = a.cacheDriver.Del(ctx, entry.Key); err != nil {
+			return false, err
+		}
+
+		if err = a.cacheDriver.Del(ctx, refreshFamilyKey(entry.ID)); err != nil {
+			return false, err
+		}
+	}
+
+	if len(remaining) == len(entries) {
+		return false, nil
+	}
+
+	return true, a.writeIndex(ctx, userID, remaining)
+}
+
+// Sessions lists the active sessions of the user, the latest first.
+func (a *Manager) Sessions(ctx context.Context, userID string) ([]UserSession, error) {
+	if a.signed() {
+		return nil, ErrSessionsNotTracked
+	}
+
+	entries, err := a.readIndex(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]UserSession, 0, len(entries))
+	for _, entry := range entries {
+		contents, err := a.cacheDriver.Get(ctx, entry.Key)
+
+		// the session whose access token is expired is still active as long as it can be refreshed.
+		if errors.Is(err, cache.ErrNotFound) {
+			family, err := a.readFamily(ctx, entry.ID)
+			if errors.Is(err, cache.ErrNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			sessions = append(sessions, family.session(family.ExpiredAt))
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var session UserSession
+		if err = json.Unmarshal(contents, &session); err != nil {
+			return nil, err
+		}
+
+		session.ExpiredAt = entry.ExpiredAt
+		sessions = append(sessions, session)
+	}
+
+	slices.SortFunc(sessions, func(a, b UserSession) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession ends the session of the user, the session of the other user is not found.
+func (a *Manager) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	if a.signed() {
+		return a.revokeSignedSession(ctx, userID, sessionID)
+	}
+
+	found, err := a.revokeIndexed(ctx, userID, func(entry sessionIndexEntry) bool {
+		return entry.ID == sessionID
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return ErrSessionKeyNotFound
+	}
+
+	return nil
+}
+
+// RevokeAll ends every session of the user, it logs the user out everywhere.
+func (a *Manager) RevokeAll(ctx context.Context, userID string) error {
+	if a.signed() {
+		return a.revokeAllSigned(ctx, userID)
+	}
+
+	_, err := a.revokeIndexed(ctx, userID, func(entry sessionIndexEntry) bool {
+		return true
+	})
+
+	return err
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAuthManager_Sessions(t *testing.T) {
+	a, _ := NewAuthManager(Config{CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, mockCacheDriver())
+	ctx := context.Background()
+
+	first, _ := a.Token(ctx, "10", "customer", "Firefox")
+	second, _ := a.Token(ctx, "10", "customer", "Safari")
+	third, _ := a.Token(ctx, "10", "customer", "curl")
+	other, _ := a.Token(ctx, "11", "customer", "Chrome")
+
+	sessions, err := a.Sessions(ctx, "10")
+	if err != nil || len(sessions) != 3 {
+		t.Fatalf("Sessions() got = %+v, %v, want 3 sessions", sessions, err)
+	}
+
+	if sessions[0].UserAgent != "curl" || sessions[2].UserAgent != "Firefox" || sessions[0].SessionID == "" || sessions[0].CreatedAt.IsZero() {
+		t.Errorf("Sessions() got = %+v, want the latest first", sessions)
+	}
+
+	current, _ := a.User(ctx, second)
+	if current.SessionID != sessions[1].SessionID {
+		t.Errorf("User() session ID = %v, want %v", current.SessionID, sessions[1].SessionID)
+	}
+
+	// the session of the other user cannot be revoked.
+	otherSession, _ := a.User(ctx, other)
+	if err = a.RevokeSession(ctx, "10", otherSession.SessionID); !errors.Is(err, ErrSessionKeyNotFound) {
+		t.Errorf("RevokeSession() of the other user error = %v, wantErr %v", err, ErrSessionKeyNotFound)
+	}
+
+	if err = a.RevokeSession(ctx, "10", current.SessionID); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+
+	if _, err = a.User(ctx, second); err == nil {
+		t.Errorf("User() of the revoked session error = nil, want unauthenticated")
+	}
+
+	// the logged out session leaves the index.
+	if err = a.Revoke(ctx, first); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	if sessions, _ = a.Sessions(ctx, "10"); len(sessions) != 1 || sessions[0].UserAgent != "curl" {
+		t.Errorf("Sessions() after the revoke got = %+v", sessions)
+	}
+
+	if err = a.RevokeAll(ctx, "10"); err != nil {
+		t.Fatalf("RevokeAll() error = %v", err)
+	}
+
+	if _, err = a.User(ctx, third); err == nil {
+		t.Errorf("User() after logging out everywhere error = nil, want unauthenticated")
+	}
+
+	if sessions, _ = a.Sessions(ctx, "10"); len(sessions) != 0 {
+		t.Errorf("Sessions() after logging out everywhere got = %+v", sessions)
+	}
+
+	if _, err = a.User(ctx, other); err != nil {
+		t.Errorf("User() of the other user error = %v", err)
+	}
+}
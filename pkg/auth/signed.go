@@ -0,0 +1,373 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/cache"
+)
+
+// signingKey signs the claims of the signed token strategy, the token carries the key ID to tell which key
+// verifies it.
+type signingKey struct {
+	id        string
+	algorithm string
+	secret    []byte
+	private   ed25519.PrivateKey
+}
+
+func newSigningKey(algorithm string, key string) (signingKey, error) {
+	switch algorithm {
+	case AlgorithmHS256:
+		if len(key) < 32 {
+			return signingKey{}, ErrInvalidSigningKey
+		}
+
+		sum := sha256.Sum256([]byte(key))
+
+		return signingKey{id: hex.EncodeToString(sum[:4]), algorithm: algorithm, secret: []byte(key)}, nil
+	case AlgorithmEdDSA:
+		seed, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return signingKey{}, ErrInvalidSigningKey
+		}
+
+		private := ed25519.NewKeyFromSeed(seed)
+		sum := sha256.Sum256(private.Public().(ed25519.PublicKey))
+
+		return signingKey{id: hex.EncodeToString(sum[:4]), algorithm: algorithm, private: private}, nil
+	default:
+		return signingKey{}, ErrUnsupportedAlgorithm
+	}
+}
+
+func (k signingKey) sign(input []byte) []byte {
+	if k.algorithm == AlgorithmEdDSA {
+		return ed25519.Sign(k.private, input)
+	}
+
+	mac := hmac.New(sha256.New, k.secret)
+	mac.Write(input)
+
+	return mac.Sum(nil)
+}
+
+func (k signingKey) verify(input []byte, signature []byte) bool {
+	if k.algorithm == AlgorithmEdDSA {
+		return ed25519.Verify(k.private.Public().(ed25519.PublicKey), input, signature)
+	}
+
+	return hmac.Equal(k.sign(input), signature)
+}
+
+type signedHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// signedClaims is the session carried by the signed token, the times are in unix seconds. The auth time keeps
+// the milliseconds, thus logging in right after logging out everywhere is not revoked.
+type signedClaims struct {
+	Subject   string  `json:"sub"`
+	Role      string  `json:"role"`
+	SessionID string  `json:"sid"`
+	UserAgent string  `json:"ua,omitempty"`
+	AuthTime  float64 `json:"auth_time"`
+	IssuedAt  int64   `json:"iat"`
+	ExpiredAt int64   `json:"exp"`
+}
+
+// sign issues the JWT of the session using the newest signing key.
+func (a *Manager) sign(session UserSession) (string, error) {
+	newest := a.signingKeys[0]
+
+	header, err := json.Marshal(signedHeader{Algorithm: newest.algorithm, Type: "JWT", KeyID: newest.id})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(signedClaims{
+		Subject:   session.ID,
+		Role:      session.Type,
+		SessionID: session.SessionID,
+		UserAgent: session.UserAgent,
+		AuthTime:  float64(session.CreatedAt.UnixMilli()) / 1000,
+		IssuedAt:  time.Now().Unix(),
+		ExpiredAt: session.ExpiredAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	signature := newest.sign([]byte(input))
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseSigned verifies the signed token, it reports whether the token is signed by the newest key.
+func (a *Manager) parseSigned(token string) (UserSession, bool, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return UserSession{}, false, ErrInvalidToken
+	}
+
+	var header signedHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return UserSession{}, false, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return UserSession{}, false, ErrInvalidToken
+	}
+
+	for i, key := range a.signingKeys {
+		if key.id != header.KeyID {
+			continue
+		}
+
+		// the algorithm of the header is never trusted, it only has to match the key.
+		if header.Algorithm != key.algorithm || !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+			return UserSession{}, false, ErrInvalidToken
+		}
+
+		var claims signedClaims
+		if err = decodeSegment(parts[1], &claims); err != nil {
+			return UserSession{}, false, ErrInvalidToken
+		}
+
+		session := UserSession{
+			ID:        claims.Subject,
+			Type:      claims.Role,
+			ExpiredAt: time.Unix(claims.ExpiredAt, 0),
+			SessionID: claims.SessionID,
+			UserAgent: claims.UserAgent,
+			CreatedAt: time.UnixMilli(int64(math.Round(claims.AuthTime * 1000))),
+		}
+
+		if time.Now().After(session.ExpiredAt) {
+			return UserSession{}, false, ErrTokenExpired
+		}
+
+		return session, i == 0, nil
+	}
+
+	return UserSession{}, false, ErrUnknownSigningKey
+}
+
+func decodeSegment(segment string, v any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(decoded, v)
+}
+
+// signedUser verifies the token without reading the cache unless the denylist is enabled, the token signed by
+// the older key is reissued using the newest key.
+func (a *Manager) signedUser(ctx context.Context, token string) (UserSession, string, error) {
+	session, current, err := a.parseSigned(token)
+	if errors.Is(err, ErrTokenExpired) {
+		return UserSession{}, "", ErrTokenExpired
+	}
+
+	if err != nil {
+		return UserSession{}, "", ErrUnauthenticated
+	}
+
+	if a.config.Denylist {
+		denied, err := a.readDenylist(ctx, session.ID)
+		if err != nil {
+			return UserSession{}, "", err
+		}
+
+		if denied.revokes(session) {
+			return UserSession{}, "", ErrUnauthenticated
+		}
+	}
+
+	if current {
+		return session, "", nil
+	}
+
+	// failing to reissue keeps the token of the older key working until it is expired.
+	reissued, err := a.sign(session)
+	if err != nil {
+		slog.Warn("auth: cannot reissue the token", slog.String("error", err.Error()))
+		return session, "", nil
+	}
+
+	return session, reissued, nil
+}
+
+// denylist is the revoked signed tokens of the user, the sessions logged in before the revoked time and
+// the revoked sessions until their tokens are expired.
+type denylist struct {
+	RevokedBefore time.Time            `json:"revoked_before"`
+	Sessions      map[string]time.Time `json:"sessions,omitempty"`
+}
+
+func (d denylist) revokes(session UserSession) bool {
+	if !d.RevokedBefore.IsZero() && !session.CreatedAt.After(d.RevokedBefore) {
+		return true
+	}
+
+	_, revoked := d.Sessions[session.SessionID]
+
+	return revoked
+}
+
+// denylistKey is the cache key of the denylist of the user.
+func denylistKey(userID string) string {
+	return "auth:denylist:" + userID
+}
+
+func (a *Manager) readDenylist(ctx context.Context, userID string) (denylist, error) {
+	contents, err := a.cacheDriver.Get(ctx, denylistKey(userID))
+	if errors.Is(err, cache.ErrNotFound) {
+		return denylist{}, nil
+	}
+
+	if err != nil {
+		return denylist{}, err
+	}
+
+	var denied denylist
+	if err = json.Unmarshal(contents, &denied); err != nil {
+		return denylist{}, err
+	}
+
+	now := time.Now()
+	for sessionID, expiredAt := range denied.Sessions {
+		if now.After(expiredAt) {
+			delete(denied.Sessions, sessionID)
+		}
+	}
+
+	return denied, nil
+}
+
+// writeDenylist keeps the denylist until the last of the revoked tokens expires, the refresh token logged in
+// before the revoked time lives the longest.
+func (a *Manager) writeDenylist(ctx context.Context, userID string, denied denylist) error {
+	var expiredAt time.Time
+	if !denied.RevokedBefore.IsZero() {
+		expiredAt = denied.RevokedBefore.Add(a.config.RefreshTokenLifetime)
+	}
+
+	for _, sessionExpiredAt := range denied.Sessions {
+		if sessionExpiredAt.After(expiredAt) {
+			expiredAt = sessionExpiredAt
+		}
+	}
+
+	if !expiredAt.After(time.Now()) {
+		return a.cacheDriver.Del(ctx, denylistKey(userID))
+	}
+
+	contents, err := json.Marshal(denied)
+	if err != nil {
+		return err
+	}
+
+	return a.cacheDriver.Set(ctx, denylistKey(userID), contents, time.Until(expiredAt))
+}
+
+// denySession revokes the tokens of the session until they are expired, the caller holds the index lock.
+func (a *Manager) denySession(ctx context.Context, userID string, sessionID string) error {
+	denied, err := a.readDenylist(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if denied.Sessions == nil {
+		denied.Sessions = make(map[string]time.Time, 1)
+	}
+
+	denied.Sessions[sessionID] = time.Now().Add(a.config.TokenLifetime)
+
+	return a.writeDenylist(ctx, userID, denied)
+}
+
+// revokeSigned ends the refresh token of the session, the token itself keeps working until it is expired
+// unless the denylist is enabled.
+func (a *Manager) revokeSigned(ctx context.Context, token string) error {
+	session, _, err := a.parseSigned(token)
+
+	// the token which cannot be verified is not usable anyway.
+	if err != nil {
+		return nil
+	}
+
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	if err = a.cacheDriver.Del(ctx, refreshFamilyKey(session.SessionID)); err != nil {
+		return err
+	}
+
+	if !a.config.Denylist {
+		return nil
+	}
+
+	return a.denySession(ctx, session.ID, session.SessionID)
+}
+
+// revokeSignedSession revokes the session of the user, the signed tokens cannot be revoked without the denylist.
+func (a *Manager) revokeSignedSession(ctx context.Context, userID string, sessionID string) error {
+	if !a.config.Denylist {
+		return ErrRevocationDisabled
+	}
+
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	family, err := a.readFamily(ctx, sessionID)
+	if err != nil && !errors.Is(err, cache.ErrNotFound) {
+		return err
+	}
+
+	if err == nil && family.UserID != userID {
+		return ErrSessionKeyNotFound
+	}
+
+	if err == nil {
+		if err = a.cacheDriver.Del(ctx, refreshFamilyKey(sessionID)); err != nil {
+			return err
+		}
+	}
+
+	return a.denySession(ctx, userID, sessionID)
+}
+
+// revokeAllSigned revokes every session of the user logged in until now, the refresh tokens included.
+func (a *Manager) revokeAllSigned(ctx context.Context, userID string) error {
+	if !a.config.Denylist {
+		return ErrRevocationDisabled
+	}
+
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	denied, err := a.readDenylist(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	denied.RevokedBefore = time.Now()
+
+	return a.writeDenylist(ctx, userID, denied)
+}
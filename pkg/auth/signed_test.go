@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testHMACKey    = "fW2nq8ZkT4xVb7Lr1pGh6sYd3cJm9aEu"
+	testHMACKeyOld = "Qa5tR8yUi2oP4lKj7hGf1dSz6xCv3bNm"
+)
+
+// testEd25519Key is the base64 encoded seed of the Ed25519 key.
+var testEd25519Key = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func TestNewAuthManager_Signed(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    Config
+		wantErr error
+	}{
+		{name: "hmac by default", conf: Config{Strategy: StrategySigned, SigningKeys: []string{testHMACKey}}},
+		{name: "ed25519", conf: Config{Strategy: StrategySigned, SigningAlgorithm: AlgorithmEdDSA, SigningKeys: []string{testEd25519Key}}},
+		{name: "empty keys", conf: Config{Strategy: StrategySigned}, wantErr: ErrSigningKeysIsEmpty},
+		{name: "short hmac key", conf: Config{Strategy: StrategySigned, SigningKeys: []string{"short"}}, wantErr: ErrInvalidSigningKey},
+		{name: "invalid ed25519 seed", conf: Config{Strategy: StrategySigned, SigningAlgorithm: AlgorithmEdDSA, SigningKeys: []string{testHMACKey}}, wantErr: ErrInvalidSigningKey},
+		{name: "unsupported algorithm", conf: Config{Strategy: StrategySigned, SigningAlgorithm: "none", SigningKeys: []string{testHMACKey}}, wantErr: ErrUnsupportedAlgorithm},
+		{name: "unsupported strategy", conf: Config{Strategy: "cookie", CipherKeys: []string{"0rMTKewMPeSGi6vi"}}, wantErr: ErrUnsupportedStrategy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuthManager(tt.conf, mockCacheDriver())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewAuthManager() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && (len(got.signingKeys) != 1 || len(got.ciphers) != 0) {
+				t.Errorf("NewAuthManager() got = %+v, want the signing key only", got)
+			}
+		})
+	}
+}
+
+func TestAuthManager_SignedUser(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmHS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			key := testHMACKey
+			if algorithm == AlgorithmEdDSA {
+				key = testEd25519Key
+			}
+
+			// the cache is never read without the denylist.
+			cacheDriver := mockCacheDriverWithOpt(mockFuncOpt{
+				getFunc: func() ([]byte, error) {
+					return nil, errors.New("cache is read")
+				},
+			})
+
+			a, _ := NewAuthManager(Config{Strategy: StrategySigned, SigningAlgorithm: algorithm, SigningKeys: []string{key}}, cacheDriver)
+			ctx := context.Background()
+
+			token, err := a.Token(ctx, "10", "customer", "Firefox")
+			if err != nil || strings.Count(token, ".") != 2 {
+				t.Fatalf("Token() got = %v, %v, want the signed token", token, err)
+			}
+
+			session, err := a.User(ctx, token)
+			if err != nil || session.ID != "10" || session.Type != "customer" || session.UserAgent != "Firefox" || session.SessionID == "" {
+				t.Fatalf("User() got = %+v, %v", session, err)
+			}
+
+			parts := strings.Split(token, ".")
+			claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+			forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(claims), `"customer"`, `"admin"`, 1))) + "." + parts[2]
+
+			for name, invalid := range map[string]string{"forged claims": forged, "malformed": "token", "missing signature": parts[0] + "." + parts[1] + "."} {
+				if _, err = a.User(ctx, invalid); !errors.Is(err, ErrUnauthenticated) {
+					t.Errorf("User() of the %s error = %v, wantErr %v", name, err, ErrUnauthenticated)
+				}
+			}
+
+			if _, err = a.Sessions(ctx, "10"); !errors.Is(err, ErrSessionsNotTracked) {
+				t.Errorf("Sessions() error = %v, wantErr %v", err, ErrSessionsNotTracked)
+			}
+
+			if err = a.RevokeAll(ctx, "10"); !errors.Is(err, ErrRevocationDisabled) {
+				t.Errorf("RevokeAll() without the denylist error = %v, wantErr %v", err, ErrRevocationDisabled)
+			}
+		})
+	}
+}
+
+func TestAuthManager_SignedUserExpired(t *testing.T) {
+	a, _ := NewAuthManager(Config{Strategy: StrategySigned, SigningKeys: []string{testHMACKey}}, mockCacheDriver())
+
+	token, _ := a.sign(UserSession{ID: "10", Type: "customer", ExpiredAt: time.Now().Add(-time.Minute)})
+	if _, err := a.User(context.Background(), token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("User() of the expired token error = %v, wantErr %v", err, ErrTokenExpired)
+	}
+}
+
+func TestAuthManager_SignedKeyRotation(t *testing.T) {
+	ctx := context.Background()
+
+	before, _ := NewAuthManager(Config{Strategy: StrategySigned, SigningKeys: []string{testHMACKeyOld}}, mockCacheDriver())
+	after, _ := NewAuthManager(Config{Strategy: StrategySigned, SigningKeys: []string{testHMACKeyOld, testHMACKey}}, mockCacheDriver())
+	removed, _ := NewAuthManager(Config{Strategy: StrategySigned, SigningKeys: []string{testHMACKey}}, mockCacheDriver())
+
+	old, _ := before.Token(ctx, "10", "customer", "")
+
+	session, reissued, err := after.user(ctx, old)
+	if err != nil || reissued == "" {
+		t.Fatalf("user() of the older key got = %v, %v, want the reissued token", reissued, err)
+	}
+
+	upgraded, err := removed.User(ctx, reissued)
+	if err != nil || upgraded.SessionID != session.SessionID || !upgraded.CreatedAt.Equal(session.CreatedAt) {
+		t.Errorf("User() of the reissued token got = %+v, %v, want %+v", upgraded, err, session)
+	}
+
+	if _, err = removed.User(ctx, old); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("User() of the removed key error = %v, wantErr %v", err, ErrUnauthenticated)
+	}
+}
+
+func TestAuthManager_SignedDenylist(t *testing.T) {
+	a, _ := NewAuthManager(Config{Strategy: StrategySigned, SigningKeys: []string{testHMACKey}, Denylist: true}, mockCacheDriver())
+	ctx := context.Background()
+
+	loggedOut, _ := a.TokenPair(ctx, "10", "customer", "Firefox")
+	revoked, _ := a.TokenPair(ctx, "10", "customer", "Safari")
+	other, _ := a.Token(ctx, "11", "customer", "Chrome")
+
+	if err := a.Revoke(ctx, loggedOut.Token); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	if _, err := a.User(ctx, loggedOut.Token); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("User() after the logout error = %v, wantErr %v", err, ErrUnauthenticated)
+	}
+
+	if _, _, err := a.Refresh(ctx, loggedOut.RefreshToken, customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() after the logout error = %v, wantErr %v", err, ErrInvalidRefreshToken)
+	}
+
+	session, _ := a.User(ctx, revoked.Token)
+	if err := a.RevokeSession(ctx, "11", session.SessionID); !errors.Is(err, ErrSessionKeyNotFound) {
+		t.Errorf("RevokeSession() of the other user error = %v, wantErr %v", err, ErrSessionKeyNotFound)
+	}
+
+	// the refreshed token is revoked as well, the session stays the same.
+	refreshed, _, err := a.Refresh(ctx, revoked.RefreshToken, customerRole)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if err = a.RevokeSession(ctx, "10", session.SessionID); err != nil {
+		t.Fatalf("RevokeSession() error = %v", err)
+	}
+
+	for _, token := range []string{revoked.Token, refreshed.Token} {
+		if _, err = a.User(ctx, token); !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("User() of the revoked session error = %v, wantErr %v", err, ErrUnauthenticated)
+		}
+	}
+
+	if _, err = a.User(ctx, other); err != nil {
+		t.Errorf("User() of the other user error = %v", err)
+	}
+}
+
+func TestAuthManager_SignedRevokeAll(t *testing.T) {
+	a, _ := NewAuthManager(Config{Strategy: StrategySigned, SigningKeys: []string{testHMACKey}, Denylist: true}, mockCacheDriver())
+	ctx := context.Background()
+
+	pair, _ := a.TokenPair(ctx, "10", "customer", "Firefox")
+	token, _ := a.Token(ctx, "10", "customer", "Safari")
+
+	if err := a.RevokeAll(ctx, "10"); err != nil {
+		t.Fatalf("RevokeAll() error = %v", err)
+	}
+
+	for _, revoked := range []string{pair.Token, token} {
+		if _, err := a.User(ctx, revoked); !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("User() after logging out everywhere error = %v, wantErr %v", err, ErrUnauthenticated)
+		}
+	}
+
+	if _, _, err := a.Refresh(ctx, pair.RefreshToken, customerRole); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() after logging out everywhere error = %v, wantErr %v", err, ErrInvalidRefreshToken)
+	}
+
+	// logging in again is not revoked.
+	time.Sleep(2 * time.Millisecond)
+
+	again, _ := a.Token(ctx, "10", "customer", "Firefox")
+	if _, err := a.User(ctx, again); err != nil {
+		t.Errorf("User() of the new login error = %v", err)
+	}
+}
@@ -25,6 +25,8 @@ const (
 	querySetKey = `insert into caches (id, key, value, expired_at) 
 		values (?, ?, ?, ?) on conflict (key) do update set value = ?, expired_at = ?`
 	queryDelKey = `delete from caches where key = ?`
+	// queryTrimKeys removes the expired keys, the keys without expiry are kept.
+	queryTrimKeys = `delete from caches where expired_at <= ?`
 )
 
 type DriverDatabaseConfig struct {
@@ -85,7 +87,7 @@ func (d *DriverDatabase) boot() error {
 
 		for {
 			<-ticker.C
-			if _, cleanupErr := d.connection.Query("delete from caches where expired_at > ?", time.Now()); cleanupErr != nil {
+			if _, cleanupErr := d.connection.Exec(d.connection.Rebind(queryTrimKeys), time.Now()); cleanupErr != nil {
 				slog.Error(fmt.Sprintf("failed to trim caches table, err: %s", cleanupErr))
 			}
 		}
@@ -99,10 +101,14 @@ func (d *DriverDatabase) Get(ctx context.Context, key string) ([]byte, error) {
 
 	err := d.getPreparedStmt.GetContext(ctx, &result, key, time.Now())
 
-	if err != nil && errors.Is(err, sql.ErrNoRows) {
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrNotFound
 	}
 
+	if err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
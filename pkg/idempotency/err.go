@@ -0,0 +1,9 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrInvalidKey        error = errors.New("invalid idempotency key")
+	ErrKeyReused         error = errors.New("idempotency key is already used by another request")
+	ErrRequestInProgress error = errors.New("request with the same idempotency key is in progress")
+)
@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+)
+
+const (
+	HTTPHeaderKey      = "Idempotency-Key"
+	HTTPHeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+type errorWriter interface {
+	Write(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Middleware replays the response of the request which is retried with the same Idempotency-Key header.
+// It must be placed after the auth middleware, the keys are stored against the user of the session.
+// The request without the header is passed through.
+type Middleware struct {
+	store     *Store
+	errWriter errorWriter
+}
+
+func NewMiddleware(store *Store, httpErrWriter errorWriter) *Middleware {
+	return &Middleware{
+		store:     store,
+		errWriter: httpErrWriter,
+	}
+}
+
+func (m *Middleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(HTTPHeaderKey)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxKeyLength {
+			m.errWriter.Write(w, r, ErrInvalidKey)
+			return
+		}
+
+		session, ok := r.Context().Value(auth.CtxKeyUserSession).(*auth.UserSession)
+		if !ok || session == nil {
+			m.errWriter.Write(w, r, auth.ErrUnauthenticated)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			m.errWriter.Write(w, r, err)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestFingerprint := fingerprint(r, body)
+
+		record, err := m.store.Begin(r.Context(), session.ID, key, requestFingerprint)
+		if err != nil {
+			m.errWriter.Write(w, r, err)
+			return
+		}
+
+		if record != nil {
+			replay(w, *record)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// the server errors are not stored, the request can be retried with the same key.
+		if recorder.statusCode >= http.StatusInternalServerError {
+			if err = m.store.Release(r.Context(), session.ID, key); err != nil {
+				slog.Error("failed to release idempotency key", slog.String("error", err.Error()))
+			}
+
+			return
+		}
+
+		err = m.store.Complete(r.Context(), session.ID, key, Record{
+			Fingerprint: requestFingerprint,
+			StatusCode:  recorder.statusCode,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			slog.Error("failed to store idempotent response", slog.String("error", err.Error()))
+		}
+	})
+}
+
+// fingerprint identifies the request by the method, the uri including the query and the body.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.URL.RequestURI()))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, record Record) {
+	if record.ContentType != "" {
+		w.Header().Set("Content-Type", record.ContentType)
+	}
+
+	w.Header().Set(HTTPHeaderReplayed, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
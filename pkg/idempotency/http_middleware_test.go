@@ -0,0 +1,197 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/pkg/auth"
+)
+
+type simpleErrorWriter struct {
+}
+
+func (s *simpleErrorWriter) Write(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Is(err, ErrInvalidKey):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, ErrKeyReused):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	case errors.Is(err, ErrRequestInProgress):
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	w.Write([]byte(err.Error()))
+}
+
+func TestMiddleware_Handle(t *testing.T) {
+	calls := 0
+	statusCode := http.StatusCreated
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(`{"order":` + string(body) + `}`))
+	})
+
+	m := NewMiddleware(NewStore(Config{}, mockCacheDriver()), &simpleErrorWriter{})
+	handler := m.Handle(next)
+
+	request := func(key, body string, session *auth.UserSession) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/orders/place", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(HTTPHeaderKey, key)
+		}
+
+		if session != nil {
+			req = req.WithContext(context.WithValue(req.Context(), auth.CtxKeyUserSession, session))
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	user := &auth.UserSession{ID: "user-1"}
+
+	tests := []struct {
+		name           string
+		key            string
+		body           string
+		session        *auth.UserSession
+		beforeTest     func()
+		wantStatusCode int
+		wantBody       string
+		wantReplayed   bool
+		wantCalls      int
+	}{
+		{
+			name:           "without key",
+			body:           `1`,
+			session:        user,
+			wantStatusCode: http.StatusCreated,
+			wantBody:       `{"order":1}`,
+			wantCalls:      1,
+		},
+		{
+			name:           "first request",
+			key:            "key-1",
+			body:           `2`,
+			session:        user,
+			wantStatusCode: http.StatusCreated,
+			wantBody:       `{"order":2}`,
+			wantCalls:      2,
+		},
+		{
+			name:           "replayed request",
+			key:            "key-1",
+			body:           `2`,
+			session:        user,
+			wantStatusCode: http.StatusCreated,
+			wantBody:       `{"order":2}`,
+			wantReplayed:   true,
+			wantCalls:      2,
+		},
+		{
+			name:           "same key with different body",
+			key:            "key-1",
+			body:           `3`,
+			session:        user,
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantBody:       ErrKeyReused.Error(),
+			wantCalls:      2,
+		},
+		{
+			name:           "same key of another user",
+			key:            "key-1",
+			body:           `3`,
+			session:        &auth.UserSession{ID: "user-2"},
+			wantStatusCode: http.StatusCreated,
+			wantBody:       `{"order":3}`,
+			wantCalls:      3,
+		},
+		{
+			name:           "without session",
+			key:            "key-2",
+			body:           `4`,
+			wantStatusCode: http.StatusUnauthorized,
+			wantBody:       auth.ErrUnauthenticated.Error(),
+			wantCalls:      3,
+		},
+		{
+			name:           "key too long",
+			key:            strings.Repeat("k", maxKeyLength+1),
+			body:           `4`,
+			session:        user,
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       ErrInvalidKey.Error(),
+			wantCalls:      3,
+		},
+		{
+			name:    "server error is not stored",
+			key:     "key-3",
+			body:    `5`,
+			session: user,
+			beforeTest: func() {
+				statusCode = http.StatusInternalServerError
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"order":5}`,
+			wantCalls:      4,
+		},
+		{
+			name:    "retry after server error",
+			key:     "key-3",
+			body:    `5`,
+			session: user,
+			beforeTest: func() {
+				statusCode = http.StatusCreated
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBody:       `{"order":5}`,
+			wantCalls:      5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.beforeTest != nil {
+				tt.beforeTest()
+			}
+
+			rec := request(tt.key, tt.body, tt.session)
+
+			if rec.Code != tt.wantStatusCode {
+				t.Errorf("Handle() status code = %v, want %v", rec.Code, tt.wantStatusCode)
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("Handle() body = %v, want %v", got, tt.wantBody)
+			}
+
+			if got := rec.Header().Get(HTTPHeaderReplayed) == "true"; got != tt.wantReplayed {
+				t.Errorf("Handle() replayed = %v, want %v", got, tt.wantReplayed)
+			}
+
+			if tt.wantReplayed && rec.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Handle() replayed content type = %v", rec.Header().Get("Content-Type"))
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("Handle() next handler calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/cache"
+)
+
+const (
+	defaultTTL         = 24 * time.Hour
+	defaultLockTimeout = time.Minute
+	cacheKeyPrefix     = "idempotency:"
+)
+
+type cacheDriver interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
+	Del(ctx context.Context, key string) error
+}
+
+type Config struct {
+	// TTL is how long the completed response is kept to be replayed.
+	TTL time.Duration
+	// LockTimeout is how long the key is reserved by the request which is not completed yet,
+	// the key is released after the timeout when the process dies in the middle of the request.
+	LockTimeout time.Duration
+}
+
+// Record is the stored state of the idempotency key.
+type Record struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Store keeps the idempotency keys of the users in the cache.
+type Store struct {
+	config      Config
+	cacheDriver cacheDriver
+	mu          sync.Mutex
+}
+
+func NewStore(conf Config, cacheDriver cacheDriver) *Store {
+	if conf.TTL == 0 {
+		conf.TTL = defaultTTL
+	}
+
+	if conf.LockTimeout == 0 {
+		conf.LockTimeout = defaultLockTimeout
+	}
+
+	return &Store{
+		config:      conf,
+		cacheDriver: cacheDriver,
+	}
+}
+
+func cacheKey(userID, key string) string {
+	return cacheKeyPrefix + userID + ":" + key
+}
+
+// Begin reserves the key of the user for the request with the fingerprint. The completed record is returned
+// when the same request is replayed, and nil is returned when the key is reserved for the request.
+func (s *Store) Begin(ctx context.Context, userID, key, fingerprint string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cacheKey := cacheKey(userID, key)
+
+	stored, err := s.cacheDriver.Get(ctx, cacheKey)
+	if err != nil && !errors.Is(err, cache.ErrNotFound) {
+		return nil, err
+	}
+
+	if err == nil {
+		var record Record
+		if err = json.Unmarshal(stored, &record); err != nil {
+			return nil, err
+		}
+
+		if record.Fingerprint != fingerprint {
+			return nil, ErrKeyReused
+		}
+
+		if !record.Completed {
+			return nil, ErrRequestInProgress
+		}
+
+		return &record, nil
+	}
+
+	return nil, s.put(ctx, cacheKey, Record{Fingerprint: fingerprint}, s.config.LockTimeout)
+}
+
+// Complete stores the response of the reserved key to be replayed.
+func (s *Store) Complete(ctx context.Context, userID, key string, record Record) error {
+	record.Completed = true
+
+	return s.put(ctx, cacheKey(userID, key), record, s.config.TTL)
+}
+
+// Release removes the reserved key, so the request can be retried with the same key.
+func (s *Store) Release(ctx context.Context, userID, key string) error {
+	return s.cacheDriver.Del(ctx, cacheKey(userID, key))
+}
+
+func (s *Store) put(ctx context.Context, cacheKey string, record Record, ttl time.Duration) error {
+	contents, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.cacheDriver.Set(ctx, cacheKey, contents, ttl)
+}
@@ -0,0 +1,109 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rendyananta/example-online-book-store/pkg/cache"
+)
+
+type arrayCacheDriver struct {
+	array map[string][]byte
+	ttl   map[string]time.Duration
+}
+
+func (a *arrayCacheDriver) Get(ctx context.Context, key string) ([]byte, error) {
+	val, ok := a.array[key]
+	if !ok {
+		return nil, cache.ErrNotFound
+	}
+
+	return val, nil
+}
+
+func (a *arrayCacheDriver) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	a.array[key] = val
+	a.ttl[key] = ttl
+	return nil
+}
+
+func (a *arrayCacheDriver) Del(ctx context.Context, key string) error {
+	delete(a.array, key)
+	delete(a.ttl, key)
+	return nil
+}
+
+func mockCacheDriver() *arrayCacheDriver {
+	return &arrayCacheDriver{
+		array: make(map[string][]byte),
+		ttl:   make(map[string]time.Duration),
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	store := NewStore(Config{}, mockCacheDriver())
+
+	if store.config.TTL != defaultTTL {
+		t.Errorf("NewStore() TTL = %v, want %v", store.config.TTL, defaultTTL)
+	}
+
+	if store.config.LockTimeout != defaultLockTimeout {
+		t.Errorf("NewStore() LockTimeout = %v, want %v", store.config.LockTimeout, defaultLockTimeout)
+	}
+}
+
+func TestStore_Begin(t *testing.T) {
+	ctx := context.Background()
+	driver := mockCacheDriver()
+	store := NewStore(Config{TTL: time.Hour, LockTimeout: time.Minute}, driver)
+
+	record, err := store.Begin(ctx, "user-1", "key-1", "fingerprint")
+	if err != nil || record != nil {
+		t.Fatalf("Begin() got = %v, error = %v, want the key reserved", record, err)
+	}
+
+	if got := driver.ttl["idempotency:user-1:key-1"]; got != time.Minute {
+		t.Errorf("Begin() reserved the key for %v, want %v", got, time.Minute)
+	}
+
+	if _, err = store.Begin(ctx, "user-1", "key-1", "fingerprint"); !errors.Is(err, ErrRequestInProgress) {
+		t.Errorf("Begin() error = %v, wantErr %v", err, ErrRequestInProgress)
+	}
+
+	if _, err = store.Begin(ctx, "user-1", "key-1", "other"); !errors.Is(err, ErrKeyReused) {
+		t.Errorf("Begin() error = %v, wantErr %v", err, ErrKeyReused)
+	}
+
+	// the keys are stored against the user.
+	if record, err = store.Begin(ctx, "user-2", "key-1", "other"); err != nil || record != nil {
+		t.Errorf("Begin() of another user got = %v, error = %v, want the key reserved", record, err)
+	}
+
+	err = store.Complete(ctx, "user-1", "key-1", Record{Fingerprint: "fingerprint", StatusCode: 201, Body: []byte("created")})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	if got := driver.ttl["idempotency:user-1:key-1"]; got != time.Hour {
+		t.Errorf("Complete() stored the key for %v, want %v", got, time.Hour)
+	}
+
+	record, err = store.Begin(ctx, "user-1", "key-1", "fingerprint")
+	if err != nil || record == nil {
+		t.Fatalf("Begin() got = %v, error = %v, want the completed record", record, err)
+	}
+
+	if !record.Completed || record.StatusCode != 201 || string(record.Body) != "created" {
+		t.Errorf("Begin() got = %+v", record)
+	}
+
+	if err = store.Release(ctx, "user-2", "key-1"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+
+	if record, err = store.Begin(ctx, "user-2", "key-1", "fingerprint"); err != nil || record != nil {
+		t.Errorf("Begin() of the released key got = %v, error = %v, want the key reserved", record, err)
+	}
+}
@@ -0,0 +1,59 @@
+package money
+
+import "strings"
+
+// Currency is the ISO 4217 currency code.
+type Currency string
+
+const (
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	IDR Currency = "IDR"
+	JPY Currency = "JPY"
+)
+
+// DefaultCurrency is used for the amount given without its currency.
+const DefaultCurrency = USD
+
+// minorUnits lists the currencies which minor unit is not the cent.
+var minorUnits = map[Currency]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	JPY:   0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+// ParseCurrency normalizes the three letters currency code.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidCurrency
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+
+	return Currency(code), nil
+}
+
+// MinorUnits is the number of decimals of the currency, e.g. 2 for the cents of USD.
+func (c Currency) MinorUnits() int {
+	if units, ok := minorUnits[c]; ok {
+		return units
+	}
+
+	return 2
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
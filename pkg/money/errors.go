@@ -0,0 +1,11 @@
+package money
+
+import "errors"
+
+var (
+	ErrInvalidAmount       = errors.New("invalid money amount")
+	ErrInvalidCurrency     = errors.New("invalid currency")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+	ErrInvalidRoundingMode = errors.New("invalid rounding mode")
+	ErrInvalidRate         = errors.New("invalid exchange rate")
+)
@@ -0,0 +1,237 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is the amount in the minor unit of its currency, e.g. 1234 USD is 12.34 dollars.
+// The arithmetic of different currencies is a programming error and panics with ErrCurrencyMismatch,
+// the zero value takes the currency of the other operand.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+func New(minorAmount int64, currency Currency) Money {
+	return Money{amount: minorAmount, currency: currency}
+}
+
+// Zero is the zero amount of the currency.
+func Zero(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// Parse parses the decimal amount, e.g. "12.34". The amount more precise than the minor unit is rejected.
+func Parse(value string, currency Currency) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	r.Mul(r, scale(currency))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return Money{}, ErrInvalidAmount
+	}
+
+	return Money{amount: r.Num().Int64(), currency: currency}, nil
+}
+
+// ParseRounded parses the decimal amount, the amount more precise than the minor unit is rounded.
+func ParseRounded(value string, currency Currency, mode RoundingMode) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	return Money{amount: round(r.Mul(r, scale(currency)), mode), currency: currency}, nil
+}
+
+// scale is the number of minor units in a major unit of the currency.
+func scale(currency Currency) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currency.MinorUnits())), nil))
+}
+
+// Amount is the amount in the minor unit.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// Decimal formats the amount using the decimals of the currency, e.g. "12.34".
+func (m Money) Decimal() string {
+	units := m.currency.MinorUnits()
+	if units == 0 {
+		return strconv.FormatInt(m.amount, 10)
+	}
+
+	digits := strconv.FormatInt(m.amount, 10)
+	sign := ""
+	if m.amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currency.String()
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// currencyWith finds the currency of the operation, the zero value adopts the currency of the other operand.
+func (m Money) currencyWith(other Money) Currency {
+	switch {
+	case m.currency == other.currency:
+		return m.currency
+	case m.currency == "" && m.amount == 0:
+		return other.currency
+	case other.currency == "" && other.amount == 0:
+		return m.currency
+	}
+
+	panic(ErrCurrencyMismatch)
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{amount: m.amount + other.amount, currency: m.currencyWith(other)}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{amount: m.amount - other.amount, currency: m.currencyWith(other)}
+}
+
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Mul multiplies the amount by the quantity.
+func (m Money) Mul(quantity int64) Money {
+	return Money{amount: m.amount * quantity, currency: m.currency}
+}
+
+// Percent takes the percent of the amount, e.g. 11 for 11%, the fraction of the minor unit is rounded.
+func (m Money) Percent(percent float64, mode RoundingMode) Money {
+	// the percent is taken from its shortest decimal form, thus 0.1 is exactly a tenth.
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	r.Mul(r, new(big.Rat).SetInt64(m.amount))
+	r.Quo(r, big.NewRat(100, 1))
+
+	return Money{amount: round(r, mode), currency: m.currency}
+}
+
+// Cmp compares the amounts, it returns -1, 0 or +1.
+func (m Money) Cmp(other Money) int {
+	m.currencyWith(other)
+
+	switch {
+	case m.amount < other.amount:
+		return -1
+	case m.amount > other.amount:
+		return 1
+	}
+
+	return 0
+}
+
+func (m Money) Equal(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+func Min(a, b Money) Money {
+	if a.Cmp(b) > 0 {
+		return b
+	}
+
+	return a
+}
+
+func Max(a, b Money) Money {
+	if a.Cmp(b) < 0 {
+		return b
+	}
+
+	return a
+}
+
+type jsonMoney struct {
+	Amount   string   `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// MarshalJSON encodes the decimal amount as string, e.g. {"amount": "12.34", "currency": "USD"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	currency := m.currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	return json.Marshal(jsonMoney{Amount: Money{amount: m.amount, currency: currency}.Decimal(), Currency: currency})
+}
+
+// UnmarshalJSON decodes the money object, the decimal string or the number, the latter two use the default currency.
+// The number is read from its literal thus it is as exact as the string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var value string
+	var currency = DefaultCurrency
+
+	switch raw.(type) {
+	case nil:
+		return nil
+	case string:
+		_ = json.Unmarshal(data, &value)
+	case float64:
+		value = string(data)
+	case map[string]any:
+		var obj jsonMoney
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return ErrInvalidAmount
+		}
+
+		value = obj.Amount
+		if obj.Currency != "" {
+			parsed, err := ParseCurrency(string(obj.Currency))
+			if err != nil {
+				return err
+			}
+
+			currency = parsed
+		}
+	default:
+		return ErrInvalidAmount
+	}
+
+	parsed, err := Parse(value, currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency Currency
+		want     int64
+		wantErr  error
+	}{
+		{name: "decimal", value: "12.34", currency: USD, want: 1234},
+		{name: "integer", value: "12", currency: USD, want: 1200},
+		{name: "negative", value: "-0.05", currency: USD, want: -5},
+		{name: "zero decimals currency", value: "1500", currency: JPY, want: 1500},
+		{name: "too precise", value: "0.105", currency: USD, wantErr: ErrInvalidAmount},
+		{name: "not a number", value: "ten", currency: USD, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got.Amount() != tt.want {
+				t.Errorf("Parse() got = %v, want %v", got.Amount(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRounded(t *testing.T) {
+	tests := []struct {
+		value string
+		mode  RoundingMode
+		want  int64
+	}{
+		{value: "0.105", mode: RoundHalfUp, want: 11},
+		{value: "-0.105", mode: RoundHalfUp, want: -11},
+		{value: "0.105", mode: RoundHalfEven, want: 10},
+		{value: "0.115", mode: RoundHalfEven, want: 12},
+		{value: "0.101", mode: RoundCeiling, want: 11},
+		{value: "-0.101", mode: RoundCeiling, want: -10},
+		{value: "0.109", mode: RoundFloor, want: 10},
+		{value: "-0.101", mode: RoundFloor, want: -11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRounded(tt.value, USD, tt.mode)
+			if err != nil {
+				t.Fatalf("ParseRounded() error = %v", err)
+			}
+
+			if got.Amount() != tt.want {
+				t.Errorf("ParseRounded() got = %v, want %v", got.Amount(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_Percent(t *testing.T) {
+	price := New(1999, USD)
+
+	if got := price.Percent(10, RoundHalfUp); got.Amount() != 200 {
+		t.Errorf("Percent() half up got = %v, want 200", got.Amount())
+	}
+
+	if got := price.Percent(10, RoundFloor); got.Amount() != 199 {
+		t.Errorf("Percent() floor got = %v, want 199", got.Amount())
+	}
+
+	// 0.1 is not exact as float64, it must still be a tenth of a percent.
+	if got := New(100000, USD).Percent(0.1, RoundHalfUp); got.Amount() != 100 {
+		t.Errorf("Percent() got = %v, want 100", got.Amount())
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	total := Money{}.Add(New(1050, USD)).Add(New(1050, USD).Mul(2)).Sub(New(150, USD))
+	if !total.Equal(New(3000, USD)) {
+		t.Errorf("got = %v, want 30.00 USD", total)
+	}
+
+	if Min(New(500, USD), New(300, USD)).Amount() != 300 {
+		t.Errorf("Min() did not take the smaller amount")
+	}
+
+	defer func() {
+		if r := recover(); r != ErrCurrencyMismatch {
+			t.Errorf("Add() of different currencies recover = %v, want %v", r, ErrCurrencyMismatch)
+		}
+	}()
+
+	New(100, USD).Add(New(100, EUR))
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: New(1234, USD), want: "12.34"},
+		{money: New(5, USD), want: "0.05"},
+		{money: New(-5, USD), want: "-0.05"},
+		{money: New(1500, JPY), want: "1500"},
+		{money: New(1, "KWD"), want: "0.001"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.money.Decimal(); got != tt.want {
+			t.Errorf("Decimal() got = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(New(1234, USD))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(data) != `{"amount":"12.34","currency":"USD"}` {
+		t.Errorf("Marshal() got = %s", data)
+	}
+
+	tests := []struct {
+		data    string
+		want    Money
+		wantErr bool
+	}{
+		{data: `{"amount":"12.34","currency":"eur"}`, want: New(1234, EUR)},
+		{data: `"12.34"`, want: New(1234, DefaultCurrency)},
+		{data: `12.34`, want: New(1234, DefaultCurrency)},
+		{data: `12.345`, wantErr: true},
+		{data: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("Unmarshal() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
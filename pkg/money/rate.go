@@ -0,0 +1,105 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+)
+
+// rateDecimals is the number of decimals used to format the rate which is not a finite decimal, e.g. the inverse rate.
+const rateDecimals = 12
+
+// Rate is the exact exchange rate, the amount of the quote currency of one major unit of the base currency,
+// e.g. 15750.5 IDR for 1 USD. The zero value is the identity rate.
+type Rate struct {
+	value *big.Rat
+}
+
+// ParseRate parses the positive decimal rate, e.g. "0.92".
+func ParseRate(value string) (Rate, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || r.Sign() <= 0 {
+		return Rate{}, ErrInvalidRate
+	}
+
+	return Rate{value: r}, nil
+}
+
+func (r Rate) rat() *big.Rat {
+	if r.value == nil {
+		return big.NewRat(1, 1)
+	}
+
+	return r.value
+}
+
+// IsIdentity reports whether the rate keeps the amount unchanged.
+func (r Rate) IsIdentity() bool {
+	return r.rat().Cmp(big.NewRat(1, 1)) == 0
+}
+
+// Inverse is the rate of the opposite direction, e.g. the USD for 1 IDR from the IDR for 1 USD.
+func (r Rate) Inverse() Rate {
+	return Rate{value: new(big.Rat).Inv(r.rat())}
+}
+
+// Mul chains the rates, e.g. the EUR to USD rate multiplied by the USD to IDR rate is the EUR to IDR rate.
+func (r Rate) Mul(other Rate) Rate {
+	return Rate{value: new(big.Rat).Mul(r.rat(), other.rat())}
+}
+
+// String formats the rate as decimal without the trailing zeros, the rate which is not
+// a finite decimal is rounded to 12 decimals.
+func (r Rate) String() string {
+	value := r.rat()
+	if exact, ok := value.FloatPrec(); ok {
+		return value.FloatString(exact)
+	}
+
+	formatted := strings.TrimRight(value.FloatString(rateDecimals), "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// MarshalJSON encodes the rate as decimal string.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON decodes the decimal string or the number.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var value string
+	switch raw.(type) {
+	case nil:
+		return nil
+	case string:
+		_ = json.Unmarshal(data, &value)
+	case float64:
+		value = string(data)
+	default:
+		return ErrInvalidRate
+	}
+
+	parsed, err := ParseRate(value)
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
+
+// Convert converts the amount into the currency using the rate, the fraction of the minor unit is rounded.
+func (m Money) Convert(currency Currency, rate Rate, mode RoundingMode) Money {
+	r := new(big.Rat).SetInt64(m.amount)
+	r.Mul(r, rate.rat())
+	r.Mul(r, scale(currency))
+	r.Quo(r, scale(m.currency))
+
+	return Money{amount: round(r, mode), currency: currency}
+}
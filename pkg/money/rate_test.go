@@ -0,0 +1,106 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr error
+	}{
+		{value: "15750.50", want: "15750.5"},
+		{value: "0.92", want: "0.92"},
+		{value: "1", want: "1"},
+		{value: "0", wantErr: ErrInvalidRate},
+		{value: "-1.5", wantErr: ErrInvalidRate},
+		{value: "one", wantErr: ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRate(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseRate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ParseRate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate_Inverse(t *testing.T) {
+	rate, _ := ParseRate("0.8")
+	if got := rate.Inverse().String(); got != "1.25" {
+		t.Errorf("Inverse() got = %v, want 1.25", got)
+	}
+
+	rate, _ = ParseRate("3")
+	if got := rate.Inverse().String(); got != "0.333333333333" {
+		t.Errorf("Inverse() got = %v, want 0.333333333333", got)
+	}
+
+	if got := rate.Mul(rate.Inverse()); !got.IsIdentity() {
+		t.Errorf("Mul() got = %v, want the identity rate", got)
+	}
+
+	if got := (Rate{}).String(); got != "1" {
+		t.Errorf("String() of the zero rate got = %v, want 1", got)
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     Money
+		rate     string
+		currency Currency
+		mode     RoundingMode
+		want     Money
+	}{
+		{name: "rounded half up to the minor unit", from: New(1099, USD), rate: "15750.5", currency: IDR, want: New(17309800, IDR)},
+		{name: "from zero decimals currency", from: New(1500, JPY), rate: "0.0067", currency: USD, want: New(1005, USD)},
+		{name: "rounded down by half up", from: New(1099, USD), rate: "0.92", currency: EUR, want: New(1011, EUR)},
+		{name: "rounded floor", from: New(1099, USD), rate: "0.9205", currency: EUR, mode: RoundFloor, want: New(1011, EUR)},
+		{name: "negative amount", from: New(-1099, USD), rate: "0.92", currency: EUR, want: New(-1011, EUR)},
+		{name: "identity", from: New(1099, USD), rate: "1", currency: USD, want: New(1099, USD)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatalf("ParseRate() error = %v", err)
+			}
+
+			if got := tt.from.Convert(tt.currency, rate, tt.mode); !got.Equal(tt.want) {
+				t.Errorf("Convert() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate_JSON(t *testing.T) {
+	var got struct {
+		Rate Rate `json:"rate"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"rate": 15750.50}`), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	encoded, _ := json.Marshal(got)
+	if string(encoded) != `{"rate":"15750.5"}` {
+		t.Errorf("Marshal() got = %s", encoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"rate": "-2"}`), &got); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("Unmarshal() error = %v, wantErr %v", err, ErrInvalidRate)
+	}
+}
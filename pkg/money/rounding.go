@@ -0,0 +1,63 @@
+package money
+
+import "math/big"
+
+// RoundingMode decides the minor unit of the amount which falls between two minor units.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest minor unit, the half is rounded away from zero.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest minor unit, the half is rounded to the even minor unit.
+	RoundHalfEven
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+)
+
+var roundingModeNames = map[string]RoundingMode{
+	"half_up":   RoundHalfUp,
+	"half_even": RoundHalfEven,
+	"ceiling":   RoundCeiling,
+	"floor":     RoundFloor,
+}
+
+// ParseRoundingMode parses the rounding mode name, i.e. half_up, half_even, ceiling or floor.
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	mode, ok := roundingModeNames[name]
+	if !ok {
+		return 0, ErrInvalidRoundingMode
+	}
+
+	return mode, nil
+}
+
+// round rounds the rational number to the integer using the rounding mode.
+func round(r *big.Rat, mode RoundingMode) int64 {
+	num, denom := r.Num(), r.Denom()
+
+	// the quotient is truncated towards zero, the remainder takes the sign of the numerator.
+	quo, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo.Int64()
+	}
+
+	awayFromZero := false
+	switch mode {
+	case RoundCeiling:
+		awayFromZero = r.Sign() > 0
+	case RoundFloor:
+		awayFromZero = r.Sign() < 0
+	case RoundHalfUp, RoundHalfEven:
+		// compare twice the remainder against the denominator to find out the half.
+		cmp := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(denom)
+		awayFromZero = cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || quo.Bit(0) == 1))
+	}
+
+	if awayFromZero {
+		quo.Add(quo, big.NewInt(int64(r.Sign())))
+	}
+
+	return quo.Int64()
+}
@@ -0,0 +1,10 @@
+package payment
+
+import "errors"
+
+var (
+	ErrGatewayUnregistered = errors.New("gateway not registered")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrInvalidNotification = errors.New("invalid notification")
+	ErrFakeSecretIsEmpty   = errors.New("fake gateway secret is empty")
+)
@@ -0,0 +1,96 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+const GwNameFake GatewayName = "fake"
+
+// FakeSignatureHeader carries the hex encoded HMAC-SHA256 of the notification payload.
+const FakeSignatureHeader = "X-Fake-Signature"
+
+type FakeGatewayConfig struct {
+	// Enabled registers the fake gateway, it settles any payment without charging thus it is meant for
+	// the local and test environments only.
+	Enabled bool
+	Secret  string
+}
+
+// FakeGateway is the local payment gateway, it never calls any provider thus the whole payment flow runs offline.
+// The payment is confirmed by sending the signed notification, see FakeGateway.Sign.
+type FakeGateway struct {
+	config FakeGatewayConfig
+}
+
+// fakeNotification is the notification payload accepted by the fake gateway.
+type fakeNotification struct {
+	Reference string      `json:"reference"`
+	Status    Status      `json:"status"`
+	Amount    money.Money `json:"amount"`
+}
+
+func NewFakeGateway(config FakeGatewayConfig) (*FakeGateway, error) {
+	if config.Secret == "" {
+		return nil, ErrFakeSecretIsEmpty
+	}
+
+	return &FakeGateway{config: config}, nil
+}
+
+func (g *FakeGateway) CreateIntent(_ context.Context, _ IntentParam) (Intent, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return Intent{}, err
+	}
+
+	reference := fmt.Sprintf("fake_%s", id.String())
+
+	return Intent{
+		Reference:  reference,
+		Status:     StatusPending,
+		PaymentURL: fmt.Sprintf("https://fake-gateway.local/pay/%s", reference),
+	}, nil
+}
+
+func (g *FakeGateway) ParseNotification(_ context.Context, header http.Header, payload []byte) (Notification, error) {
+	signature, err := hex.DecodeString(header.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, g.sign(payload)) {
+		return Notification{}, ErrInvalidSignature
+	}
+
+	var notification fakeNotification
+	if err = json.Unmarshal(payload, &notification); err != nil {
+		return Notification{}, ErrInvalidNotification
+	}
+
+	if notification.Reference == "" || (notification.Status != StatusSucceeded && notification.Status != StatusFailed) {
+		return Notification{}, ErrInvalidNotification
+	}
+
+	return Notification{
+		Reference: notification.Reference,
+		Status:    notification.Status,
+		Amount:    notification.Amount,
+	}, nil
+}
+
+// Sign returns the signature header value of the notification payload, as it would be sent by the gateway.
+func (g *FakeGateway) Sign(payload []byte) string {
+	return hex.EncodeToString(g.sign(payload))
+}
+
+func (g *FakeGateway) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(g.config.Secret))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
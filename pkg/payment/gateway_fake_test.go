@@ -0,0 +1,86 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestFakeGateway_CreateIntent(t *testing.T) {
+	g, _ := NewFakeGateway(FakeGatewayConfig{Secret: "secret"})
+
+	got, err := g.CreateIntent(context.Background(), IntentParam{MerchantReference: "1", Amount: money.New(240, money.USD)})
+	if err != nil {
+		t.Fatalf("CreateIntent() error = %v", err)
+	}
+
+	if !strings.HasPrefix(got.Reference, "fake_") || got.Status != StatusPending || !strings.HasSuffix(got.PaymentURL, got.Reference) {
+		t.Errorf("CreateIntent() got = %+v, want pending fake intent", got)
+	}
+}
+
+func TestFakeGateway_ParseNotification(t *testing.T) {
+	g, _ := NewFakeGateway(FakeGatewayConfig{Secret: "secret"})
+
+	signed := func(payload string) http.Header {
+		header := http.Header{}
+		header.Set(FakeSignatureHeader, g.Sign([]byte(payload)))
+		return header
+	}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		payload string
+		want    Notification
+		wantErr error
+	}{
+		{
+			name:    "can parse signed notification",
+			header:  signed(`{"reference":"fake_1","status":"succeeded","amount":2.4}`),
+			payload: `{"reference":"fake_1","status":"succeeded","amount":2.4}`,
+			want:    Notification{Reference: "fake_1", Status: StatusSucceeded, Amount: money.New(240, money.USD)},
+		},
+		{
+			name:    "can reject tampered notification",
+			header:  signed(`{"reference":"fake_1","status":"failed","amount":2.4}`),
+			payload: `{"reference":"fake_1","status":"succeeded","amount":2.4}`,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "can reject unsigned notification",
+			header:  http.Header{},
+			payload: `{"reference":"fake_1","status":"succeeded","amount":2.4}`,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "can reject unknown status",
+			header:  signed(`{"reference":"fake_1","status":"pending"}`),
+			payload: `{"reference":"fake_1","status":"pending"}`,
+			wantErr: ErrInvalidNotification,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.ParseNotification(context.Background(), tt.header, []byte(tt.payload))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseNotification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseNotification() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFakeGateway(t *testing.T) {
+	if _, err := NewFakeGateway(FakeGatewayConfig{Enabled: true}); !errors.Is(err, ErrFakeSecretIsEmpty) {
+		t.Errorf("NewFakeGateway() without the secret error = %v, want %v", err, ErrFakeSecretIsEmpty)
+	}
+}
@@ -0,0 +1,77 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+type Status = string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// IntentParam asks the gateway to collect the amount for the merchant reference, e.g. the order id.
+type IntentParam struct {
+	MerchantReference string
+	Amount            money.Money
+}
+
+// Intent is the payment created on the gateway, the customer completes it using the payment url.
+type Intent struct {
+	Reference  string
+	Status     Status
+	PaymentURL string
+}
+
+// Notification is the payment result sent by the gateway, the payload is verified by the gateway.
+type Notification struct {
+	Reference string
+	Status    Status
+	Amount    money.Money
+}
+
+type Gateway interface {
+	CreateIntent(ctx context.Context, param IntentParam) (Intent, error)
+	ParseNotification(ctx context.Context, header http.Header, payload []byte) (Notification, error)
+}
+
+type Config struct {
+	DefaultGateway string
+}
+
+type GatewayName = string
+
+type Manager struct {
+	gatewaySet map[GatewayName]Gateway
+	config     Config
+}
+
+func NewManager(cfg Config) Manager {
+	return Manager{
+		config:     cfg,
+		gatewaySet: make(map[GatewayName]Gateway),
+	}
+}
+
+func (m Manager) Register(name GatewayName, gateway Gateway) {
+	m.gatewaySet[name] = gateway
+}
+
+// Gateway returns the registered gateway, empty name refers to the default gateway.
+func (m Manager) Gateway(name GatewayName) (GatewayName, Gateway, error) {
+	if name == "" {
+		name = m.config.DefaultGateway
+	}
+
+	gateway, registered := m.gatewaySet[name]
+	if !registered {
+		return name, nil, ErrGatewayUnregistered
+	}
+
+	return name, gateway, nil
+}
@@ -0,0 +1,26 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestManager_Gateway(t *testing.T) {
+	m := NewManager(Config{DefaultGateway: GwNameFake})
+	fake, _ := NewFakeGateway(FakeGatewayConfig{Secret: "secret"})
+	m.Register(GwNameFake, fake)
+
+	name, got, err := m.Gateway("")
+	if err != nil || name != GwNameFake || got != fake {
+		t.Errorf("Gateway() got = %v, %v, %v, want the default fake gateway", name, got, err)
+	}
+
+	// no gateway is the default unless it is configured.
+	if _, _, err = NewManager(Config{}).Gateway(""); !errors.Is(err, ErrGatewayUnregistered) {
+		t.Errorf("Gateway() of the unconfigured default error = %v, want %v", err, ErrGatewayUnregistered)
+	}
+
+	if _, _, err = m.Gateway("unknown"); !errors.Is(err, ErrGatewayUnregistered) {
+		t.Errorf("Gateway() error = %v, want %v", err, ErrGatewayUnregistered)
+	}
+}
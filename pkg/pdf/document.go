@@ -0,0 +1,232 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// the A4 page size in points.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document is the minimal PDF writer of the text and the lines, the coordinates are in points from the top left
+// corner of the page.
+type Document struct {
+	width  float64
+	height float64
+	title  string
+	pages  []*bytes.Buffer
+	fonts  []Font
+}
+
+// New creates the document with the A4 portrait pages.
+func New() *Document {
+	return &Document{
+		width:  A4Width,
+		height: A4Height,
+		fonts:  []Font{FontHelvetica, FontHelveticaBold},
+	}
+}
+
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+func (d *Document) Width() float64 {
+	return d.width
+}
+
+func (d *Document) Height() float64 {
+	return d.height
+}
+
+// AddPage starts a new page, the following drawings are put on it.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// PageCount is the number of the pages added to the document.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+func (d *Document) current() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Document) fontName(font Font) string {
+	for i, f := range d.fonts {
+		if f == font {
+			return "F" + strconv.Itoa(i+1)
+		}
+	}
+
+	return "F1"
+}
+
+// Text draws the text with its baseline at y.
+func (d *Document) Text(x, y float64, font Font, size float64, text string) {
+	page := d.current()
+
+	fmt.Fprintf(page, "BT /%s %s Tf %s %s Td (", d.fontName(font), number(size), number(x), number(d.height-y))
+	page.Write(escape(encode(text)))
+	page.WriteString(") Tj ET\n")
+}
+
+// TextRight draws the text which ends at x.
+func (d *Document) TextRight(x, y float64, font Font, size float64, text string) {
+	d.Text(x-TextWidth(text, font, size), y, font, size, text)
+}
+
+// Line draws the straight line of the width.
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.current(), "%s w %s %s m %s %s l S\n", number(width),
+		number(x1), number(d.height-y1), number(x2), number(d.height-y2))
+}
+
+// WriteTo writes the document in the PDF format, the page contents are compressed.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		return 0, ErrNoPages
+	}
+
+	out := &writer{}
+
+	// the catalog, the pages tree, the fonts and the info dictionary come first, each page takes two objects.
+	pagesObj := 2
+	firstFontObj := 3
+	infoObj := firstFontObj + len(d.fonts)
+	firstPageObj := infoObj + 1
+
+	out.header()
+	out.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := &bytes.Buffer{}
+	for i := range d.pages {
+		fmt.Fprintf(kids, "%d 0 R ", firstPageObj+i*2)
+	}
+	out.object(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(d.pages)))
+
+	fontRefs := &bytes.Buffer{}
+	for i, font := range d.fonts {
+		out.object(firstFontObj+i, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font))
+		fmt.Fprintf(fontRefs, "/F%d %d 0 R ", i+1, firstFontObj+i)
+	}
+
+	out.object(infoObj, fmt.Sprintf("<< /Title (%s) /Producer (example-online-book-store) >>", escape(encode(d.title))))
+
+	for i, page := range d.pages {
+		pageObj := firstPageObj + i*2
+		out.object(pageObj, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			number(d.width), number(d.height), fontRefs, pageObj+1))
+
+		compressed, err := compress(page.Bytes())
+		if err != nil {
+			return 0, err
+		}
+
+		out.stream(pageObj+1, compressed)
+	}
+
+	out.trailer(1, infoObj)
+
+	return out.buf.WriteTo(w)
+}
+
+// Bytes renders the whole document.
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writer keeps the offsets of the objects for the cross-reference table.
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) header() {
+	// the binary comment marks the file as binary for the transfer programs.
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+}
+
+func (w *writer) begin(id int) {
+	for len(w.offsets) < id {
+		w.offsets = append(w.offsets, 0)
+	}
+
+	w.offsets[id-1] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n", id)
+}
+
+func (w *writer) object(id int, body string) {
+	w.begin(id)
+	w.buf.WriteString(body)
+	w.buf.WriteString("\nendobj\n")
+}
+
+func (w *writer) stream(id int, data []byte) {
+	w.begin(id)
+	fmt.Fprintf(&w.buf, "<< /Length %d /Filter /FlateDecode >>\nstream\n", len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *writer) trailer(rootObj, infoObj int) {
+	xref := w.buf.Len()
+
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(w.offsets)+1, rootObj, infoObj, xref)
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// escape escapes the delimiters of the PDF literal string.
+func escape(text []byte) []byte {
+	escaped := make([]byte, 0, len(text))
+	for _, c := range text {
+		if c == '(' || c == ')' || c == '\\' {
+			escaped = append(escaped, '\\')
+		}
+
+		escaped = append(escaped, c)
+	}
+
+	return escaped
+}
+
+// number formats the coordinate with at most two decimals.
+func number(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,98 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := New()
+	doc.SetTitle("Invoice (INV-000001)")
+	doc.Text(40, 60, FontHelveticaBold, 18, "Invoice")
+	doc.TextRight(555, 60, FontHelvetica, 10, "Total 17.24 €")
+	doc.Line(40, 70, 555, 70, 0.5)
+	doc.AddPage()
+	doc.Text(40, 60, FontHelvetica, 10, `Price (excl. \ tax)`)
+
+	got, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+
+	if !bytes.HasPrefix(got, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(got, []byte("%%EOF\n")) {
+		t.Fatalf("Bytes() is not framed as PDF: %q", got)
+	}
+
+	if !bytes.Contains(got, []byte("/Count 2")) {
+		t.Errorf("Bytes() does not have two pages")
+	}
+
+	if !bytes.Contains(got, []byte(`/Title (Invoice \(INV-000001\))`)) {
+		t.Errorf("Bytes() does not have the escaped title")
+	}
+
+	// every entry of the cross-reference table points to the start of its object.
+	xref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(got)
+	if xref == nil {
+		t.Fatalf("Bytes() has no startxref")
+	}
+
+	xrefOffset, _ := strconv.Atoi(string(xref[1]))
+	if !bytes.HasPrefix(got[xrefOffset:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point to the xref table", xrefOffset)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(got[xrefOffset:], -1)
+	if len(entries) != 9 {
+		t.Fatalf("xref has %d objects, want 9", len(entries))
+	}
+
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		want := strconv.Itoa(i+1) + " 0 obj\n"
+		if !bytes.HasPrefix(got[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points to %q, want %q", i+1, got[offset:offset+len(want)], want)
+		}
+	}
+
+	// the last page content is the compressed text with the escaped delimiters.
+	streams := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(got, -1)
+	if len(streams) != 2 {
+		t.Fatalf("Bytes() has %d content streams, want 2", len(streams))
+	}
+
+	first := inflate(t, streams[0][1])
+	if !bytes.Contains(first, []byte("(Total 17.24 \x80) Tj")) {
+		t.Errorf("first page content = %q, want the euro sign encoded", first)
+	}
+
+	last := inflate(t, streams[1][1])
+	if !bytes.Contains(last, []byte(`BT /F1 10 Tf 40 781.89 Td (Price \(excl. \\ tax\)) Tj ET`)) {
+		t.Errorf("last page content = %q", last)
+	}
+}
+
+func TestDocument_Bytes_noPages(t *testing.T) {
+	if _, err := New().Bytes(); !errors.Is(err, ErrNoPages) {
+		t.Errorf("Bytes() error = %v, wantErr %v", err, ErrNoPages)
+	}
+}
+
+func inflate(t *testing.T, data []byte) []byte {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("cannot inflate the stream: %v", err)
+	}
+
+	inflated, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("cannot inflate the stream: %v", err)
+	}
+
+	return inflated
+}
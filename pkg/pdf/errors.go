@@ -0,0 +1,5 @@
+package pdf
+
+import "errors"
+
+var ErrNoPages = errors.New("document has no pages")
@@ -0,0 +1,105 @@
+package pdf
+
+import "strings"
+
+// Font is one of the standard Type 1 fonts, they are available in every PDF reader thus not embedded.
+type Font string
+
+const (
+	FontHelvetica     Font = "Helvetica"
+	FontHelveticaBold Font = "Helvetica-Bold"
+)
+
+// defaultGlyphWidth is the width of the glyph outside the ASCII range.
+const defaultGlyphWidth = 556
+
+// glyphWidths are the widths of the printable ASCII glyphs from the space (32) to the tilde (126),
+// in thousandths of the font size, taken from the Adobe font metrics.
+var glyphWidths = map[Font][95]int{
+	FontHelvetica: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	FontHelveticaBold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// winAnsiSpecials maps the characters of the WinAnsiEncoding outside the Latin-1 range.
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encode converts the text into the WinAnsiEncoding, the characters which cannot be encoded are replaced by "?".
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		case winAnsiSpecials[r] != 0:
+			encoded = append(encoded, winAnsiSpecials[r])
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+
+	return encoded
+}
+
+// TextWidth measures the width of the text in points.
+func TextWidth(text string, font Font, size float64) float64 {
+	widths, ok := glyphWidths[font]
+	if !ok {
+		widths = glyphWidths[FontHelvetica]
+	}
+
+	total := 0
+	for _, c := range encode(text) {
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+			continue
+		}
+
+		total += defaultGlyphWidth
+	}
+
+	return float64(total) * size / 1000
+}
+
+// Wrap breaks the text into the lines which fit the width, the word longer than the width is kept on its own line.
+func Wrap(text string, font Font, size float64, width float64) []string {
+	var lines []string
+	var current string
+
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+
+		if current != "" && TextWidth(candidate, font, size) > width {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+
+		current = candidate
+	}
+
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
@@ -0,0 +1,41 @@
+package pdf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		text string
+		font Font
+		size float64
+		want float64
+	}{
+		{text: "Total", font: FontHelvetica, size: 10, want: 22.23},
+		{text: "Total", font: FontHelveticaBold, size: 10, want: 23.89},
+		{text: "€", font: FontHelvetica, size: 10, want: 5.56},
+		{text: "", font: FontHelvetica, size: 10, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := TextWidth(tt.text, tt.font, tt.size)
+			if got < tt.want-0.01 || got > tt.want+0.01 {
+				t.Errorf("TextWidth() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	got := Wrap("The Hunger Games: Catching Fire", FontHelvetica, 10, 80)
+	want := []string{"The Hunger", "Games: Catching", "Fire"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrap() got = %q, want %q", got, want)
+	}
+
+	if got := Wrap("Supercalifragilistic", FontHelvetica, 10, 20); !reflect.DeepEqual(got, []string{"Supercalifragilistic"}) {
+		t.Errorf("Wrap() of the long word got = %q", got)
+	}
+}
@@ -0,0 +1,37 @@
+package shipping
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+const CalcNameItemCount CalculatorName = "item_count"
+
+type ItemCountCalculatorConfig struct {
+	Rates RegionRates
+}
+
+// ItemCountCalculator charges the base fee plus the per unit fee for every item in the parcel.
+type ItemCountCalculator struct {
+	config ItemCountCalculatorConfig
+}
+
+func NewItemCountCalculator(config ItemCountCalculatorConfig) *ItemCountCalculator {
+	return &ItemCountCalculator{config: config}
+}
+
+func (c *ItemCountCalculator) Calculate(_ context.Context, parcel Parcel) (money.Money, error) {
+	var count int
+	for _, item := range parcel.Items {
+		count += item.Quantity
+	}
+
+	if count <= 0 {
+		return money.Money{}, ErrEmptyParcel
+	}
+
+	rate := c.config.Rates.Of(parcel.Region)
+
+	return rate.BaseFee.Add(rate.PerUnit.Mul(int64(count))), nil
+}
@@ -0,0 +1,70 @@
+package shipping
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestWeightCalculator_Calculate(t *testing.T) {
+	c := NewWeightCalculator(WeightCalculatorConfig{
+		Rates: RegionRates{
+			Default: Rate{BaseFee: money.New(200, money.USD), PerUnit: money.New(150, money.USD)},
+			Regions: map[string]Rate{"bali": {BaseFee: money.New(400, money.USD), PerUnit: money.New(200, money.USD)}},
+		},
+	})
+
+	tests := []struct {
+		name    string
+		parcel  Parcel
+		want    money.Money
+		wantErr error
+	}{
+		{
+			name:   "charges every started kilogram",
+			parcel: Parcel{Region: "jakarta", Items: []Item{{Weight: 600, Quantity: 2}}},
+			want:   money.New(500, money.USD),
+		},
+		{
+			name:   "uses the region rate and default item weight",
+			parcel: Parcel{Region: "Bali", Items: []Item{{Weight: 0, Quantity: 2}, {Weight: 200, Quantity: 1}}},
+			want:   money.New(600, money.USD),
+		},
+		{
+			name:    "empty parcel",
+			parcel:  Parcel{Region: "jakarta"},
+			wantErr: ErrEmptyParcel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Calculate(context.Background(), tt.parcel)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Calculate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("Calculate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemCountCalculator_Calculate(t *testing.T) {
+	c := NewItemCountCalculator(ItemCountCalculatorConfig{
+		Rates: RegionRates{Default: Rate{BaseFee: money.New(200, money.USD), PerUnit: money.New(50, money.USD)}},
+	})
+
+	got, err := c.Calculate(context.Background(), Parcel{Items: []Item{{Quantity: 2}, {Quantity: 1}}})
+	if err != nil || !got.Equal(money.New(350, money.USD)) {
+		t.Errorf("Calculate() got = %v, %v, want 3.50 USD", got, err)
+	}
+
+	if _, err = c.Calculate(context.Background(), Parcel{}); !errors.Is(err, ErrEmptyParcel) {
+		t.Errorf("Calculate() error = %v, want %v", err, ErrEmptyParcel)
+	}
+}
@@ -0,0 +1,53 @@
+package shipping
+
+import (
+	"context"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+const CalcNameWeight CalculatorName = "weight"
+
+// calcWeightDefaultItemWeight is used for items without known weight, roughly a 300 pages paperback.
+const calcWeightDefaultItemWeight = 400
+
+type WeightCalculatorConfig struct {
+	Rates RegionRates
+	// DefaultItemWeight in grams, used for items without weight.
+	DefaultItemWeight int
+}
+
+// WeightCalculator charges the base fee plus the per unit fee for every started kilogram of the parcel.
+type WeightCalculator struct {
+	config WeightCalculatorConfig
+}
+
+func NewWeightCalculator(config WeightCalculatorConfig) *WeightCalculator {
+	if config.DefaultItemWeight <= 0 {
+		config.DefaultItemWeight = calcWeightDefaultItemWeight
+	}
+
+	return &WeightCalculator{config: config}
+}
+
+func (c *WeightCalculator) Calculate(_ context.Context, parcel Parcel) (money.Money, error) {
+	var grams int
+	for _, item := range parcel.Items {
+		weight := item.Weight
+		if weight <= 0 {
+			weight = c.config.DefaultItemWeight
+		}
+
+		grams += weight * item.Quantity
+	}
+
+	if grams <= 0 {
+		return money.Money{}, ErrEmptyParcel
+	}
+
+	// every started kilogram is charged.
+	kilograms := int64((grams + 999) / 1000)
+	rate := c.config.Rates.Of(parcel.Region)
+
+	return rate.BaseFee.Add(rate.PerUnit.Mul(kilograms)), nil
+}
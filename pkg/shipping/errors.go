@@ -0,0 +1,8 @@
+package shipping
+
+import "errors"
+
+var (
+	ErrCalculatorUnregistered = errors.New("calculator not registered")
+	ErrEmptyParcel            = errors.New("empty parcel")
+)
@@ -0,0 +1,96 @@
+package shipping
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+// Item is a parcel content, weight is in grams per unit.
+type Item struct {
+	Weight   int
+	Quantity int
+}
+
+// Parcel is the shipment of the order items to the destination region.
+type Parcel struct {
+	Region string
+	Items  []Item
+}
+
+// Rate is the fee charged for the destination region, per unit is charged for every kilogram or item
+// depending on the calculator.
+type Rate struct {
+	BaseFee money.Money
+	PerUnit money.Money
+}
+
+// RegionRates holds the rates keyed by the lower-cased region, the default rate is used for unlisted region.
+type RegionRates struct {
+	Default Rate
+	Regions map[string]Rate
+}
+
+func (r RegionRates) Of(region string) Rate {
+	rate, listed := r.Regions[strings.ToLower(strings.TrimSpace(region))]
+	if !listed {
+		return r.Default
+	}
+
+	return rate
+}
+
+type Calculator interface {
+	Calculate(ctx context.Context, parcel Parcel) (money.Money, error)
+}
+
+type Config struct {
+	DefaultCalculator string
+}
+
+type CalculatorName = string
+
+type Manager struct {
+	calculatorSet map[CalculatorName]Calculator
+	config        Config
+}
+
+func NewManager(cfg Config) Manager {
+	if cfg.DefaultCalculator == "" {
+		cfg.DefaultCalculator = CalcNameWeight
+	}
+
+	return Manager{
+		config:        cfg,
+		calculatorSet: make(map[CalculatorName]Calculator),
+	}
+}
+
+func (m Manager) Register(name CalculatorName, calculator Calculator) {
+	m.calculatorSet[name] = calculator
+}
+
+// Calculator returns the registered calculator, empty name refers to the default calculator.
+func (m Manager) Calculator(name CalculatorName) (Calculator, error) {
+	if name == "" {
+		name = m.config.DefaultCalculator
+	}
+
+	calculator, registered := m.calculatorSet[name]
+	if !registered {
+		return nil, ErrCalculatorUnregistered
+	}
+
+	return calculator, nil
+}
+
+// Calculate calculates the shipping fee using the default calculator.
+func (m Manager) Calculate(ctx context.Context, parcel Parcel) (money.Money, error) {
+	calculator, err := m.Calculator("")
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return calculator.Calculate(ctx, parcel)
+}
@@ -0,0 +1,38 @@
+package shipping
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
+)
+
+func TestManager_Calculator(t *testing.T) {
+	m := NewManager(Config{})
+	weight := NewWeightCalculator(WeightCalculatorConfig{})
+	m.Register(CalcNameWeight, weight)
+
+	got, err := m.Calculator("")
+	if err != nil || got != weight {
+		t.Errorf("Calculator() got = %v, %v, want the default weight calculator", got, err)
+	}
+
+	if _, err = m.Calculator(CalcNameItemCount); !errors.Is(err, ErrCalculatorUnregistered) {
+		t.Errorf("Calculator() error = %v, want %v", err, ErrCalculatorUnregistered)
+	}
+}
+
+func TestRegionRates_Of(t *testing.T) {
+	rates := RegionRates{
+		Default: Rate{BaseFee: money.New(200, money.USD), PerUnit: money.New(100, money.USD)},
+		Regions: map[string]Rate{"bali": {BaseFee: money.New(500, money.USD), PerUnit: money.New(300, money.USD)}},
+	}
+
+	if got := rates.Of(" Bali "); got != rates.Regions["bali"] {
+		t.Errorf("Of() got = %v, want %v", got, rates.Regions["bali"])
+	}
+
+	if got := rates.Of("jakarta"); got != rates.Default {
+		t.Errorf("Of() got = %v, want %v", got, rates.Default)
+	}
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"github.com/go-playground/validator/v10"
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 	"reflect"
 	"strings"
 )
@@ -19,6 +20,11 @@ func SetUp() {
 		}
 		return name
 	})
+
+	// money is validated by its minor unit amount, e.g. gt=0 requires a positive amount.
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.Interface().(money.Money).Amount()
+	}, money.Money{})
 }
 
 func Struct(any any) error {
@@ -2,13 +2,21 @@ package validator
 
 import (
 	"testing"
+
+	"github.com/rendyananta/example-online-book-store/pkg/money"
 )
 
 type TestValidation struct {
 	Name string `validate:"required"`
 }
 
+type TestMoneyValidation struct {
+	Price money.Money `validate:"gt=0"`
+}
+
 func TestStruct(t *testing.T) {
+	SetUp()
+
 	type args struct {
 		any any
 	}
@@ -24,6 +32,20 @@ func TestStruct(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "money validation",
+			args: args{
+				any: TestMoneyValidation{Price: money.New(-100, money.USD)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid money",
+			args: args{
+				any: TestMoneyValidation{Price: money.New(100, money.USD)},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
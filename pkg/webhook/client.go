@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTimeout         = 10 * time.Second
+	defaultMaxResponseSize = 1024
+	userAgent              = "online-book-store-webhook/1.0"
+)
+
+type ClientConfig struct {
+	// Timeout limits the whole request, including reading the response.
+	Timeout time.Duration
+	// MaxResponseSize is the number of the response body bytes kept for the delivery log.
+	MaxResponseSize int64
+}
+
+// Request is the single delivery of the payload to the receiver.
+type Request struct {
+	URL        string
+	Secret     string
+	EventID    string
+	EventType  string
+	DeliveryID string
+	Payload    []byte
+}
+
+// Response is what the receiver answered, the body is cut to the configured size.
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// Client posts the signed payloads to the webhook receivers.
+type Client struct {
+	config     ClientConfig
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func NewClient(config ClientConfig) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	if config.MaxResponseSize <= 0 {
+		config.MaxResponseSize = defaultMaxResponseSize
+	}
+
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+			// the redirect is answered as is, the payload is only sent to the registered URL.
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Send posts the payload signed with the secret. The response of the status other than 2xx is returned
+// along with ErrUnexpectedStatus.
+func (c *Client) Send(ctx context.Context, request Request) (Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Payload))
+	if err != nil {
+		return Response{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, request.EventType)
+	req.Header.Set(HeaderEventID, request.EventID)
+	req.Header.Set(HeaderDelivery, request.DeliveryID)
+	req.Header.Set(HeaderSignature, Sign(request.Secret, c.now(), request.Payload))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.config.MaxResponseSize))
+	if err != nil {
+		return Response{StatusCode: resp.StatusCode}, err
+	}
+
+	response := Response{StatusCode: resp.StatusCode, Body: string(body)}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return response, ErrUnexpectedStatus
+	}
+
+	return response, nil
+}
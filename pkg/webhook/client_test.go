@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Send(t *testing.T) {
+	var received *http.Request
+	var receivedBody []byte
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+
+		if err := Verify("secret", r.Header.Get(HeaderSignature), receivedBody, time.Minute, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/failing":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("queued"))
+		}
+	}))
+	defer receiver.Close()
+
+	client := NewClient(ClientConfig{MaxResponseSize: 16})
+	request := Request{
+		Secret:     "secret",
+		EventID:    "event-1",
+		EventType:  "order.placed",
+		DeliveryID: "delivery-1",
+		Payload:    []byte(`{"id":"event-1"}`),
+	}
+
+	t.Run("delivered", func(t *testing.T) {
+		request.URL = receiver.URL + "/ok"
+		got, err := client.Send(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+
+		if got.StatusCode != http.StatusAccepted || got.Body != "queued" {
+			t.Errorf("Send() got = %+v", got)
+		}
+
+		if received.Method != http.MethodPost || received.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("received %s with content type %s", received.Method, received.Header.Get("Content-Type"))
+		}
+
+		if received.Header.Get(HeaderEvent) != "order.placed" || received.Header.Get(HeaderEventID) != "event-1" ||
+			received.Header.Get(HeaderDelivery) != "delivery-1" {
+			t.Errorf("received headers = %v", received.Header)
+		}
+
+		if string(receivedBody) != `{"id":"event-1"}` {
+			t.Errorf("received body = %s", receivedBody)
+		}
+	})
+
+	t.Run("rejected signature", func(t *testing.T) {
+		request := request
+		request.URL = receiver.URL + "/ok"
+		request.Secret = "other"
+
+		got, err := client.Send(context.Background(), request)
+		if !errors.Is(err, ErrUnexpectedStatus) || got.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Send() got = %+v, error = %v", got, err)
+		}
+	})
+
+	t.Run("failing receiver keeps the cut response", func(t *testing.T) {
+		request.URL = receiver.URL + "/failing"
+		got, err := client.Send(context.Background(), request)
+		if !errors.Is(err, ErrUnexpectedStatus) {
+			t.Fatalf("Send() error = %v, wantErr %v", err, ErrUnexpectedStatus)
+		}
+
+		if got.StatusCode != http.StatusServiceUnavailable || len(got.Body) != 16 {
+			t.Errorf("Send() got status %d with %d bytes body", got.StatusCode, len(got.Body))
+		}
+	})
+
+	t.Run("redirect is not followed", func(t *testing.T) {
+		request.URL = receiver.URL + "/moved"
+		got, err := client.Send(context.Background(), request)
+		if !errors.Is(err, ErrUnexpectedStatus) || got.StatusCode != http.StatusFound {
+			t.Errorf("Send() got = %+v, error = %v", got, err)
+		}
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+
+		request.URL = unreachable.URL
+		if _, err := client.Send(context.Background(), request); err == nil || errors.Is(err, ErrUnexpectedStatus) {
+			t.Errorf("Send() error = %v, want the transport error", err)
+		}
+	})
+}
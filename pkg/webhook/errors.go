@@ -0,0 +1,9 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrSignatureExpired = errors.New("webhook signature expired")
+	ErrUnexpectedStatus = errors.New("webhook receiver responded with unexpected status")
+)
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderSignature carries the timestamp and the signature of the payload, formatted as "t=<unix>,v1=<hex>".
+	HeaderSignature = "X-Webhook-Signature"
+	// HeaderEvent carries the event type of the payload, e.g. "order.placed".
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderEventID carries the event ID, it is kept on the redelivery thus the receiver recognizes the duplicate.
+	HeaderEventID = "X-Webhook-Event-Id"
+	// HeaderDelivery carries the delivery ID.
+	HeaderDelivery = "X-Webhook-Delivery"
+)
+
+const signatureVersion = "v1"
+
+// Sign returns the signature header value of the payload sent at the given time. The signature is the hex encoded
+// HMAC-SHA256 of the unix timestamp and the payload joined by a dot, thus the old request cannot be replayed
+// with the fresh timestamp.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	return "t=" + unix + "," + signatureVersion + "=" + hex.EncodeToString(sign(secret, unix, payload))
+}
+
+// Verify checks the signature header value of the payload, the signature older than the tolerance is rejected.
+// The zero tolerance accepts the signature of any age.
+func Verify(secret, header string, payload []byte, tolerance time.Duration, now time.Time) error {
+	var unix string
+	var signatures [][]byte
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "t":
+			unix = value
+		case signatureVersion:
+			signature, err := hex.DecodeString(value)
+			if err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := sign(secret, unix, payload)
+
+	valid := false
+	for _, signature := range signatures {
+		if hmac.Equal(signature, expected) {
+			valid = true
+			break
+		}
+	}
+
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 && now.Sub(time.Unix(seconds, 0)).Abs() > tolerance {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+func sign(secret, unix string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
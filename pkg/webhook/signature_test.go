@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"id":"1","type":"order.placed"}`)
+	signedAt := time.Unix(1700000000, 0)
+	header := Sign("secret", signedAt, payload)
+
+	tests := []struct {
+		name      string
+		secret    string
+		header    string
+		payload   []byte
+		tolerance time.Duration
+		now       time.Time
+		wantErr   error
+	}{
+		{name: "valid signature", secret: "secret", header: header, payload: payload, tolerance: 5 * time.Minute, now: signedAt.Add(time.Minute)},
+		{name: "valid signature of any age", secret: "secret", header: header, payload: payload, now: signedAt.Add(24 * time.Hour)},
+		{name: "among multiple signatures", secret: "secret", header: header + ",v1=00ff", payload: payload, now: signedAt},
+		{name: "other secret", secret: "other", header: header, payload: payload, now: signedAt, wantErr: ErrInvalidSignature},
+		{name: "tampered payload", secret: "secret", header: header, payload: []byte(`{"id":"2"}`), now: signedAt, wantErr: ErrInvalidSignature},
+		{name: "tampered timestamp", secret: "secret", header: strings.Replace(header, "t=1700000000", "t=1700000300", 1), payload: payload, now: signedAt, wantErr: ErrInvalidSignature},
+		{name: "missing signature", secret: "secret", header: "t=1700000000", payload: payload, now: signedAt, wantErr: ErrInvalidSignature},
+		{name: "malformed header", secret: "secret", header: "garbage", payload: payload, now: signedAt, wantErr: ErrInvalidSignature},
+		{name: "expired signature", secret: "secret", header: header, payload: payload, tolerance: 5 * time.Minute, now: signedAt.Add(6 * time.Minute), wantErr: ErrSignatureExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Verify(tt.secret, tt.header, tt.payload, tt.tolerance, tt.now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	got := Sign("secret", time.Unix(1700000000, 0), []byte("{}"))
+	if !strings.HasPrefix(got, "t=1700000000,v1=") || len(got) != len("t=1700000000,v1=")+64 {
+		t.Errorf("Sign() got = %v", got)
+	}
+}
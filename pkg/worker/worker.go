@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// Config is the config of the worker taking the queued jobs in batches, the failed job is retried with the
+// exponential backoff until it runs out of the attempts.
+type Config struct {
+	// PollInterval is how often the queue is checked for the due jobs.
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts is the number of the attempts before the job is given up.
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, it doubles on each following retry up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// ClaimTimeout is how long the job is hidden from the other workers while it is processed.
+	ClaimTimeout time.Duration
+}
+
+// WithDefaults fills the unset fields of the config by the defaults.
+func (c Config) WithDefaults(defaults Config) Config {
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaults.PollInterval
+	}
+
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaults.BatchSize
+	}
+
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaults.MaxAttempts
+	}
+
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = defaults.RetryBackoff
+	}
+
+	if c.MaxRetryBackoff <= 0 {
+		c.MaxRetryBackoff = defaults.MaxRetryBackoff
+	}
+
+	if c.ClaimTimeout <= 0 {
+		c.ClaimTimeout = defaults.ClaimTimeout
+	}
+
+	return c
+}
+
+// Backoff is the delay before the next attempt, it doubles after each failed attempt.
+func (c Config) Backoff(attempts int) time.Duration {
+	delay := c.RetryBackoff
+	for i := 1; i < attempts && delay < c.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, c.MaxRetryBackoff)
+}
+
+// NextAttempt returns when the job is tried again after its failed attempts, it is false when the job runs out of
+// the attempts and is given up.
+func (c Config) NextAttempt(now time.Time, attempts int) (time.Time, bool) {
+	if attempts >= c.MaxAttempts {
+		return time.Time{}, false
+	}
+
+	return now.Add(c.Backoff(attempts)), true
+}
+
+// Poll runs the batch until the context is done, the batch returns the number of the jobs it takes from the queue.
+// The full batch is followed right away by the next one, otherwise the next batch waits for the poll interval.
+func Poll(ctx context.Context, config Config, name string, batch func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		taken, err := batch(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("failed to process the batch", slog.String("worker", name), slog.String("error", err.Error()))
+		}
+
+		if taken >= config.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessClaimed processes the jobs of the batch one by one, the job claimed by another worker meanwhile is skipped.
+// It stops at the first error, the jobs left are taken again by the following batch.
+func ProcessClaimed[T any](ctx context.Context, jobs []T, claim func(ctx context.Context, job T) (bool, error),
+	process func(ctx context.Context, job T) error) error {
+	for _, job := range jobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		claimed, err := claim(ctx, job)
+		if err != nil {
+			return err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		if err = process(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
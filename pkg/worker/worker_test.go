@@ -0,0 +1,147 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig_WithDefaults(t *testing.T) {
+	defaults := Config{
+		PollInterval:    time.Second,
+		BatchSize:       50,
+		MaxAttempts:     10,
+		RetryBackoff:    5 * time.Second,
+		MaxRetryBackoff: time.Hour,
+		ClaimTimeout:    time.Minute,
+	}
+
+	got := Config{BatchSize: 10, RetryBackoff: -time.Second}.WithDefaults(defaults)
+
+	want := defaults
+	want.BatchSize = 10
+
+	if got != want {
+		t.Errorf("WithDefaults() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestConfig_Backoff(t *testing.T) {
+	config := Config{RetryBackoff: time.Second, MaxRetryBackoff: time.Minute}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 5, want: 16 * time.Second},
+		{attempts: 7, want: time.Minute},
+		{attempts: 100, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := config.Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) got = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_NextAttempt(t *testing.T) {
+	config := Config{MaxAttempts: 3, RetryBackoff: time.Second, MaxRetryBackoff: time.Minute}
+	now := time.Date(2024, 10, 6, 10, 0, 0, 0, time.UTC)
+
+	if got, retry := config.NextAttempt(now, 2); !retry || !got.Equal(now.Add(2*time.Second)) {
+		t.Errorf("NextAttempt() got = %v, %v, want %v, true", got, retry, now.Add(2*time.Second))
+	}
+
+	if _, retry := config.NextAttempt(now, 3); retry {
+		t.Errorf("NextAttempt() retries the job which runs out of the attempts")
+	}
+}
+
+func TestPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// the full batches are taken right away, the batch after the partial one waits for the poll interval.
+	taken := []int{2, 2, 1}
+	batches := make(chan int, len(taken))
+
+	done := make(chan struct{})
+	go func() {
+		Poll(ctx, Config{PollInterval: time.Hour, BatchSize: 2}, "test", func(ctx context.Context) (int, error) {
+			n := taken[len(batches)]
+			batches <- n
+			return n, errors.New("database is locked")
+		})
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Poll() did not stop after the context is done")
+	}
+
+	if len(batches) != len(taken) {
+		t.Errorf("Poll() ran %d batches, want %d", len(batches), len(taken))
+	}
+}
+
+func TestProcessClaimed(t *testing.T) {
+	errProcess := errors.New("process failed")
+
+	tests := []struct {
+		name          string
+		jobs          []string
+		claimed       map[string]bool
+		failing       string
+		wantProcessed []string
+		wantErr       error
+	}{
+		{
+			name:          "skip the job claimed by another worker",
+			jobs:          []string{"1", "2", "3"},
+			claimed:       map[string]bool{"1": true, "3": true},
+			wantProcessed: []string{"1", "3"},
+		},
+		{
+			name:          "stop at the failed job",
+			jobs:          []string{"1", "2", "3"},
+			claimed:       map[string]bool{"1": true, "2": true, "3": true},
+			failing:       "2",
+			wantProcessed: []string{"1", "2"},
+			wantErr:       errProcess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var processed []string
+
+			err := ProcessClaimed(context.Background(), tt.jobs, func(ctx context.Context, job string) (bool, error) {
+				return tt.claimed[job], nil
+			}, func(ctx context.Context, job string) error {
+				processed = append(processed, job)
+				if job == tt.failing {
+					return errProcess
+				}
+
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ProcessClaimed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(processed, tt.wantProcessed) {
+				t.Errorf("ProcessClaimed() processed = %v, want %v", processed, tt.wantProcessed)
+			}
+		})
+	}
+}